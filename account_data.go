@@ -0,0 +1,102 @@
+package chatwork
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// AccountData is the data authored by a single account, as collected by
+// Client.ExtractAccountData. It is designed to be serialized as JSON when
+// answering data-subject access requests.
+type AccountData struct {
+	AccountID   AccountID          `json:"account_id"`
+	CollectedAt time.Time          `json:"collected_at"`
+	Rooms       []*AccountRoomData `json:"rooms"`
+}
+
+// AccountRoomData is the part of AccountData found in a single room.
+type AccountRoomData struct {
+	RoomID   RoomID     `json:"room_id"`
+	RoomName string     `json:"room_name"`
+	Messages []*Message `json:"messages,omitempty"`
+	Tasks    []*Task    `json:"tasks,omitempty"`
+	Files    []*File    `json:"files,omitempty"`
+}
+
+// ExtractAccountData collects the messages, tasks, and files authored by
+// accountID in every room the authenticated user can access.
+//
+// Messages are limited to what the API returns for each room, which is the
+// most recent 100. Tasks are those assigned by the account, and files are
+// those uploaded by it. Rooms without any such data are omitted.
+//
+// Failures in individual rooms do not stop the extraction. They are joined
+// into the returned error, together with the data that could be collected.
+func (c *Client) ExtractAccountData(ctx context.Context, accountID AccountID, opts ...RequestOption) (*AccountData, error) {
+	rooms, _, err := c.Rooms.List(ctx, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	data := &AccountData{
+		AccountID:   accountID,
+		CollectedAt: time.Now(),
+	}
+
+	var errs []error
+	for _, room := range rooms {
+		roomData, err := c.extractRoomData(ctx, room, accountID, opts)
+		if err != nil {
+			if ctx.Err() != nil {
+				return data, ctx.Err()
+			}
+			errs = append(errs, fmt.Errorf("room %d: %w", room.RoomID, err))
+		}
+		if roomData != nil {
+			data.Rooms = append(data.Rooms, roomData)
+		}
+	}
+
+	return data, errors.Join(errs...)
+}
+
+// extractRoomData collects the data authored by accountID in a single room.
+// It returns nil data when the account has not authored anything there.
+func (c *Client) extractRoomData(ctx context.Context, room *Room, accountID AccountID, opts []RequestOption) (*AccountRoomData, error) {
+	roomData := &AccountRoomData{
+		RoomID:   room.RoomID,
+		RoomName: room.Name,
+	}
+
+	messages, _, err := c.Messages.List(ctx, room.RoomID, &MessageListParams{Force: 1}, opts...)
+	if err != nil {
+		return nil, err
+	}
+	for _, message := range messages {
+		if message.Account.AccountID == accountID {
+			roomData.Messages = append(roomData.Messages, message)
+		}
+	}
+
+	roomData.Tasks, _, err = c.Rooms.GetTasks(ctx, room.RoomID, &TaskListParams{AssignedByAccountID: accountID}, opts...)
+	if err != nil {
+		return roomData.orNil(), err
+	}
+
+	roomData.Files, _, err = c.Rooms.GetFiles(ctx, room.RoomID, accountID, opts...)
+	if err != nil {
+		return roomData.orNil(), err
+	}
+
+	return roomData.orNil(), nil
+}
+
+// orNil returns d, or nil if it holds no data.
+func (d *AccountRoomData) orNil() *AccountRoomData {
+	if len(d.Messages) == 0 && len(d.Tasks) == 0 && len(d.Files) == 0 {
+		return nil
+	}
+	return d
+}
@@ -0,0 +1,62 @@
+package chatwork_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/nashirox/chatwork-go"
+	"github.com/nashirox/chatwork-go/chatworktest"
+)
+
+func TestClient_ExtractAccountData(t *testing.T) {
+	srv := chatworktest.NewServer()
+	defer srv.Close()
+
+	const subject = chatwork.AccountID(200)
+	alice := chatwork.User{AccountID: subject, Name: "Alice"}
+	bob := chatwork.User{AccountID: 300, Name: "Bob"}
+
+	project := srv.AddRoom(chatwork.Room{Name: "Project"})
+	srv.AddMessage(project, chatwork.Message{Account: bob, Body: "Bob's message"})
+	message := srv.AddMessage(project, chatwork.Message{Account: alice, Body: "Alice's message"})
+	task := srv.AddTask(project, chatwork.Task{Account: bob, AssignedByAccount: alice, Body: "Assigned by Alice"})
+	srv.AddTask(project, chatwork.Task{Account: alice, AssignedByAccount: bob, Body: "Assigned to Alice"})
+	file := srv.AddFile(project, chatwork.File{Account: alice, Filename: "alice.pdf"}, []byte("a"))
+	srv.AddFile(project, chatwork.File{Account: bob, Filename: "bob.pdf"}, []byte("b"))
+
+	other := srv.AddRoom(chatwork.Room{Name: "Other"})
+	srv.AddMessage(other, chatwork.Message{Account: bob, Body: "Unrelated"})
+	srv.AddTask(other, chatwork.Task{Account: alice, AssignedByAccount: bob, Body: "Assigned to Alice"})
+
+	broken := srv.AddRoom(chatwork.Room{Name: "Broken"})
+	srv.AddMessage(broken, chatwork.Message{Account: alice, Body: "Before the outage"})
+	srv.Fail("GET", "rooms/"+broken.String()+"/files", http.StatusInternalServerError)
+
+	data, err := srv.Client().ExtractAccountData(context.Background(), subject)
+	if !errors.Is(err, chatwork.ErrServerError) {
+		t.Errorf("Expected the failing room to be reported, got %v", err)
+	}
+	if data.AccountID != subject || len(data.Rooms) != 2 {
+		t.Fatalf("Expected data from 2 rooms, got %+v", data)
+	}
+
+	got := data.Rooms[0]
+	if got.RoomID != project || got.RoomName != "Project" {
+		t.Errorf("Expected the project room first, got %d %q", got.RoomID, got.RoomName)
+	}
+	if len(got.Messages) != 1 || got.Messages[0].MessageID != message {
+		t.Errorf("Expected only Alice's message, got %+v", got.Messages)
+	}
+	if len(got.Tasks) != 1 || got.Tasks[0].TaskID != task {
+		t.Errorf("Expected only the task assigned by Alice, got %+v", got.Tasks)
+	}
+	if len(got.Files) != 1 || got.Files[0].FileID != file {
+		t.Errorf("Expected only Alice's file, got %+v", got.Files)
+	}
+
+	if got := data.Rooms[1]; got.RoomID != broken || len(got.Messages) != 1 || got.Files != nil {
+		t.Errorf("Expected the messages collected before the failure, got %+v", got)
+	}
+}
@@ -0,0 +1,284 @@
+package chatwork
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+var (
+	emailPattern = regexp.MustCompile(`[A-Za-z0-9._%+\-]+@[A-Za-z0-9.\-]+\.[A-Za-z]{2,}`)
+	phonePattern = regexp.MustCompile(`(?:\+\d{1,3}[\s\-]?)?\(?\d{2,4}\)?[\s\-]\d{2,4}[\s\-]\d{3,4}`)
+
+	// accountRefPattern matches notation tags that reference an account ID.
+	accountRefPattern = regexp.MustCompile(`(?i)(\[To:|\[piconname:|\[picon:|aid=)(\d+)`)
+
+	// addresseePattern matches a [To:…] or [rp …] tag and the display name
+	// that ChatWork clients write after it, up to the line end or next tag.
+	addresseePattern = regexp.MustCompile(`(?i)(\[To:\d+\]|\[rp aid=\d+[^\]]*\])([ \t]*)([^\[\n]*)`)
+)
+
+// Anonymizer removes personal data from messages before they leave the organization,
+// for example when exports are shared with analysts.
+//
+// Account IDs are replaced with stable pseudonyms derived from Salt, so the
+// same person maps to the same pseudonym across an export while the real ID
+// cannot be recovered without the salt. E-mail addresses, phone numbers,
+// the names written after mentions and replies, and the configured keywords
+// are redacted from message bodies.
+//
+// Create an Anonymizer with NewAnonymizer. One with an empty Salt uses a
+// random salt of its own, so that its pseudonyms cannot be reversed by
+// hashing every account ID; they are then only stable within it.
+type Anonymizer struct {
+	// Secret mixed into account ID pseudonyms. Use a different salt per export
+	// to prevent pseudonyms from being linked across exports.
+	Salt string
+
+	// Case-insensitive words or phrases to redact from bodies. They are
+	// compiled on first use and must not be changed afterwards.
+	Keywords []string
+
+	// Text inserted in place of redacted data. Defaults to "[redacted]".
+	Replacement string
+
+	keywordsOnce    sync.Once
+	keywordPatterns []*regexp.Regexp
+
+	saltOnce sync.Once
+	key      []byte
+}
+
+// NewAnonymizer returns an Anonymizer that derives pseudonyms from salt and
+// redacts keywords. The salt must not be empty.
+func NewAnonymizer(salt string, keywords ...string) (*Anonymizer, error) {
+	if salt == "" {
+		return nil, fmt.Errorf("%w: anonymizer salt is required", ErrInvalidParams)
+	}
+	return &Anonymizer{Salt: salt, Keywords: keywords}, nil
+}
+
+// AccountID returns the pseudonym for an account ID. Zero is left unchanged.
+func (a *Anonymizer) AccountID(id AccountID) AccountID {
+	if id == 0 {
+		return 0
+	}
+
+	mac := hmac.New(sha256.New, a.salt())
+	mac.Write([]byte(id.String()))
+	sum := mac.Sum(nil)
+
+	pseudonym := AccountID(binary.BigEndian.Uint32(sum) & 0x7fffffff)
+	if pseudonym == 0 {
+		pseudonym = 1
+	}
+	return pseudonym
+}
+
+// salt returns the key of the pseudonyms: Salt, or a random salt if it is
+// empty.
+func (a *Anonymizer) salt() []byte {
+	a.saltOnce.Do(func() {
+		if a.Salt != "" {
+			a.key = []byte(a.Salt)
+			return
+		}
+		a.key = make([]byte, 32)
+		if _, err := rand.Read(a.key); err != nil {
+			panic("chatwork: cannot generate anonymizer salt: " + err.Error())
+		}
+	})
+	return a.key
+}
+
+// Text redacts e-mail addresses, phone numbers, and keywords in s, as well as
+// the names written after [To:123] and [rp …] tags, and replaces account IDs
+// referenced by notation tags with pseudonyms.
+func (a *Anonymizer) Text(s string) string {
+	replacement := a.replacement()
+
+	s = addresseePattern.ReplaceAllStringFunc(s, func(tag string) string {
+		m := addresseePattern.FindStringSubmatch(tag)
+		if strings.TrimSpace(m[3]) == "" {
+			return tag
+		}
+		return m[1] + m[2] + replacement
+	})
+
+	s = accountRefPattern.ReplaceAllStringFunc(s, func(ref string) string {
+		m := accountRefPattern.FindStringSubmatch(ref)
+		id, err := strconv.Atoi(m[2])
+		if err != nil {
+			return ref
+		}
+		return m[1] + a.AccountID(AccountID(id)).String()
+	})
+	s = emailPattern.ReplaceAllString(s, replacement)
+	s = phonePattern.ReplaceAllString(s, replacement)
+
+	return a.redactKeywords(s, replacement)
+}
+
+// redactKeywords replaces the keywords in s, leaving the account references
+// of notation tags intact so that a numeric keyword does not rewrite their
+// pseudonyms.
+func (a *Anonymizer) redactKeywords(s, replacement string) string {
+	a.keywordsOnce.Do(func() {
+		for _, keyword := range a.Keywords {
+			if keyword != "" {
+				a.keywordPatterns = append(a.keywordPatterns, regexp.MustCompile(`(?i)`+regexp.QuoteMeta(keyword)))
+			}
+		}
+	})
+	if len(a.keywordPatterns) == 0 {
+		return s
+	}
+
+	redact := func(text string) string {
+		for _, pattern := range a.keywordPatterns {
+			text = pattern.ReplaceAllString(text, replacement)
+		}
+		return text
+	}
+	var b strings.Builder
+	last := 0
+	for _, ref := range accountRefPattern.FindAllStringIndex(s, -1) {
+		b.WriteString(redact(s[last:ref[0]]))
+		b.WriteString(s[ref[0]:ref[1]])
+		last = ref[1]
+	}
+	b.WriteString(redact(s[last:]))
+	return b.String()
+}
+
+// User returns an anonymized copy of u that keeps only the pseudonymous
+// account ID, a derived display name, and the room ID.
+func (a *Anonymizer) User(u User) User {
+	id := a.AccountID(u.AccountID)
+	return User{
+		AccountID: id,
+		RoomID:    u.RoomID,
+		Name:      "user-" + id.String(),
+	}
+}
+
+// Message returns an anonymized copy of m.
+func (a *Anonymizer) Message(m *Message) *Message {
+	if m == nil {
+		return nil
+	}
+
+	anonymized := *m
+	anonymized.Account = a.User(m.Account)
+	anonymized.Body = a.Text(m.Body)
+	return &anonymized
+}
+
+// Member returns an anonymized copy of m that keeps only the pseudonymous
+// account ID, a derived display name, and the role.
+func (a *Anonymizer) Member(m *Member) *Member {
+	if m == nil {
+		return nil
+	}
+
+	id := a.AccountID(m.AccountID)
+	return &Member{
+		AccountID: id,
+		Role:      m.Role,
+		Name:      "user-" + id.String(),
+	}
+}
+
+// Room returns an anonymized copy of r. Its name and description are
+// redacted with the same rules as message bodies, except that the name of a
+// direct chat, which is the other person's, is replaced entirely.
+func (a *Anonymizer) Room(r *Room) *Room {
+	if r == nil {
+		return nil
+	}
+
+	anonymized := *r
+	anonymized.Name = a.Text(r.Name)
+	if r.Type == RoomTypeDirect {
+		anonymized.Name = a.replacement()
+	}
+	anonymized.Description = a.Text(r.Description)
+	anonymized.IconPath = ""
+	return &anonymized
+}
+
+// Record returns an anonymized copy of r, with its message, file, room, and
+// member anonymized.
+func (a *Anonymizer) Record(r *ExportRecord) *ExportRecord {
+	if r == nil {
+		return nil
+	}
+
+	anonymized := *r
+	anonymized.Message = a.Message(r.Message)
+	anonymized.File = a.File(r.File)
+	anonymized.Room = a.Room(r.Room)
+	anonymized.Member = a.Member(r.Member)
+	return &anonymized
+}
+
+// File returns an anonymized copy of f. The file name is redacted with the
+// same rules as message bodies and the download URL is removed.
+func (a *Anonymizer) File(f *File) *File {
+	if f == nil {
+		return nil
+	}
+
+	anonymized := *f
+	anonymized.Account = a.User(f.Account)
+	anonymized.Filename = a.Text(f.Filename)
+	anonymized.DownloadURL = ""
+	return &anonymized
+}
+
+func (a *Anonymizer) replacement() string {
+	if a.Replacement == "" {
+		return "[redacted]"
+	}
+	return a.Replacement
+}
+
+// anonymizingSink is the ExportSink returned by NewAnonymizingSink.
+type anonymizingSink struct {
+	sink ExportSink
+	a    *Anonymizer
+}
+
+// NewAnonymizingSink returns an ExportSink that anonymizes every record with
+// a before writing it to sink, for exports shared outside the organization:
+//
+//	a, err := chatwork.NewAnonymizer(salt, "Project Falcon")
+//	if err != nil {
+//		return err
+//	}
+//	_, _, err = client.Rooms.ExportHistory(ctx, roomID, chatwork.NewAnonymizingSink(w, a))
+//
+// Records are *ExportRecord values, as written by RoomsService.ExportHistory,
+// or *Message values. Any other record is refused rather than written as is.
+func NewAnonymizingSink(sink ExportSink, a *Anonymizer) ExportSink {
+	return &anonymizingSink{sink: sink, a: a}
+}
+
+// WriteRecord implements ExportSink.
+func (s *anonymizingSink) WriteRecord(v interface{}, at time.Time) error {
+	switch record := v.(type) {
+	case *ExportRecord:
+		return s.sink.WriteRecord(s.a.Record(record), at)
+	case *Message:
+		return s.sink.WriteRecord(s.a.Message(record), at)
+	default:
+		return fmt.Errorf("chatwork: cannot anonymize record of type %T", v)
+	}
+}
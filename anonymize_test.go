@@ -0,0 +1,86 @@
+package chatwork
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestAnonymizer_Message(t *testing.T) {
+	a := &Anonymizer{Salt: "s3cret", Keywords: []string{"Project Falcon"}}
+
+	message := &Message{
+		MessageID: "1",
+		Account:   User{AccountID: 123, Name: "Taro Yamada", Mail: "taro@example.com"},
+		Body:      "[To:456] Call me at 03-1234-5678 or mail taro@example.com about project falcon. [qtmeta aid=123 time=1609459200]",
+	}
+
+	got := a.Message(message)
+
+	if got.Account.AccountID == 123 || got.Account.AccountID != a.AccountID(123) {
+		t.Errorf("Expected a stable pseudonym for the author, got %d", got.Account.AccountID)
+	}
+	if got.Account.Mail != "" || strings.Contains(got.Account.Name, "Taro") {
+		t.Errorf("Expected personal fields to be removed, got %+v", got.Account)
+	}
+
+	for _, leaked := range []string{"456", "03-1234-5678", "taro@example.com", "falcon", "aid=123"} {
+		if strings.Contains(got.Body, leaked) {
+			t.Errorf("Anonymized body still contains %q: %s", leaked, got.Body)
+		}
+	}
+	if !strings.Contains(got.Body, "[To:"+a.AccountID(456).String()+"]") {
+		t.Errorf("Expected mention to be pseudonymized, got %s", got.Body)
+	}
+	if !strings.Contains(got.Body, "time=1609459200") {
+		t.Errorf("Expected timestamps to be preserved, got %s", got.Body)
+	}
+	if message.Body == got.Body || message.Account.AccountID != 123 {
+		t.Error("Expected the original message to be left unchanged")
+	}
+}
+
+func TestAnonymizer_NumericKeyword(t *testing.T) {
+	pseudonym := (&Anonymizer{Salt: "s3cret"}).AccountID(456).String()
+	keyword := pseudonym[:3]
+	a := &Anonymizer{Salt: "s3cret", Keywords: []string{keyword}}
+
+	for i := 0; i < 2; i++ {
+		got := a.Text("[To:456]\nTicket " + keyword)
+		if want := "[To:" + pseudonym + "]\nTicket [redacted]"; got != want {
+			t.Errorf("Expected %q, got %q", want, got)
+		}
+	}
+}
+
+func TestAnonymizer_Addressees(t *testing.T) {
+	a := &Anonymizer{Salt: "s3cret"}
+
+	body := "[To:123]Taro Yamadaさん\n[rp aid=456 to=100-200] Hanako Suzukiさん[To:789]Jiroさん\n資料の確認をお願いします。"
+	want := "[To:" + a.AccountID(123).String() + "][redacted]\n" +
+		"[rp aid=" + a.AccountID(456).String() + " to=100-200] [redacted][To:" + a.AccountID(789).String() + "][redacted]\n" +
+		"資料の確認をお願いします。"
+	if got := a.Text(body); got != want {
+		t.Errorf("Expected %q, got %q", want, got)
+	}
+}
+
+func TestNewAnonymizer(t *testing.T) {
+	if _, err := NewAnonymizer(""); !errors.Is(err, ErrInvalidParams) {
+		t.Errorf("Expected an empty salt to be refused, got %v", err)
+	}
+
+	a, err := NewAnonymizer("s3cret", "Falcon")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if a.AccountID(123) != (&Anonymizer{Salt: "s3cret"}).AccountID(123) || a.Text("Falcon") != "[redacted]" {
+		t.Error("Expected the salt and keywords to be used")
+	}
+
+	// Without a salt, pseudonyms are keyed with a random one.
+	unsalted := (&Anonymizer{}).AccountID(123)
+	if unsalted == (&Anonymizer{}).AccountID(123) {
+		t.Error("Expected anonymizers without a salt to use different salts")
+	}
+}
@@ -0,0 +1,151 @@
+package chatwork
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// ArchiveManifestVersion is the version of the manifest format written by
+// this package.
+const ArchiveManifestVersion = 1
+
+// ErrArchiveCorrupt is returned by VerifyArchive when an archive does not
+// match its manifest.
+var ErrArchiveCorrupt = errors.New("chatwork: archive does not match its manifest")
+
+// ArchiveManifest describes the files of an archive, such as those written by
+// ExportWriter, so that truncation and tampering can be detected.
+type ArchiveManifest struct {
+	Version   int           `json:"version"`
+	CreatedAt time.Time     `json:"created_at"`
+	Files     []ArchiveFile `json:"files"`
+}
+
+// ArchiveFile describes a single file of an archive.
+type ArchiveFile struct {
+	// File name, relative to the manifest's directory
+	Name string `json:"name"`
+
+	// SHA-256 of the file as stored, in hex
+	SHA256 string `json:"sha256"`
+
+	// Number of records (lines) in the uncompressed contents
+	Records int `json:"records"`
+
+	// SHA-256 of each record without its line ending, in hex
+	RecordHashes []string `json:"record_hashes"`
+
+	// Time range of the records written with ExportWriter.WriteRecord
+	FirstTime time.Time `json:"first_time,omitempty"`
+	LastTime  time.Time `json:"last_time,omitempty"`
+}
+
+// Records returns the total number of records in the archive.
+func (m *ArchiveManifest) Records() int {
+	n := 0
+	for _, f := range m.Files {
+		n += f.Records
+	}
+	return n
+}
+
+// ReadArchiveManifest reads the manifest at path.
+func ReadArchiveManifest(path string) (*ArchiveManifest, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	manifest := new(ArchiveManifest)
+	if err := json.Unmarshal(data, manifest); err != nil {
+		return nil, fmt.Errorf("%w: invalid manifest: %v", ErrArchiveCorrupt, err)
+	}
+	if manifest.Version < 1 || manifest.Version > ArchiveManifestVersion {
+		return nil, fmt.Errorf("chatwork: unsupported archive manifest version %d", manifest.Version)
+	}
+	return manifest, nil
+}
+
+// VerifyArchive checks every file listed in the manifest at path against its
+// SHA-256, record count, and per-record hashes, and returns the manifest.
+// Mismatches are reported as ErrArchiveCorrupt, naming the file and, where
+// possible, the first record that differs.
+func VerifyArchive(path string) (*ArchiveManifest, error) {
+	manifest, err := ReadArchiveManifest(path)
+	if err != nil {
+		return nil, err
+	}
+
+	dir := filepath.Dir(path)
+	for _, f := range manifest.Files {
+		if err := verifyArchiveFile(filepath.Join(dir, f.Name), f); err != nil {
+			return manifest, err
+		}
+	}
+	return manifest, nil
+}
+
+func verifyArchiveFile(path string, f ArchiveFile) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("%w: %s: %v", ErrArchiveCorrupt, f.Name, err)
+	}
+
+	sum := sha256.Sum256(data)
+	fileErr := ""
+	if hex.EncodeToString(sum[:]) != f.SHA256 {
+		fileErr = "file hash mismatch"
+	}
+
+	var r io.Reader = bytes.NewReader(data)
+	if strings.HasSuffix(f.Name, ".gz") {
+		gz, err := gzip.NewReader(r)
+		if err != nil {
+			return fmt.Errorf("%w: %s: %v", ErrArchiveCorrupt, f.Name, err)
+		}
+		r = gz
+	}
+
+	// Locate the first differing record to make the report actionable.
+	reader := bufio.NewReader(r)
+	records := 0
+	for {
+		line, err := reader.ReadBytes('\n')
+		if len(line) > 0 {
+			line = bytes.TrimSuffix(line, []byte("\n"))
+			if records >= len(f.RecordHashes) {
+				return fmt.Errorf("%w: %s: more records than the %d listed", ErrArchiveCorrupt, f.Name, len(f.RecordHashes))
+			}
+			recordSum := sha256.Sum256(line)
+			if hex.EncodeToString(recordSum[:]) != f.RecordHashes[records] {
+				return fmt.Errorf("%w: %s: record %d was modified", ErrArchiveCorrupt, f.Name, records+1)
+			}
+			records++
+		}
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return fmt.Errorf("%w: %s: %v", ErrArchiveCorrupt, f.Name, err)
+		}
+	}
+
+	if records != f.Records || records != len(f.RecordHashes) {
+		return fmt.Errorf("%w: %s: has %d records, want %d", ErrArchiveCorrupt, f.Name, records, f.Records)
+	}
+	if fileErr != "" {
+		return fmt.Errorf("%w: %s: %s", ErrArchiveCorrupt, f.Name, fileErr)
+	}
+	return nil
+}
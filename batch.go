@@ -0,0 +1,139 @@
+package chatwork
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// defaultBatchConcurrency is the default number of jobs Batch runs at the
+// same time.
+const defaultBatchConcurrency = 4
+
+// BatchJob is a unit of work run by Batch, typically a single API call.
+type BatchJob struct {
+	// Identifies the job in errors, such as the room it posts to
+	Name string
+
+	// Makes the call with the client passed to Batch
+	Run func(ctx context.Context, client *Client) error
+}
+
+// BatchOptions represents the options for Batch.
+type BatchOptions struct {
+	// Number of jobs run at the same time. Defaults to 4.
+	Concurrency int
+
+	// Whether the jobs not yet started are skipped after the first failure.
+	// Jobs are skipped after rate limit errors in any case. Skipped jobs have
+	// the error that stopped the batch as their result.
+	StopOnError bool
+
+	// Called as each job finishes or is skipped (optional), such as to
+	// report progress. It may be called from several goroutines at once.
+	OnResult func(*BatchResult)
+}
+
+// BatchResult is the outcome of a BatchJob.
+type BatchResult struct {
+	Job *BatchJob
+
+	// Why the job failed or was skipped, if it did not succeed
+	Err error
+}
+
+// Batch runs jobs with bounded concurrency and returns their results in the
+// order of jobs. opts may be nil.
+//
+// Calls go through the client's rate limiting (see OptionRateLimit and
+// OptionWaitOnRateLimit). If ChatWork rate limits a call nevertheless, the
+// jobs not yet started are skipped, with the rate limit error as their
+// result, rather than adding to the load. Jobs are also skipped once ctx is
+// done.
+//
+// Failed jobs do not stop the others unless StopOnError is set. Their
+// errors are joined into the returned error.
+//
+// Example:
+//
+//	jobs := make([]chatwork.BatchJob, len(roomIDs))
+//	for i, roomID := range roomIDs {
+//		roomID := roomID
+//		jobs[i] = chatwork.BatchJob{
+//			Name: roomID.String(),
+//			Run: func(ctx context.Context, client *chatwork.Client) error {
+//				_, _, err := client.Messages.SendMessage(ctx, roomID, "Maintenance tonight")
+//				return err
+//			},
+//		}
+//	}
+//	results, err := chatwork.Batch(ctx, client, jobs, nil)
+func Batch(ctx context.Context, client *Client, jobs []BatchJob, opts *BatchOptions) ([]*BatchResult, error) {
+	if opts == nil {
+		opts = &BatchOptions{}
+	}
+	concurrency := defaultBatchConcurrency
+	if opts.Concurrency > 0 {
+		concurrency = opts.Concurrency
+	}
+
+	results := make([]*BatchResult, len(jobs))
+	for i := range jobs {
+		results[i] = &BatchResult{Job: &jobs[i]}
+	}
+	report := func(result *BatchResult) {
+		if opts.OnResult != nil {
+			opts.OnResult(result)
+		}
+	}
+
+	var (
+		wg   sync.WaitGroup
+		mu   sync.Mutex
+		stop error
+		sem  = make(chan struct{}, concurrency)
+	)
+	for _, result := range results {
+		sem <- struct{}{}
+		mu.Lock()
+		skip := stop
+		mu.Unlock()
+		if skip == nil {
+			skip = ctx.Err()
+		}
+		if skip != nil {
+			<-sem
+			result.Err = skip
+			report(result)
+			continue
+		}
+
+		wg.Add(1)
+		go func(result *BatchResult) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+			err := result.Job.Run(ctx, client)
+			result.Err = err
+			if err != nil && (opts.StopOnError || errors.Is(err, ErrRateLimited)) {
+				mu.Lock()
+				if stop == nil {
+					stop = err
+				}
+				mu.Unlock()
+			}
+			report(result)
+		}(result)
+	}
+	wg.Wait()
+
+	var errs []error
+	for _, result := range results {
+		if result.Err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", result.Job.Name, result.Err))
+		}
+	}
+	return results, errors.Join(errs...)
+}
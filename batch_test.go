@@ -0,0 +1,104 @@
+package chatwork_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync/atomic"
+	"testing"
+
+	"github.com/nashirox/chatwork-go"
+	"github.com/nashirox/chatwork-go/chatworktest"
+)
+
+func TestBatch(t *testing.T) {
+	srv := chatworktest.NewServer()
+	defer srv.Close()
+
+	var roomIDs []chatwork.RoomID
+	for i := 0; i < 6; i++ {
+		roomIDs = append(roomIDs, srv.AddRoom(chatwork.Room{Name: "Room"}))
+	}
+	srv.Fail("POST", "/rooms/"+roomIDs[1].String()+"/messages", http.StatusForbidden)
+	client := srv.Client()
+	ctx := context.Background()
+
+	jobs := make([]chatwork.BatchJob, len(roomIDs))
+	for i, roomID := range roomIDs {
+		roomID := roomID
+		jobs[i] = chatwork.BatchJob{
+			Name: roomID.String(),
+			Run: func(ctx context.Context, client *chatwork.Client) error {
+				_, _, err := client.Messages.SendMessage(ctx, roomID, "Maintenance tonight")
+				return err
+			},
+		}
+	}
+
+	var reported atomic.Int32
+	results, err := chatwork.Batch(ctx, client, jobs, &chatwork.BatchOptions{
+		Concurrency: 3,
+		OnResult:    func(*chatwork.BatchResult) { reported.Add(1) },
+	})
+	if !errors.Is(err, chatwork.ErrForbidden) {
+		t.Errorf("Expected the failure to be reported, got %v", err)
+	}
+	if len(results) != len(jobs) || reported.Load() != int32(len(jobs)) {
+		t.Fatalf("Expected a result for each job, got %d results and %d reports", len(results), reported.Load())
+	}
+	for i, result := range results {
+		if result.Job != &jobs[i] {
+			t.Errorf("Result %d belongs to job %s", i, result.Job.Name)
+		}
+		if failed := result.Err != nil; failed != (i == 1) {
+			t.Errorf("Unexpected result for room %s: %v", result.Job.Name, result.Err)
+		}
+		if sent := len(srv.Messages(roomIDs[i])) == 1; sent != (i != 1) {
+			t.Errorf("Unexpected messages in room %s: %d", result.Job.Name, len(srv.Messages(roomIDs[i])))
+		}
+	}
+
+	srv.Fail("POST", "/rooms/"+roomIDs[0].String()+"/messages", http.StatusTooManyRequests)
+	results, _ = chatwork.Batch(ctx, client, jobs, &chatwork.BatchOptions{Concurrency: 1})
+	for _, result := range results {
+		if !errors.Is(result.Err, chatwork.ErrRateLimited) {
+			t.Errorf("Expected room %s to be skipped after the rate limit, got %v", result.Job.Name, result.Err)
+		}
+	}
+}
+
+func TestRoomsService_GetAllDetails(t *testing.T) {
+	srv := chatworktest.NewServer()
+	defer srv.Close()
+
+	var roomIDs []chatwork.RoomID
+	for _, name := range []string{"A", "B", "C", "D", "E"} {
+		roomID := srv.AddRoom(chatwork.Room{Name: name})
+		srv.AddMember(roomID, chatwork.Member{AccountID: 200})
+		roomIDs = append(roomIDs, roomID)
+	}
+	srv.Fail("GET", "/rooms/"+roomIDs[2].String()+"/members", http.StatusForbidden)
+	client := srv.Client()
+
+	details, err := client.Rooms.GetAllDetails(context.Background(), roomIDs, 2)
+	if !errors.Is(err, chatwork.ErrForbidden) {
+		t.Errorf("Expected the failure to be reported, got %v", err)
+	}
+	if len(details) != len(roomIDs) {
+		t.Fatalf("Expected details of %d rooms, got %d", len(roomIDs), len(details))
+	}
+	for i, d := range details {
+		if d.RoomID != roomIDs[i] {
+			t.Errorf("Expected room %d at %d, got %d", roomIDs[i], i, d.RoomID)
+		}
+		if i == 2 {
+			if d.Err == nil || d.Room != nil {
+				t.Errorf("Expected room %d to fail, got %+v", d.RoomID, d)
+			}
+			continue
+		}
+		if d.Err != nil || d.Room.Name != string(rune('A'+i)) || len(d.Members) != 2 {
+			t.Errorf("Unexpected details of room %d: %+v", d.RoomID, d)
+		}
+	}
+}
@@ -0,0 +1,51 @@
+package chatwork
+
+import (
+	"context"
+	"time"
+)
+
+// Budget splits the deadline of a context across a sequence of calls, so
+// that a slow early call, such as one waiting out a rate limit, leaves time
+// for the calls after it instead of consuming the whole deadline.
+//
+// Each call gets an equal share of the time remaining when it starts, so
+// time left over by fast calls goes to the later ones, and the last call
+// gets all that remains:
+//
+//	budget := chatwork.NewBudget(ctx, 2)
+//	getCtx, cancel := budget.Next()
+//	room, _, err := client.Rooms.Get(getCtx, roomID)
+//	cancel()
+//	...
+//	sendCtx, cancel := budget.Next()
+//	defer cancel()
+//	_, _, err = client.Messages.SendMessage(sendCtx, roomID, body)
+//
+// Without a deadline on the context, calls are not limited. Multi-call
+// helpers of this package, such as MessagesService.Quote, budget their
+// calls this way.
+type Budget struct {
+	ctx   context.Context
+	calls int
+}
+
+// NewBudget returns a Budget splitting the deadline of ctx across calls.
+func NewBudget(ctx context.Context, calls int) *Budget {
+	return &Budget{ctx: ctx, calls: calls}
+}
+
+// Next returns the context for the next call, with its share of the
+// remaining time as deadline. Calls beyond the number the budget was
+// created for get all of the remaining time.
+func (b *Budget) Next() (context.Context, context.CancelFunc) {
+	deadline, ok := b.ctx.Deadline()
+	if !ok || b.calls <= 1 {
+		b.calls--
+		return context.WithCancel(b.ctx)
+	}
+
+	share := time.Until(deadline) / time.Duration(b.calls)
+	b.calls--
+	return context.WithTimeout(b.ctx, share)
+}
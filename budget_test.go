@@ -0,0 +1,36 @@
+package chatwork
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestBudget(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 4*time.Second)
+	defer cancel()
+	parent, _ := ctx.Deadline()
+
+	budget := NewBudget(ctx, 2)
+	first, cancelFirst := budget.Next()
+	defer cancelFirst()
+	deadline, ok := first.Deadline()
+	if !ok {
+		t.Fatal("Expected the first call to have a deadline")
+	}
+	if share := time.Until(deadline); share > 2*time.Second || share < 1500*time.Millisecond {
+		t.Errorf("Expected the first call to get half of the time, got %v", share)
+	}
+
+	second, cancelSecond := budget.Next()
+	defer cancelSecond()
+	if deadline, _ := second.Deadline(); !deadline.Equal(parent) {
+		t.Errorf("Expected the last call to get the remaining time, got deadline %v, want %v", deadline, parent)
+	}
+
+	unlimited, cancelUnlimited := NewBudget(context.Background(), 3).Next()
+	defer cancelUnlimited()
+	if _, ok := unlimited.Deadline(); ok {
+		t.Error("Expected no deadline without a parent deadline")
+	}
+}
@@ -28,11 +28,14 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
+	"log/slog"
 	"net/http"
 	"net/url"
 	"strings"
+	"time"
 
 	"github.com/google/go-querystring/query"
 )
@@ -60,10 +63,92 @@ type Client struct {
 	// API token for authentication.
 	token string
 
+	// Provider consulted for the token on every request. Overrides token when set.
+	tokenProvider TokenProvider
+
+	// Pool of tokens to fail over between. Also installed as tokenProvider.
+	tokenPool *tokenPool
+
+	// Send tokens as "Authorization: Bearer" instead of X-ChatWorkToken (OAuth 2.0).
+	bearerAuth bool
+
+	// Client-side limiter for outgoing requests. Nil when throttling is disabled.
+	limiter *rateLimiter
+
+	// Whether to wait for the rate limit to reset and retry on 429 responses.
+	waitOnRateLimit bool
+
+	// Which failed calls are retried, if set.
+	retryPolicy *RetryPolicy
+
+	// Deadline applied to every call unless overridden with WithTimeout.
+	defaultTimeout time.Duration
+
+	// Whether responses with fields unknown to the result types are errors.
+	strictDecoding bool
+
+	// Whether response bodies are kept in Response.RawBody.
+	captureBody bool
+
+	// Clock of the polling helpers, if not the time package.
+	clock Clock
+
+	// Coordination of the adaptive watchers started with Watch. Shared with
+	// derived clients, which use the same rate limit.
+	watches *watchCoordinator
+
+	// Deprecation warnings logged so far, shared with derived clients.
+	warnings *warningLog
+
+	// Contacts listed by ContactsService.List, if cached. Shared with
+	// derived clients.
+	contactsCache *contactsCache
+
+	// Instances of registered extensions, created on first use.
+	extensions *extensionSet
+
+	// Templates rendered by MessagesService.SendTemplate.
+	templates *MessageTemplates
+
+	// Extra attempts of member helpers after concurrent changes, if not zero.
+	memberUpdateRetries int
+
+	// Archiving of rooms before deletion, if set.
+	retention *RetentionPolicy
+
+	// Room that messages are redirected to, if not zero.
+	stagingRoom RoomID
+
+	// Why messages are refused, if the staging room could not be read.
+	stagingErr error
+
+	// Policy evaluated for every call other than GET requests.
+	policy *Policy
+
+	// Whether mutating requests are logged instead of sent.
+	dryRun bool
+
+	// Restrictions of clients derived with WithPermissions.
+	permissions []Permissions
+
+	// Transport middlewares in registration order.
+	middlewares []Middleware
+
+	// Hooks run around every round trip.
+	onRequest  []func(*http.Request)
+	onResponse []func(*Response, error)
+
+	// Debug logging configuration.
+	debug       bool
+	debugBodies bool
+	debugCurl   bool
+	logger      *slog.Logger
+
 	// Services used for talking to different parts of the ChatWork API.
 	common service // Reuse a single struct instead of allocating one for each service on the heap.
 
-	// Services for each endpoint
+	// Services for each endpoint. Each one implements the interface of the
+	// same name with an API suffix, such as RoomsAPI, for use in dependency injection.
 	Rooms            *RoomsService
 	Messages         *MessagesService
 	Me               *MeService
@@ -99,10 +184,13 @@ func New(token string, options ...ClientOption) *Client {
 	}
 
 	c := &Client{
-		client:    httpClient,
-		BaseURL:   baseURL,
-		UserAgent: userAgent,
-		token:     token,
+		client:     httpClient,
+		BaseURL:    baseURL,
+		UserAgent:  userAgent,
+		token:      token,
+		watches:    &watchCoordinator{},
+		warnings:   &warningLog{},
+		extensions: &extensionSet{},
 	}
 
 	c.common.client = c
@@ -117,6 +205,7 @@ func New(token string, options ...ClientOption) *Client {
 	for _, option := range options {
 		option(c)
 	}
+	c.applyMiddlewares()
 
 	return c
 }
@@ -139,14 +228,40 @@ func OptionHTTPClient(httpClient *http.Client) ClientOption {
 	}
 }
 
-// OptionDebug enables debug mode for the client.
-// When enabled, the client will log detailed information about API requests and responses.
-// This is useful for troubleshooting and development.
-func OptionDebug(debug bool) ClientOption {
+// OptionDefaultTimeout bounds every API call, including retries and reading
+// the response, by d. Unlike http.Client.Timeout, it is applied to the
+// context of each call and can be overridden per call with WithTimeout.
+//
+// Example:
+//
+//	client := chatwork.New("token", chatwork.OptionDefaultTimeout(10*time.Second))
+func OptionDefaultTimeout(d time.Duration) ClientOption {
 	return func(c *Client) {
-		// Debug logging is currently not implemented
-		// This option is reserved for future use
-		_ = debug
+		c.defaultTimeout = d
+	}
+}
+
+// OptionStrictDecoding makes calls fail when a response contains fields
+// that the result types of this package do not have. It is meant for tests
+// and CI, to notice when ChatWork adds or renames fields; the default is to
+// ignore unknown fields.
+//
+// Example:
+//
+//	client := chatwork.New("token", chatwork.OptionStrictDecoding(true))
+func OptionStrictDecoding(enabled bool) ClientOption {
+	return func(c *Client) {
+		c.strictDecoding = enabled
+	}
+}
+
+// OptionCaptureBody keeps the body of every response in Response.RawBody, in
+// addition to decoding it, so that callers can log or decode again the exact
+// payload when ChatWork returns unexpected JSON. Use WithRawBody to capture
+// the body of a single call instead.
+func OptionCaptureBody(enabled bool) ClientOption {
+	return func(c *Client) {
+		c.captureBody = enabled
 	}
 }
 
@@ -164,14 +279,7 @@ func (c *Client) NewRequest(method, urlStr string, body interface{}) (*http.Requ
 
 // NewRequestWithContext creates a new API request with context and JSON body.
 func (c *Client) NewRequestWithContext(ctx context.Context, method, urlStr string, body interface{}) (*http.Request, error) {
-	// Build the full URL by joining BaseURL and the relative path
-	baseURL := strings.TrimRight(c.BaseURL.String(), "/")
-	if !strings.HasPrefix(urlStr, "/") {
-		urlStr = "/" + urlStr
-	}
-	fullURL := baseURL + urlStr
-
-	u, err := url.Parse(fullURL)
+	u, err := c.resolveURL(urlStr)
 	if err != nil {
 		return nil, err
 	}
@@ -197,11 +305,25 @@ func (c *Client) NewRequestWithContext(ctx context.Context, method, urlStr strin
 	}
 	req.Header.Set("Accept", "application/json")
 	req.Header.Set("User-Agent", c.UserAgent)
-	req.Header.Set("X-ChatWorkToken", c.token)
+	if err := c.authenticate(ctx, req); err != nil {
+		return nil, err
+	}
 
 	return req, nil
 }
 
+// resolveURL joins the relative urlStr onto BaseURL.
+//
+// Unlike url.URL.Parse, the path of BaseURL (such as "/v2") is always kept.
+// Path segments built from user input must be escaped by the caller.
+func (c *Client) resolveURL(urlStr string) (*url.URL, error) {
+	baseURL := strings.TrimRight(c.BaseURL.String(), "/")
+	if !strings.HasPrefix(urlStr, "/") {
+		urlStr = "/" + urlStr
+	}
+	return url.Parse(baseURL + urlStr)
+}
+
 // NewFormRequest creates a new API request with form-encoded body.
 //
 // This method is similar to NewRequest but encodes the body as form data
@@ -214,7 +336,7 @@ func (c *Client) NewFormRequest(method, urlStr string, body interface{}) (*http.
 
 // NewFormRequestWithContext creates a new API request with context and form-encoded body.
 func (c *Client) NewFormRequestWithContext(ctx context.Context, method, urlStr string, body interface{}) (*http.Request, error) {
-	u, err := c.BaseURL.Parse(urlStr)
+	u, err := c.resolveURL(urlStr)
 	if err != nil {
 		return nil, err
 	}
@@ -238,7 +360,9 @@ func (c *Client) NewFormRequestWithContext(ctx context.Context, method, urlStr s
 	}
 	req.Header.Set("Accept", "application/json")
 	req.Header.Set("User-Agent", c.UserAgent)
-	req.Header.Set("X-ChatWorkToken", c.token)
+	if err := c.authenticate(ctx, req); err != nil {
+		return nil, err
+	}
 
 	return req, nil
 }
@@ -251,16 +375,151 @@ func (c *Client) NewFormRequestWithContext(ctx context.Context, method, urlStr s
 // attempting to first decode it.
 //
 // The provided context is used to cancel the request if needed.
-func (c *Client) Do(ctx context.Context, req *http.Request, v interface{}) (*Response, error) {
+// Any RequestOptions are applied to the request before it is sent.
+func (c *Client) Do(ctx context.Context, req *http.Request, v interface{}, opts ...RequestOption) (*Response, error) {
+	if err := c.checkPermissions(req); err != nil {
+		return nil, err
+	}
+	if err := c.checkPolicy(ctx, req); err != nil {
+		return nil, err
+	}
+	cfg := newRequestConfig(opts)
+	timeout := c.defaultTimeout
+	if cfg.hasTimeout {
+		timeout = cfg.timeout
+	}
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+	for key, values := range cfg.header {
+		req.Header[key] = values
+	}
+
 	req = req.WithContext(ctx)
+	if response := c.skipDryRun(req); response != nil {
+		return response, nil
+	}
+
+	start := time.Now()
+	for attempt := 0; ; attempt++ {
+		// Authenticate again on every attempt, so that a token that expired
+		// while waiting, such as an OAuth token, is refreshed, and a request
+		// sent after token pool failover uses the new token.
+		if err := c.authenticate(ctx, req); err != nil {
+			return nil, err
+		}
+		if cfg.progress != nil && req.Body != nil && req.Body != http.NoBody {
+			// Wrap the body of each attempt, as retries replace it.
+			total := req.ContentLength
+			if total <= 0 {
+				total = -1
+			}
+			req.Body = &progressReader{r: req.Body, total: total, report: cfg.progress}
+		}
+		response, err := c.send(req, v, c.captureBody || cfg.rawBody)
+		if c.failover(req, cfg, err) {
+			if err := rewindBody(req); err != nil {
+				return response, err
+			}
+			continue
+		}
+
+		wait, retry := c.rateLimitWait(ctx, req, cfg, attempt, err)
+		if !retry {
+			wait, retry = c.retryWait(ctx, req, cfg, attempt, start, response, err)
+		}
+		if !retry {
+			return response, err
+		}
+
+		if err := sleepContext(ctx, wait); err != nil {
+			return response, err
+		}
+		if err := rewindBody(req); err != nil {
+			return response, err
+		}
+	}
+}
+
+// Do sends an API request with client and returns the JSON decoded response
+// body as a new T. It is the generic counterpart of Client.Do, used by the
+// service methods, and can be used to call endpoints this package does not
+// wrap yet:
+//
+//	req, err := client.NewRequest(http.MethodGet, "rooms/123/links", nil)
+//	if err != nil {
+//		return err
+//	}
+//	links, _, err := chatwork.Do[[]Link](ctx, client, req)
+func Do[T any](ctx context.Context, client *Client, req *http.Request, opts ...RequestOption) (*T, *Response, error) {
+	v := new(T)
+	resp, err := client.Do(ctx, req, v, opts...)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return v, resp, nil
+}
+
+// doValue is like Do but returns the decoded T itself, for slice and map
+// results.
+func doValue[T any](ctx context.Context, client *Client, req *http.Request, opts ...RequestOption) (T, *Response, error) {
+	var v T
+	resp, err := client.Do(ctx, req, &v, opts...)
+	if err != nil {
+		var zero T
+		return zero, resp, err
+	}
+
+	return v, resp, nil
+}
 
+// send performs a single round trip for Do.
+func (c *Client) send(req *http.Request, v interface{}, capture bool) (*Response, error) {
+	ctx := req.Context()
+	if c.limiter != nil {
+		if err := c.limiter.Wait(ctx); err != nil {
+			return nil, err
+		}
+	}
+
+	c.runRequestHooks(req)
+	start := time.Now()
+	response, err := c.exchange(req, v, capture)
+	if response != nil {
+		response.Latency = time.Since(start)
+	}
+	c.runResponseHooks(response, err)
+
+	return response, err
+}
+
+// exchange sends req over HTTP and decodes the response into v. If capture
+// is set, the body is also kept in the RawBody of the response.
+func (c *Client) exchange(req *http.Request, v interface{}, capture bool) (*Response, error) {
+	start := time.Now()
 	resp, err := c.client.Do(req)
+	if c.debug {
+		c.logExchange(req, resp, time.Since(start), err)
+	}
 	if err != nil {
 		return nil, err
 	}
 	defer resp.Body.Close()
 
 	response := newResponse(resp)
+	c.warnings.log(req, c.warningLogger(), response.Warnings)
+
+	if capture {
+		data, err := io.ReadAll(resp.Body)
+		if err != nil {
+			return response, err
+		}
+		response.RawBody = data
+		resp.Body = io.NopCloser(bytes.NewReader(data))
+	}
 
 	err = CheckResponse(resp)
 	if err != nil {
@@ -274,6 +533,23 @@ func (c *Client) Do(ctx context.Context, req *http.Request, v interface{}) (*Res
 	return response, err
 }
 
+// rewindBody resets the request body so that the request can be sent again.
+func rewindBody(req *http.Request) error {
+	if req.Body == nil || req.Body == http.NoBody {
+		return nil
+	}
+	if req.GetBody == nil {
+		return errors.New("chatwork: request body cannot be replayed")
+	}
+
+	body, err := req.GetBody()
+	if err != nil {
+		return err
+	}
+	req.Body = body
+	return nil
+}
+
 // processResponseBody handles the response body parsing logic.
 func (c *Client) processResponseBody(v interface{}, body io.ReadCloser) error {
 	if w, ok := v.(io.Writer); ok {
@@ -283,7 +559,11 @@ func (c *Client) processResponseBody(v interface{}, body io.ReadCloser) error {
 		return nil
 	}
 
-	decErr := json.NewDecoder(body).Decode(v)
+	dec := json.NewDecoder(body)
+	if c.strictDecoding {
+		dec.DisallowUnknownFields()
+	}
+	decErr := dec.Decode(v)
 	if decErr == io.EOF {
 		return nil
 	}
@@ -298,6 +578,20 @@ type Response struct {
 
 	// Rate limit information parsed from headers
 	RateLimit RateLimit
+
+	// Deprecation notices sent with the response, such as a scheduled
+	// removal of the endpoint. They are also logged, once per client.
+	Warnings []string
+
+	// Whether the request was not sent because of OptionDryRun
+	DryRun bool
+
+	// Time the attempt took, from sending the request to reading the body
+	Latency time.Duration
+
+	// Body of the response as received, if captured with OptionCaptureBody
+	// or WithRawBody. Error responses are captured as well.
+	RawBody []byte
 }
 
 // RateLimit represents the rate limit information for the ChatWork API.
@@ -315,8 +609,8 @@ type RateLimit struct {
 
 func newResponse(r *http.Response) *Response {
 	response := &Response{Response: r}
-	// Rate limit parsing is not currently implemented
-	// as ChatWork API documentation doesn't specify rate limit headers
+	response.RateLimit = parseRateLimit(r)
+	response.Warnings = parseWarnings(r)
 	return response
 }
 
@@ -331,6 +625,9 @@ func CheckResponse(r *http.Response) error {
 	}
 
 	errorResponse := &APIError{Response: r}
+	if r.StatusCode == http.StatusTooManyRequests {
+		errorResponse.RetryAfter = retryAfter(r, time.Now())
+	}
 	data, err := io.ReadAll(r.Body)
 	if err == nil && data != nil {
 		if err := json.Unmarshal(data, errorResponse); err != nil {
@@ -352,6 +649,10 @@ type APIError struct {
 
 	// Error messages returned by the API
 	Errors []string `json:"errors"`
+
+	// For 429 responses, how long to wait before the rate limit resets.
+	// It is computed from the X-RateLimit-Reset or Retry-After header.
+	RetryAfter time.Duration `json:"-"`
 }
 
 // Error returns a human-readable description of the API error.
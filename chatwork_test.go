@@ -1,10 +1,21 @@
 package chatwork
 
 import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
 	"net/http"
 	"net/http/httptest"
 	"net/url"
+	"reflect"
+	"regexp"
+	"strings"
 	"testing"
+	"time"
 )
 
 const testToken = "test-token"
@@ -146,3 +157,780 @@ func TestTimestamp(t *testing.T) {
 		t.Errorf("Expected Unix timestamp 1609459200, got %d", time.Unix())
 	}
 }
+
+func TestTimestamp_JSON(t *testing.T) {
+	var m Message
+	if err := json.Unmarshal([]byte(`{"send_time": 1609459200, "update_time": "1609459260"}`), &m); err != nil {
+		t.Fatalf("Unmarshal returned error: %v", err)
+	}
+	if !m.SendTime.Time().Equal(time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)) || m.UpdateTime != 1609459260 {
+		t.Errorf("Unexpected times: %d, %d", m.SendTime, m.UpdateTime)
+	}
+	if err := json.Unmarshal([]byte(`{"send_time": "soon"}`), &m); err == nil {
+		t.Error("Expected an error for an invalid timestamp")
+	}
+
+	data, err := json.Marshal(Room{LastUpdateTime: NewTimestamp(time.Unix(1609459200, 0))})
+	if err != nil {
+		t.Fatalf("Marshal returned error: %v", err)
+	}
+	if !strings.Contains(string(data), `"last_update_time":1609459200`) {
+		t.Errorf("Expected the timestamp as a number, got %s", data)
+	}
+}
+
+func TestOptionDebug(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"account_id": 1, "name": "` + r.Header.Get("X-ChatWorkToken") + `"}`))
+	}))
+	defer server.Close()
+
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+
+	client := New(testToken, OptionDebug(true), OptionLogger(logger), OptionDebugBodies(true))
+	client.BaseURL, _ = url.Parse(server.URL)
+
+	me, _, err := client.Me.Get(context.Background())
+	if err != nil {
+		t.Fatalf("Me.Get returned error: %v", err)
+	}
+	if me.Name != testToken {
+		t.Errorf("Expected response body to remain decodable, got name %q", me.Name)
+	}
+
+	out := buf.String()
+	for _, want := range []string{"method=GET", "status=200", "latency=", redacted} {
+		if !strings.Contains(out, want) {
+			t.Errorf("Expected debug output to contain %q, got %q", want, out)
+		}
+	}
+	if strings.Contains(out, testToken) {
+		t.Errorf("Debug output leaked the API token: %q", out)
+	}
+}
+
+func TestOptionDebugCurl(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"message_id":"1"}`))
+	}))
+	defer server.Close()
+
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+
+	client := New(testToken, OptionDebug(true), OptionLogger(logger), OptionDebugCurl(true))
+	client.BaseURL, _ = url.Parse(server.URL)
+
+	req, err := client.NewRequest(http.MethodPost, "rooms/1/links", map[string]string{"title": "it's done"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.Do(context.Background(), req, nil); err != nil {
+		t.Fatalf("Do returned error: %v", err)
+	}
+
+	var record struct{ Curl string }
+	if err := json.Unmarshal(buf.Bytes(), &record); err != nil {
+		t.Fatalf("Invalid log record %q: %v", buf.String(), err)
+	}
+	want := "curl -X POST '" + server.URL + "/rooms/1/links'"
+	if !strings.HasPrefix(record.Curl, want) {
+		t.Errorf("Expected the command to start with %q, got %q", want, record.Curl)
+	}
+	for _, want := range []string{"-H 'X-Chatworktoken: " + redacted + "'", `--data-raw '{"title":"it'\''s done"}`} {
+		if !strings.Contains(record.Curl, want) {
+			t.Errorf("Expected the command to contain %q, got %q", want, record.Curl)
+		}
+	}
+	if strings.Contains(record.Curl, testToken) {
+		t.Errorf("Curl command leaked the API token: %q", record.Curl)
+	}
+}
+
+func TestResponse_Warnings(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Deprecation", "@1704067200")
+		w.Header().Set("Sunset", "Wed, 01 Jan 2025 00:00:00 GMT")
+		w.Header().Set("Link", `<https://example.com/changelog>; rel="deprecation"`)
+		w.Write([]byte(`{"account_id": 1}`))
+	}))
+	defer server.Close()
+
+	var buf bytes.Buffer
+	client := New(testToken, OptionLogger(slog.New(slog.NewTextHandler(&buf, nil))))
+	client.BaseURL, _ = url.Parse(server.URL)
+
+	var resp *Response
+	for i := 0; i < 2; i++ {
+		var err error
+		if _, resp, err = client.Me.Get(context.Background()); err != nil {
+			t.Fatalf("Me.Get returned error: %v", err)
+		}
+	}
+
+	want := []string{
+		"GET /me: endpoint is deprecated since 2024-01-01T00:00:00Z",
+		"GET /me: endpoint will be removed at 2025-01-01T00:00:00Z",
+		"GET /me: see https://example.com/changelog",
+	}
+	if !reflect.DeepEqual(resp.Warnings, want) {
+		t.Errorf("Expected warnings %q, got %q", want, resp.Warnings)
+	}
+	if n := strings.Count(buf.String(), "level=WARN"); n != len(want) {
+		t.Errorf("Expected each warning to be logged once, got %d records: %s", n, buf.String())
+	}
+}
+
+func TestOptionTokenProvider(t *testing.T) {
+	tokens := []string{"first", "second"}
+	calls := 0
+	provider := TokenProviderFunc(func(ctx context.Context) (string, error) {
+		token := tokens[calls%len(tokens)]
+		calls++
+		return token, nil
+	})
+
+	client := New(testToken, OptionTokenProvider(provider))
+
+	for _, want := range tokens {
+		req, err := client.NewRequest("GET", "me", nil)
+		if err != nil {
+			t.Fatalf("NewRequest returned error: %v", err)
+		}
+		if got := req.Header.Get("X-ChatWorkToken"); got != want {
+			t.Errorf("Expected token %q, got %q", want, got)
+		}
+	}
+
+	failing := New(testToken, OptionTokenProvider(TokenProviderFunc(func(ctx context.Context) (string, error) {
+		return "", errors.New("secret store unavailable")
+	})))
+	if _, err := failing.NewFormRequest("POST", "rooms", nil); err == nil {
+		t.Error("Expected provider error to be returned")
+	}
+}
+
+func TestDo_AuthenticatesEachAttempt(t *testing.T) {
+	var requests int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if requests == 1 {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.Write([]byte(`{"account_id": 1, "name": "` + r.Header.Get("X-ChatWorkToken") + `"}`))
+	}))
+	defer server.Close()
+
+	type ctxKey struct{}
+	calls, withContext := 0, 0
+	provider := TokenProviderFunc(func(ctx context.Context) (string, error) {
+		if ctx.Value(ctxKey{}) != nil {
+			withContext++
+		}
+		calls++
+		return fmt.Sprintf("token-%d", calls), nil
+	})
+
+	client := New(testToken, OptionTokenProvider(provider), OptionWaitOnRateLimit(true))
+	client.BaseURL, _ = url.Parse(server.URL)
+
+	ctx := context.WithValue(context.Background(), ctxKey{}, true)
+	me, _, err := client.Me.Get(ctx)
+	if err != nil {
+		t.Fatalf("Me.Get returned error: %v", err)
+	}
+	// NewRequest, the first attempt and the retry each ask for a token.
+	if calls != 3 || me.Name != "token-3" {
+		t.Errorf("Expected the retry to be sent with a fresh token, got %q after %d provider calls", me.Name, calls)
+	}
+	if withContext != 2 {
+		t.Errorf("Expected both attempts to pass the context of the call, got %d", withContext)
+	}
+}
+
+func TestRequestOptions(t *testing.T) {
+	var gotHeader string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotHeader = r.Header.Get("X-Correlation-ID")
+		if r.URL.Path == "/slow" {
+			time.Sleep(100 * time.Millisecond)
+		}
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	client := New(testToken)
+	client.BaseURL, _ = url.Parse(server.URL)
+
+	if _, _, err := client.Me.Get(context.Background(), WithHeader("X-Correlation-ID", "abc")); err != nil {
+		t.Fatalf("Me.Get returned error: %v", err)
+	}
+	if gotHeader != "abc" {
+		t.Errorf("Expected X-Correlation-ID header %q, got %q", "abc", gotHeader)
+	}
+
+	req, _ := client.NewRequest("GET", "slow", nil)
+	if _, err := client.Do(context.Background(), req, nil, WithTimeout(10*time.Millisecond)); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected deadline exceeded error, got %v", err)
+	}
+}
+
+func TestAPIError_Is(t *testing.T) {
+	tests := []struct {
+		statusCode int
+		want       error
+	}{
+		{http.StatusBadRequest, ErrBadRequest},
+		{http.StatusUnauthorized, ErrUnauthorized},
+		{http.StatusForbidden, ErrForbidden},
+		{http.StatusNotFound, ErrNotFound},
+		{http.StatusTooManyRequests, ErrRateLimited},
+		{http.StatusBadGateway, ErrServerError},
+	}
+
+	for _, tt := range tests {
+		var err error = &APIError{Response: &http.Response{StatusCode: tt.statusCode}}
+		if !errors.Is(err, tt.want) {
+			t.Errorf("Expected status %d to match %v", tt.statusCode, tt.want)
+		}
+
+		var apiErr *APIError
+		if !errors.As(fmt.Errorf("wrapped: %w", err), &apiErr) {
+			t.Errorf("Expected errors.As to find *APIError for status %d", tt.statusCode)
+		}
+	}
+
+	if errors.Is(&APIError{Response: &http.Response{StatusCode: http.StatusConflict}}, ErrBadRequest) {
+		t.Error("Expected 409 not to match ErrBadRequest")
+	}
+}
+
+func TestFile_Helpers(t *testing.T) {
+	tests := []struct {
+		file     File
+		category FileCategory
+		size     string
+	}{
+		{File{Filename: "photo.JPG", Filesize: 512}, FileCategoryImage, "512 B"},
+		{File{Filename: "report.pdf", Filesize: 1536}, FileCategoryDocument, "1.5 KB"},
+		{File{Filename: "backup.tar.gz", Filesize: 3 * 1024 * 1024}, FileCategoryArchive, "3.0 MB"},
+		{File{Filename: "binary", Filesize: 5 * 1024 * 1024 * 1024}, FileCategoryOther, "5.0 GB"},
+	}
+
+	for _, tt := range tests {
+		if got := tt.file.ContentCategory(); got != tt.category {
+			t.Errorf("%s: expected category %s, got %s", tt.file.Filename, tt.category, got)
+		}
+		if got := tt.file.HumanSize(); got != tt.size {
+			t.Errorf("%s: expected size %s, got %s", tt.file.Filename, tt.size, got)
+		}
+		if got := tt.file.IsImage(); got != (tt.category == FileCategoryImage) {
+			t.Errorf("%s: unexpected IsImage() = %v", tt.file.Filename, got)
+		}
+	}
+}
+
+func TestOptionDefaultTimeout(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(50 * time.Millisecond)
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	client := New(testToken, OptionDefaultTimeout(10*time.Millisecond))
+	client.BaseURL, _ = url.Parse(server.URL)
+
+	if _, _, err := client.Me.Get(context.Background()); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected default timeout to apply, got %v", err)
+	}
+
+	if _, _, err := client.Me.Get(context.Background(), WithTimeout(time.Second)); err != nil {
+		t.Errorf("Expected WithTimeout to override the default, got %v", err)
+	}
+}
+
+func TestOptionTransport(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/me" {
+			time.Sleep(50 * time.Millisecond)
+		}
+		w.Write([]byte(`[]`))
+	}))
+	defer server.Close()
+
+	client := New(testToken, OptionTransport(TransportSettings{ResponseHeaderTimeout: 10 * time.Millisecond}))
+	client.BaseURL, _ = url.Parse(server.URL)
+
+	transport, ok := client.client.Transport.(*http.Transport)
+	if !ok {
+		t.Fatalf("Expected an *http.Transport, got %T", client.client.Transport)
+	}
+	if transport.MaxIdleConnsPerHost != 10 || transport.TLSHandshakeTimeout != 10*time.Second || transport.IdleConnTimeout != 90*time.Second {
+		t.Errorf("Expected defaults for unset settings, got %+v", transport)
+	}
+
+	if _, _, err := client.Me.Get(context.Background()); err == nil || !strings.Contains(err.Error(), "timeout") {
+		t.Errorf("Expected the response header timeout to apply, got %v", err)
+	}
+	if _, _, err := client.Rooms.List(context.Background()); err != nil {
+		t.Errorf("Rooms.List returned error: %v", err)
+	}
+}
+
+func TestWithProgress(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		io.Copy(io.Discard, r.Body)
+		w.Write([]byte(`{"message_id":"1"}`))
+	}))
+	defer server.Close()
+
+	client := New(testToken)
+	client.BaseURL, _ = url.Parse(server.URL)
+
+	var transferred, total int64
+	body := strings.Repeat("x", 100000)
+	_, _, err := client.Messages.SendMessage(context.Background(), 1, body, WithProgress(func(n, size int64) {
+		transferred, total = n, size
+	}))
+	if err != nil {
+		t.Fatalf("SendMessage returned error: %v", err)
+	}
+	if want := int64(len("body=") + len(body)); transferred != want || total != want {
+		t.Errorf("Expected progress to end at %d of %d bytes, got %d of %d", want, want, transferred, total)
+	}
+}
+
+func TestRoomsService_UploadFile(t *testing.T) {
+	const size = 1 << 20
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "POST" || r.URL.Path != "/rooms/1/files" {
+			t.Errorf("Unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		if r.ContentLength != -1 {
+			t.Errorf("Expected a streamed body without Content-Length, got %d", r.ContentLength)
+		}
+		if err := r.ParseMultipartForm(1024); err != nil {
+			t.Fatalf("ParseMultipartForm returned error: %v", err)
+		}
+		if got := r.FormValue("message"); got != "Weekly report" {
+			t.Errorf("Expected message %q, got %q", "Weekly report", got)
+		}
+		part, header, err := r.FormFile("file")
+		if err != nil {
+			t.Fatalf("FormFile returned error: %v", err)
+		}
+		defer part.Close()
+		n, _ := io.Copy(io.Discard, part)
+		if header.Filename != `report "final".pdf` || header.Header.Get("Content-Type") != "application/pdf" || n != size {
+			t.Errorf("Unexpected file %q (%s) of %d bytes", header.Filename, header.Header.Get("Content-Type"), n)
+		}
+		w.Write([]byte(`{"file_id":42}`))
+	}))
+	defer server.Close()
+
+	client := New(testToken)
+	client.BaseURL, _ = url.Parse(server.URL)
+
+	var transferred, total int64
+	content := io.LimitReader(strings.NewReader(strings.Repeat("x", size+1)), size)
+	result, _, err := client.Rooms.UploadFile(context.Background(), 1, `report "final".pdf`, content,
+		&FileUploadParams{Message: "Weekly report"},
+		WithProgress(func(n, size int64) { transferred, total = n, size }))
+	if err != nil {
+		t.Fatalf("UploadFile returned error: %v", err)
+	}
+	if result.FileID != 42 {
+		t.Errorf("Expected file ID 42, got %d", result.FileID)
+	}
+	if transferred <= size || total != -1 {
+		t.Errorf("Expected progress past %d bytes of an unknown total, got %d of %d", size, transferred, total)
+	}
+
+	if _, _, err := client.Rooms.UploadFile(context.Background(), 1, "", content, nil); !errors.Is(err, ErrInvalidParams) {
+		t.Errorf("Expected ErrInvalidParams without a filename, got %v", err)
+	}
+
+	dryRun := New(testToken, OptionDryRun(true), OptionLogger(slog.New(slog.NewTextHandler(io.Discard, nil))))
+	dryRun.BaseURL, _ = url.Parse(server.URL)
+	if _, resp, err := dryRun.Rooms.UploadFile(context.Background(), 1, "a.txt", strings.NewReader("a"), nil); err != nil || !resp.DryRun {
+		t.Errorf("Expected an unsent upload to return, got %v", err)
+	}
+}
+
+func TestOptionStrictDecoding(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"account_id":1,"name":"Alice","pronouns":"they/them"}`))
+	}))
+	defer server.Close()
+
+	lenient := New(testToken)
+	lenient.BaseURL, _ = url.Parse(server.URL)
+	if me, _, err := lenient.Me.Get(context.Background()); err != nil || me.Name != "Alice" {
+		t.Errorf("Expected unknown fields to be ignored by default, got %+v, %v", me, err)
+	}
+
+	strict := New(testToken, OptionStrictDecoding(true))
+	strict.BaseURL, _ = url.Parse(server.URL)
+	if _, _, err := strict.Me.Get(context.Background()); err == nil || !strings.Contains(err.Error(), "pronouns") {
+		t.Errorf("Expected the unknown field to be reported, got %v", err)
+	}
+}
+
+func TestRawBody(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/rooms" {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"errors":["Invalid name"]}`))
+			return
+		}
+		w.Write([]byte(`{"account_id":1,"name":"Alice"}`))
+	}))
+	defer server.Close()
+
+	client := New(testToken)
+	client.BaseURL, _ = url.Parse(server.URL)
+	ctx := context.Background()
+
+	me, resp, err := client.Me.Get(ctx)
+	if err != nil || resp.RawBody != nil {
+		t.Errorf("Expected no raw body without the option, got %q, %v", resp.RawBody, err)
+	}
+	me, resp, err = client.Me.Get(ctx, WithRawBody())
+	if err != nil || me.Name != "Alice" || string(resp.RawBody) != `{"account_id":1,"name":"Alice"}` {
+		t.Errorf("Expected the body to be decoded and captured, got %+v, %q, %v", me, resp.RawBody, err)
+	}
+
+	client = New(testToken, OptionCaptureBody(true))
+	client.BaseURL, _ = url.Parse(server.URL)
+	_, resp, err = client.Rooms.Create(ctx, &RoomCreateParams{Name: "x", MembersAdminIDs: []AccountID{1}})
+	if !errors.Is(err, ErrBadRequest) || string(resp.RawBody) != `{"errors":["Invalid name"]}` {
+		t.Errorf("Expected the error body to be captured, got %q, %v", resp.RawBody, err)
+	}
+}
+
+func TestOptionMiddleware(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	var order []string
+	record := func(name string) Middleware {
+		return func(next http.RoundTripper) http.RoundTripper {
+			return RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+				order = append(order, name)
+				return next.RoundTrip(req)
+			})
+		}
+	}
+
+	httpClient := &http.Client{}
+	client := New(testToken, OptionMiddleware(record("first")), OptionHTTPClient(httpClient), OptionMiddleware(record("second")))
+	client.BaseURL, _ = url.Parse(server.URL)
+
+	if _, _, err := client.Me.Get(context.Background()); err != nil {
+		t.Fatalf("Me.Get returned error: %v", err)
+	}
+
+	if strings.Join(order, ",") != "first,second" {
+		t.Errorf("Expected middlewares to run in registration order, got %v", order)
+	}
+	if httpClient.Transport != nil {
+		t.Error("Expected the caller's http.Client not to be modified")
+	}
+}
+
+func TestRoomsService_IterFiles(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`[{"file_id": 3}, {"file_id": 1}, {"file_id": 2}]`))
+	}))
+	defer server.Close()
+
+	client := New(testToken)
+	client.BaseURL, _ = url.Parse(server.URL)
+
+	it := client.Rooms.IterFiles(context.Background(), 1, nil)
+	if !it.Next() || it.File().FileID != 1 {
+		t.Fatalf("Expected first file to be 1, got %+v (err %v)", it.File(), it.Err())
+	}
+	token := it.Token()
+
+	resumed := client.Rooms.IterFiles(context.Background(), 1, &FileIterParams{StartToken: token})
+	var ids []int
+	for resumed.Next() {
+		ids = append(ids, resumed.File().FileID)
+	}
+	if err := resumed.Err(); err != nil {
+		t.Fatalf("Iteration returned error: %v", err)
+	}
+	if fmt.Sprint(ids) != "[2 3]" {
+		t.Errorf("Expected resumed iteration to yield [2 3], got %v", ids)
+	}
+
+	invalid := client.Rooms.IterFiles(context.Background(), 1, &FileIterParams{StartToken: "garbage"})
+	if invalid.Next() || invalid.Err() == nil {
+		t.Error("Expected an invalid token to stop iteration with an error")
+	}
+}
+
+func TestOptionHooks(t *testing.T) {
+	var gotHeader string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotHeader = r.Header.Get("X-Correlation-ID")
+		time.Sleep(10 * time.Millisecond)
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	var gotStatus int
+	var gotErr error
+	var gotLatency time.Duration
+	client := New(testToken,
+		OptionOnRequest(func(req *http.Request) { req.Header.Set("X-Correlation-ID", "42") }),
+		OptionOnResponse(func(resp *Response, err error) {
+			gotStatus = resp.StatusCode
+			gotErr = err
+			gotLatency = resp.Latency
+		}),
+	)
+	client.BaseURL, _ = url.Parse(server.URL)
+
+	_, _, err := client.Me.Get(context.Background())
+	if gotHeader != "42" {
+		t.Errorf("Expected request hook to set header, got %q", gotHeader)
+	}
+	if gotStatus != http.StatusNotFound || gotErr != err {
+		t.Errorf("Expected response hook to see status 404 and the returned error, got %d and %v", gotStatus, gotErr)
+	}
+	if gotLatency < 10*time.Millisecond {
+		t.Errorf("Expected response hook to see the latency of the attempt, got %v", gotLatency)
+	}
+}
+
+func TestDo(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/rooms/1/links":
+			w.Write([]byte(`[{"url":"https://example.com"}]`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"errors":["Not found"]}`))
+		}
+	}))
+	defer server.Close()
+
+	client := New(testToken)
+	client.BaseURL, _ = url.Parse(server.URL)
+
+	type link struct {
+		URL string `json:"url"`
+	}
+
+	req, err := client.NewRequest(http.MethodGet, "rooms/1/links", nil)
+	if err != nil {
+		t.Fatalf("NewRequest returned error: %v", err)
+	}
+	links, resp, err := Do[[]link](context.Background(), client, req)
+	if err != nil {
+		t.Fatalf("Do returned error: %v", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("Expected status 200, got %d", resp.StatusCode)
+	}
+	if len(*links) != 1 || (*links)[0].URL != "https://example.com" {
+		t.Errorf("Unexpected links: %+v", *links)
+	}
+
+	req, _ = client.NewRequest(http.MethodGet, "rooms/2/links", nil)
+	links, _, err = Do[[]link](context.Background(), client, req)
+	if !errors.Is(err, ErrNotFound) {
+		t.Errorf("Expected ErrNotFound, got %v", err)
+	}
+	if links != nil {
+		t.Errorf("Expected nil result on error, got %+v", links)
+	}
+}
+
+func TestMessagesService_SendCode(t *testing.T) {
+	var body string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		r.ParseForm()
+		body = r.PostForm.Get("body")
+		w.Write([]byte(`{"message_id":"1"}`))
+	}))
+	defer server.Close()
+
+	client := New(testToken)
+	client.BaseURL, _ = url.Parse(server.URL)
+
+	if _, _, err := client.Messages.SendCode(context.Background(), 1, "- [code]\n+ [/code]"); err != nil {
+		t.Fatalf("SendCode returned error: %v", err)
+	}
+	if body != "[code]- [\u200bcode]\n+ [\u200b/code][/code]" {
+		t.Errorf("Unexpected body: %q", body)
+	}
+}
+
+func TestMessagesService_SendToAll(t *testing.T) {
+	var body string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		r.ParseForm()
+		body = r.PostForm.Get("body")
+		w.Write([]byte(`{"message_id":"1"}`))
+	}))
+	defer server.Close()
+
+	client := New(testToken)
+	client.BaseURL, _ = url.Parse(server.URL)
+
+	if _, _, err := client.Messages.SendToAll(context.Background(), 1, "Maintenance at 18:00"); err != nil {
+		t.Fatalf("SendToAll returned error: %v", err)
+	}
+	if body != "[toall]\nMaintenance at 18:00" {
+		t.Errorf("Unexpected body: %q", body)
+	}
+
+	params := (&MessageCreateParams{Body: "[toall]\nhi"}).ToAll()
+	if params.Body != "[toall]\nhi" {
+		t.Errorf("Expected ToAll not to add a second mention, got %q", params.Body)
+	}
+}
+
+func TestMessagesService_ListAll(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("force") != "1" {
+			t.Errorf("Expected force=1, got %q", r.URL.RawQuery)
+		}
+		w.Write([]byte(`[
+			{"message_id":"30","send_time":300},
+			{"message_id":"10","send_time":100},
+			{"message_id":"20","send_time":200},
+			{"message_id":"40","send_time":400}
+		]`))
+	}))
+	defer server.Close()
+
+	client := New(testToken)
+	client.BaseURL, _ = url.Parse(server.URL)
+
+	messages, _, err := client.Messages.ListAll(context.Background(), 1, &MessageListAllParams{Since: 200, Limit: 2})
+	if err != nil {
+		t.Fatalf("ListAll returned error: %v", err)
+	}
+	var ids []MessageID
+	for _, m := range messages {
+		ids = append(ids, m.MessageID)
+	}
+	if want := []MessageID{"30", "40"}; !reflect.DeepEqual(ids, want) {
+		t.Errorf("Expected messages %v, got %v", want, ids)
+	}
+}
+
+func TestRoomsService_FindByName(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`[
+			{"room_id":1,"name":"deploy-api"},
+			{"room_id":2,"name":"Incidents"},
+			{"room_id":3,"name":"deploy-web"}
+		]`))
+	}))
+	defer server.Close()
+
+	client := New(testToken)
+	client.BaseURL, _ = url.Parse(server.URL)
+	ctx := context.Background()
+
+	room, _, err := client.Rooms.FindByName(ctx, "Incidents")
+	if err != nil {
+		t.Fatalf("FindByName returned error: %v", err)
+	}
+	if room.RoomID != 2 {
+		t.Errorf("Expected room 2, got %d", room.RoomID)
+	}
+	if _, _, err := client.Rooms.FindByName(ctx, "incidents"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Expected ErrNotFound, got %v", err)
+	}
+
+	for _, match := range []func(*Room) bool{RoomNamePrefix("deploy-"), RoomNameMatches(regexp.MustCompile(`^deploy-(api|web)$`))} {
+		rooms, _, err := client.Rooms.Search(ctx, match)
+		if err != nil {
+			t.Fatalf("Search returned error: %v", err)
+		}
+		if len(rooms) != 2 || rooms[0].RoomID != 1 || rooms[1].RoomID != 3 {
+			t.Errorf("Expected the deploy rooms, got %v", rooms)
+		}
+	}
+}
+
+func TestRoomsService_ListByType(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`[
+			{"room_id":1,"type":"my","role":"admin"},
+			{"room_id":2,"type":"group","role":"admin"},
+			{"room_id":3,"type":"group","role":"member"},
+			{"room_id":4,"type":"direct","role":"member"}
+		]`))
+	}))
+	defer server.Close()
+
+	client := New(testToken)
+	client.BaseURL, _ = url.Parse(server.URL)
+	ctx := context.Background()
+
+	ids := func(rooms []*Room, err error) []RoomID {
+		if err != nil {
+			t.Fatalf("Listing returned error: %v", err)
+		}
+		var ids []RoomID
+		for _, room := range rooms {
+			ids = append(ids, room.RoomID)
+		}
+		return ids
+	}
+
+	groups, _, err := client.Rooms.ListGroupChats(ctx)
+	if got := ids(groups, err); !reflect.DeepEqual(got, []RoomID{2, 3}) {
+		t.Errorf("Expected group chats [2 3], got %v", got)
+	}
+	direct, _, err := client.Rooms.ListDirectChats(ctx)
+	if got := ids(direct, err); !reflect.DeepEqual(got, []RoomID{4}) {
+		t.Errorf("Expected direct chats [4], got %v", got)
+	}
+	administered, _, err := client.Rooms.Search(ctx, RoomMatchesAll(RoomTypeIs("group"), RoomRoleIs("admin")))
+	if got := ids(administered, err); !reflect.DeepEqual(got, []RoomID{2}) {
+		t.Errorf("Expected administered group chats [2], got %v", got)
+	}
+	my, _, err := client.Rooms.GetMyChat(ctx)
+	if err != nil || my.RoomID != 1 {
+		t.Errorf("Expected my chat 1, got %v, %v", my, err)
+	}
+}
+
+func TestRoomsService_GetMessagesUnreadCount(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/rooms/1/messages/unread" {
+			t.Errorf("Unexpected path %s", r.URL.Path)
+		}
+		w.Write([]byte(`{"unread_num":3,"mention_num":1}`))
+	}))
+	defer server.Close()
+
+	client := New(testToken)
+	client.BaseURL, _ = url.Parse(server.URL)
+
+	count, _, err := client.Rooms.GetMessagesUnreadCount(context.Background(), 1)
+	if err != nil {
+		t.Fatalf("GetMessagesUnreadCount returned error: %v", err)
+	}
+	if want := (UnreadCount{UnreadNum: 3, MentionNum: 1}); *count != want {
+		t.Errorf("Expected %+v, got %+v", want, *count)
+	}
+
+	room := &Room{UnreadNum: 3}
+	if !room.HasUnread() || room.HasMention() {
+		t.Errorf("Unexpected HasUnread %v and HasMention %v", room.HasUnread(), room.HasMention())
+	}
+}
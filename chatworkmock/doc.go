@@ -0,0 +1,22 @@
+// Package chatworkmock provides mock implementations of the chatwork service
+// interfaces, so that application tests can stub API calls without an HTTP
+// server:
+//
+//	messages := &chatworkmock.MessagesAPIMock{
+//		SendMessageFunc: func(ctx context.Context, roomID chatwork.RoomID, body string, opts ...chatwork.RequestOption) (*chatwork.MessageCreatedResponse, *chatwork.Response, error) {
+//			return &chatwork.MessageCreatedResponse{MessageID: "1"}, nil, nil
+//		},
+//	}
+//
+//	notifier := NewNotifier(messages) // accepts a chatwork.MessagesAPI
+//	notifier.Notify(ctx, "deployed")
+//
+//	if calls := messages.SendMessageCalls(); len(calls) != 1 {
+//		t.Errorf("expected one message, got %d", len(calls))
+//	}
+//
+// The mocks are generated from the interfaces; run go generate in this
+// directory after changing them.
+package chatworkmock
+
+//go:generate go run gen.go
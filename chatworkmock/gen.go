@@ -0,0 +1,253 @@
+//go:build ignore
+
+// This program generates mocks.go from the service interfaces declared in
+// the chatwork package. Run it with go generate after changing an interface.
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/printer"
+	"go/token"
+	"log"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+const (
+	source    = "../interfaces.go"
+	output    = "mocks.go"
+	pkgPath   = "github.com/nashirox/chatwork-go"
+	pkgPrefix = "chatwork"
+)
+
+type param struct {
+	name     string
+	field    string
+	typ      string // type as written in a function signature
+	slice    string // type as stored in the calls struct
+	variadic bool
+}
+
+type method struct {
+	name    string
+	params  []param
+	results string
+}
+
+func main() {
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, source, nil, parser.ParseComments)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	imports := []string{strconv.Quote("sync")}
+	for _, spec := range file.Imports {
+		imports = append(imports, spec.Path.Value)
+	}
+	sort.Strings(imports)
+
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "// Code generated by gen.go; DO NOT EDIT.\n\npackage chatworkmock\n\nimport (\n")
+	for _, path := range imports {
+		fmt.Fprintf(&buf, "\t%s\n", path)
+	}
+	fmt.Fprintf(&buf, "\n\t%q\n)\n", pkgPath)
+
+	for _, decl := range file.Decls {
+		gen, ok := decl.(*ast.GenDecl)
+		if !ok || gen.Tok != token.TYPE {
+			continue
+		}
+		for _, spec := range gen.Specs {
+			ts := spec.(*ast.TypeSpec)
+			iface, ok := ts.Type.(*ast.InterfaceType)
+			if !ok {
+				continue
+			}
+			writeMock(&buf, fset, ts.Name.Name, iface)
+		}
+	}
+
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		log.Fatalf("formatting generated code: %v\n%s", err, buf.Bytes())
+	}
+	if err := os.WriteFile(output, src, 0o644); err != nil {
+		log.Fatal(err)
+	}
+}
+
+func writeMock(buf *bytes.Buffer, fset *token.FileSet, iface string, it *ast.InterfaceType) {
+	var methods []method
+	for _, field := range it.Methods.List {
+		fn := field.Type.(*ast.FuncType)
+		methods = append(methods, method{
+			name:    field.Names[0].Name,
+			params:  params(fset, fn),
+			results: results(fset, fn),
+		})
+	}
+
+	mock := iface + "Mock"
+	fmt.Fprintf(buf, "\n// Ensure %s implements chatwork.%s.\nvar _ chatwork.%s = &%s{}\n", mock, iface, iface, mock)
+	fmt.Fprintf(buf, "\n// %s is a mock implementation of chatwork.%s.\n//\n", mock, iface)
+	fmt.Fprintf(buf, "// Set the function field of each method the code under test calls; calling a\n")
+	fmt.Fprintf(buf, "// method whose function is nil panics. The arguments of every call are\n")
+	fmt.Fprintf(buf, "// recorded and can be inspected with the method's Calls function.\n")
+	fmt.Fprintf(buf, "type %s struct {\n", mock)
+	for _, m := range methods {
+		fmt.Fprintf(buf, "\t// %sFunc mocks the %s method.\n", m.name, m.name)
+		fmt.Fprintf(buf, "\t%sFunc func(%s) %s\n\n", m.name, signature(m.params), m.results)
+	}
+	fmt.Fprintf(buf, "\tcalls struct {\n")
+	for _, m := range methods {
+		fmt.Fprintf(buf, "\t\t%s []%s\n", m.name, callStruct(m.params))
+	}
+	fmt.Fprintf(buf, "\t}\n")
+	for _, m := range methods {
+		fmt.Fprintf(buf, "\tlock%s sync.RWMutex\n", m.name)
+	}
+	fmt.Fprintf(buf, "}\n")
+
+	for _, m := range methods {
+		var args, values []string
+		for _, p := range m.params {
+			values = append(values, fmt.Sprintf("%s: %s,", p.field, p.name))
+			if p.variadic {
+				args = append(args, p.name+"...")
+			} else {
+				args = append(args, p.name)
+			}
+		}
+
+		fmt.Fprintf(buf, "\n// %s calls %sFunc.\n", m.name, m.name)
+		fmt.Fprintf(buf, "func (mock *%s) %s(%s) %s {\n", mock, m.name, signature(m.params), m.results)
+		fmt.Fprintf(buf, "\tif mock.%sFunc == nil {\n", m.name)
+		fmt.Fprintf(buf, "\t\tpanic(\"%s.%sFunc: method is nil but %s.%s was just called\")\n", mock, m.name, iface, m.name)
+		fmt.Fprintf(buf, "\t}\n")
+		fmt.Fprintf(buf, "\tcallInfo := %s{\n%s\n}\n", callStruct(m.params), strings.Join(values, "\n"))
+		fmt.Fprintf(buf, "\tmock.lock%s.Lock()\n", m.name)
+		fmt.Fprintf(buf, "\tmock.calls.%s = append(mock.calls.%s, callInfo)\n", m.name, m.name)
+		fmt.Fprintf(buf, "\tmock.lock%s.Unlock()\n", m.name)
+		if m.results == "" {
+			fmt.Fprintf(buf, "\tmock.%sFunc(%s)\n", m.name, strings.Join(args, ", "))
+		} else {
+			fmt.Fprintf(buf, "\treturn mock.%sFunc(%s)\n", m.name, strings.Join(args, ", "))
+		}
+		fmt.Fprintf(buf, "}\n")
+
+		fmt.Fprintf(buf, "\n// %sCalls returns the calls made to %s.\n", m.name, m.name)
+		fmt.Fprintf(buf, "func (mock *%s) %sCalls() []%s {\n", mock, m.name, callStruct(m.params))
+		fmt.Fprintf(buf, "\tmock.lock%s.RLock()\n", m.name)
+		fmt.Fprintf(buf, "\tdefer mock.lock%s.RUnlock()\n", m.name)
+		fmt.Fprintf(buf, "\treturn mock.calls.%s\n", m.name)
+		fmt.Fprintf(buf, "}\n")
+	}
+}
+
+func params(fset *token.FileSet, fn *ast.FuncType) []param {
+	var ps []param
+	for _, field := range fn.Params.List {
+		typ := field.Type
+		variadic := false
+		if ellipsis, ok := typ.(*ast.Ellipsis); ok {
+			typ = ellipsis.Elt
+			variadic = true
+		}
+
+		elem := expr(fset, typ)
+		for _, name := range field.Names {
+			p := param{name: name.Name, field: exported(name.Name), typ: elem, slice: elem, variadic: variadic}
+			if variadic {
+				p.typ = "..." + elem
+				p.slice = "[]" + elem
+			}
+			ps = append(ps, p)
+		}
+	}
+	return ps
+}
+
+func results(fset *token.FileSet, fn *ast.FuncType) string {
+	if fn.Results == nil {
+		return ""
+	}
+	var rs []string
+	for _, field := range fn.Results.List {
+		rs = append(rs, expr(fset, field.Type))
+	}
+	if len(rs) == 1 {
+		return rs[0]
+	}
+	return "(" + strings.Join(rs, ", ") + ")"
+}
+
+func signature(ps []param) string {
+	var parts []string
+	for _, p := range ps {
+		parts = append(parts, p.name+" "+p.typ)
+	}
+	return strings.Join(parts, ", ")
+}
+
+func callStruct(ps []param) string {
+	var fields []string
+	for _, p := range ps {
+		fields = append(fields, p.field+" "+p.slice)
+	}
+	return "struct {\n" + strings.Join(fields, "\n") + "\n}"
+}
+
+// expr prints a type expression, qualifying identifiers declared in the
+// chatwork package.
+func expr(fset *token.FileSet, e ast.Expr) string {
+	e = qualify(e)
+	var buf bytes.Buffer
+	if err := printer.Fprint(&buf, fset, e); err != nil {
+		log.Fatal(err)
+	}
+	return buf.String()
+}
+
+func qualify(e ast.Expr) ast.Expr {
+	ast.Inspect(e, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.SelectorExpr:
+			return false
+		case *ast.StarExpr:
+			n.X = qualifyIdent(n.X)
+		case *ast.ArrayType:
+			n.Elt = qualifyIdent(n.Elt)
+		case *ast.MapType:
+			n.Key = qualifyIdent(n.Key)
+			n.Value = qualifyIdent(n.Value)
+		case *ast.Ellipsis:
+			n.Elt = qualifyIdent(n.Elt)
+		case *ast.Field:
+			n.Type = qualifyIdent(n.Type)
+		}
+		return true
+	})
+	return qualifyIdent(e)
+}
+
+func qualifyIdent(e ast.Expr) ast.Expr {
+	if id, ok := e.(*ast.Ident); ok && ast.IsExported(id.Name) {
+		return &ast.SelectorExpr{X: ast.NewIdent(pkgPrefix), Sel: ast.NewIdent(id.Name)}
+	}
+	return e
+}
+
+func exported(name string) string {
+	r := []rune(name)
+	r[0] = unicode.ToUpper(r[0])
+	return string(r)
+}
@@ -0,0 +1,36 @@
+package chatworkmock
+
+import (
+	"context"
+	"testing"
+
+	"github.com/nashirox/chatwork-go"
+)
+
+func TestMessagesAPIMock(t *testing.T) {
+	mock := &MessagesAPIMock{
+		SendMessageFunc: func(ctx context.Context, roomID chatwork.RoomID, body string, opts ...chatwork.RequestOption) (*chatwork.MessageCreatedResponse, *chatwork.Response, error) {
+			return &chatwork.MessageCreatedResponse{MessageID: "1"}, nil, nil
+		},
+	}
+
+	var messages chatwork.MessagesAPI = mock
+	created, _, err := messages.SendMessage(context.Background(), 42, "hello", chatwork.WithNoRetry())
+	if err != nil || created.MessageID != "1" {
+		t.Fatalf("Unexpected result %+v, %v", created, err)
+	}
+
+	calls := mock.SendMessageCalls()
+	if len(calls) != 1 || calls[0].RoomID != 42 || calls[0].Body != "hello" || len(calls[0].Opts) != 1 {
+		t.Errorf("Unexpected calls %+v", calls)
+	}
+}
+
+func TestMessagesAPIMock_Unset(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("Expected a panic for a method without a function")
+		}
+	}()
+	(&MessagesAPIMock{}).Get(context.Background(), 1, "1")
+}
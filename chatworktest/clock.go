@@ -0,0 +1,169 @@
+package chatworktest
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+// Clock is a fake chatwork.Clock whose time only moves when advanced, for
+// deterministic tests of Client.Watch and UnreadMonitor. Install it with
+// chatwork.OptionClock. It is safe for concurrent use.
+//
+// Waiting goroutines are woken as Advance passes their deadlines, in time
+// order, and Scenarios registered on the clock apply their changes at the
+// same time. Advance does not wait for woken goroutines to poll; use
+// BlockUntil to wait until they are waiting again.
+type Clock struct {
+	mu      sync.Mutex
+	cond    *sync.Cond
+	now     time.Time
+	waiters []*waiter
+	events  []*event
+}
+
+type waiter struct {
+	at time.Time
+	ch chan time.Time
+}
+
+// event is a change of a Scenario, applied once the clock reaches at.
+type event struct {
+	at    time.Time
+	apply func()
+}
+
+// NewClock returns a Clock set to start.
+func NewClock(start time.Time) *Clock {
+	c := &Clock{now: start}
+	c.cond = sync.NewCond(&c.mu)
+	return c
+}
+
+// Now returns the current time of the clock.
+func (c *Clock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+// NewTimer returns a channel that receives the clock's time once it has been
+// advanced by d. Until it fires or stop is called, the caller counts as
+// waiting on the clock.
+func (c *Clock) NewTimer(d time.Duration) (<-chan time.Time, func()) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	ch := make(chan time.Time, 1)
+	if d <= 0 {
+		ch <- c.now
+		return ch, func() {}
+	}
+	w := &waiter{at: c.now.Add(d), ch: ch}
+	c.waiters = append(c.waiters, w)
+	c.cond.Broadcast()
+	return ch, func() { c.stop(w) }
+}
+
+// stop removes w from the waiters, if it has not fired.
+func (c *Clock) stop(w *waiter) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for i, waiting := range c.waiters {
+		if waiting == w {
+			c.waiters = append(c.waiters[:i], c.waiters[i+1:]...)
+			return
+		}
+	}
+}
+
+// Advance moves the clock forward by d. Scenario changes and waiters due
+// in the meantime are handled in time order, changes before the waiters of
+// the same instant, so that a goroutine woken at a time sees the changes
+// made up to it.
+func (c *Clock) Advance(d time.Duration) {
+	c.mu.Lock()
+	end := c.now.Add(d)
+	c.mu.Unlock()
+
+	for {
+		c.mu.Lock()
+		next, ok := c.next(end)
+		if !ok {
+			c.now = end
+			c.mu.Unlock()
+			return
+		}
+		if next.After(c.now) {
+			c.now = next
+		}
+		next = c.now
+
+		var due []*event
+		for len(c.events) > 0 && !c.events[0].at.After(next) {
+			due = append(due, c.events[0])
+			c.events = c.events[1:]
+		}
+		if len(due) > 0 {
+			// Changes are applied without the lock, as they may call the
+			// server, whose clients may be waiting on the clock.
+			c.mu.Unlock()
+			for _, e := range due {
+				e.apply()
+			}
+			continue
+		}
+
+		waiters := c.waiters[:0]
+		for _, w := range c.waiters {
+			if w.at.After(next) {
+				waiters = append(waiters, w)
+				continue
+			}
+			w.ch <- next
+		}
+		c.waiters = waiters
+		c.mu.Unlock()
+	}
+}
+
+// next returns the earliest time, no later than end, of a pending change or
+// waiter.
+func (c *Clock) next(end time.Time) (time.Time, bool) {
+	next, ok := time.Time{}, false
+	consider := func(at time.Time) {
+		if !at.After(end) && (!ok || at.Before(next)) {
+			next, ok = at, true
+		}
+	}
+	if len(c.events) > 0 {
+		consider(c.events[0].at)
+	}
+	for _, w := range c.waiters {
+		consider(w.at)
+	}
+	return next, ok
+}
+
+// BlockUntil waits until n goroutines are waiting on the clock, such as a
+// watcher that finished its poll and sleeps until the next one. Goroutines
+// that stopped waiting, because their context was canceled, are not counted.
+func (c *Clock) BlockUntil(n int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for len(c.waiters) < n {
+		c.cond.Wait()
+	}
+}
+
+// schedule registers a change to apply once the clock reaches at.
+func (c *Clock) schedule(at time.Time, apply func()) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.events = append(c.events, &event{at: at, apply: apply})
+	sort.SliceStable(c.events, func(i, j int) bool {
+		return c.events[i].at.Before(c.events[j].at)
+	})
+}
@@ -0,0 +1,726 @@
+package chatworktest
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/nashirox/chatwork-go"
+)
+
+// maxListedMessages is the number of messages returned by a forced listing.
+const maxListedMessages = 100
+
+func (s *Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if strings.HasPrefix(r.URL.Path, "/download/") {
+		s.serveDownload(w, r)
+		return
+	}
+
+	path, ok := strings.CutPrefix(r.URL.Path, "/v2/")
+	if !ok {
+		writeError(w, http.StatusNotFound, "Resource not found")
+		return
+	}
+	path = strings.Trim(path, "/")
+
+	if !s.authorized(r) {
+		writeError(w, http.StatusUnauthorized, "Invalid API token")
+		return
+	}
+	if f, ok := s.failures[r.Method+" "+path]; ok {
+		writeError(w, f.status, f.messages...)
+		return
+	}
+
+	form, err := readForm(r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	segments := strings.Split(path, "/")
+	switch segments[0] {
+	case "me":
+		s.serveMe(w, r, segments)
+	case "my":
+		s.serveMy(w, r, form, segments)
+	case "contacts":
+		s.serveContacts(w, r, segments)
+	case "incoming_requests":
+		s.serveIncomingRequests(w, r, segments)
+	case "rooms":
+		s.serveRooms(w, r, form, segments)
+	default:
+		writeError(w, http.StatusNotFound, "Resource not found")
+	}
+}
+
+func (s *Server) authorized(r *http.Request) bool {
+	if r.Header.Get("X-ChatWorkToken") == Token {
+		return true
+	}
+	return r.Header.Get("Authorization") == "Bearer "+Token
+}
+
+func (s *Server) serveMe(w http.ResponseWriter, r *http.Request, segments []string) {
+	if len(segments) != 1 || r.Method != "GET" {
+		writeMethodNotAllowed(w)
+		return
+	}
+	writeJSON(w, s.me)
+}
+
+func (s *Server) serveMy(w http.ResponseWriter, r *http.Request, form url.Values, segments []string) {
+	if len(segments) != 2 || r.Method != "GET" {
+		writeMethodNotAllowed(w)
+		return
+	}
+
+	switch segments[1] {
+	case "status":
+		var status chatwork.MyStatus
+		for _, rm := range s.rooms {
+			status.UnreadNum += rm.room.UnreadNum
+			status.MentionNum += rm.room.MentionNum
+			if rm.room.UnreadNum > 0 {
+				status.UnreadRoomNum++
+			}
+			if rm.room.MentionNum > 0 {
+				status.MentionRoomNum++
+			}
+			if n := s.openTasksOfMe(rm); n > 0 {
+				status.MytaskNum += n
+				status.MytaskRoomNum++
+			}
+		}
+		writeJSON(w, status)
+	case "tasks":
+		assignedBy := chatwork.AccountID(atoi(form.Get("assigned_by_account_id")))
+		tasks := []chatwork.MyTask{}
+		for _, rm := range s.sortedRooms() {
+			for _, t := range rm.tasks {
+				if t.Account.AccountID != s.me.AccountID {
+					continue
+				}
+				if assignedBy > 0 && t.AssignedByAccount.AccountID != assignedBy {
+					continue
+				}
+				if status := form.Get("status"); status != "" && string(t.Status) != status {
+					continue
+				}
+				tasks = append(tasks, chatwork.MyTask{
+					TaskID: t.TaskID,
+					Room: chatwork.TaskRoom{
+						RoomID:   rm.room.RoomID,
+						Name:     rm.room.Name,
+						IconPath: rm.room.IconPath,
+					},
+					AssignedByAccount: chatwork.TaskAccount{
+						AccountID:      t.AssignedByAccount.AccountID,
+						Name:           t.AssignedByAccount.Name,
+						AvatarImageURL: t.AssignedByAccount.AvatarImageURL,
+					},
+					MessageID: t.MessageID,
+					Body:      t.Body,
+					LimitTime: t.LimitTime,
+					Status:    t.Status,
+					LimitType: t.LimitType,
+				})
+			}
+		}
+		writeList(w, tasks)
+	default:
+		writeError(w, http.StatusNotFound, "Resource not found")
+	}
+}
+
+func (s *Server) openTasksOfMe(rm *room) int {
+	n := 0
+	for _, t := range rm.tasks {
+		if t.Account.AccountID == s.me.AccountID && t.Status == chatwork.TaskStatusOpen {
+			n++
+		}
+	}
+	return n
+}
+
+func (s *Server) serveContacts(w http.ResponseWriter, r *http.Request, segments []string) {
+	if len(segments) != 1 || r.Method != "GET" {
+		writeMethodNotAllowed(w)
+		return
+	}
+	writeList(w, s.contacts)
+}
+
+func (s *Server) serveIncomingRequests(w http.ResponseWriter, r *http.Request, segments []string) {
+	if len(segments) == 1 {
+		if r.Method != "GET" {
+			writeMethodNotAllowed(w)
+			return
+		}
+		writeList(w, s.requests)
+		return
+	}
+
+	requestID := atoi(segments[1])
+	index := -1
+	for i, req := range s.requests {
+		if req.RequestID == requestID {
+			index = i
+		}
+	}
+	if len(segments) != 2 || index < 0 {
+		writeError(w, http.StatusNotFound, "Resource not found")
+		return
+	}
+	req := s.requests[index]
+
+	switch r.Method {
+	case "PUT":
+		s.requests = append(s.requests[:index], s.requests[index+1:]...)
+		contact := chatwork.Contact{
+			AccountID:        req.AccountID,
+			RoomID:           chatwork.RoomID(s.newID()),
+			Name:             req.Name,
+			ChatworkID:       req.ChatworkID,
+			OrganizationID:   req.OrganizationID,
+			OrganizationName: req.OrganizationName,
+			Department:       req.Department,
+			AvatarImageURL:   req.AvatarImageURL,
+		}
+		s.contacts = append(s.contacts, contact)
+		writeJSON(w, contact)
+	case "DELETE":
+		s.requests = append(s.requests[:index], s.requests[index+1:]...)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		writeMethodNotAllowed(w)
+	}
+}
+
+func (s *Server) serveRooms(w http.ResponseWriter, r *http.Request, form url.Values, segments []string) {
+	if len(segments) == 1 {
+		switch r.Method {
+		case "GET":
+			rooms := []chatwork.Room{}
+			for _, rm := range s.sortedRooms() {
+				rooms = append(rooms, rm.room)
+			}
+			writeJSON(w, rooms)
+		case "POST":
+			s.createRoom(w, form)
+		default:
+			writeMethodNotAllowed(w)
+		}
+		return
+	}
+
+	rm, ok := s.rooms[chatwork.RoomID(atoi(segments[1]))]
+	if !ok {
+		writeError(w, http.StatusNotFound, "You don't have permission to get this room")
+		return
+	}
+	if len(segments) == 2 {
+		s.serveRoom(w, r, form, rm)
+		return
+	}
+
+	switch segments[2] {
+	case "members":
+		s.serveMembers(w, r, form, rm, segments[3:])
+	case "messages":
+		s.serveMessages(w, r, form, rm, segments[3:])
+	case "tasks":
+		s.serveTasks(w, r, form, rm, segments[3:])
+	case "files":
+		s.serveFiles(w, r, form, rm, segments[3:])
+	default:
+		writeError(w, http.StatusNotFound, "Resource not found")
+	}
+}
+
+func (s *Server) createRoom(w http.ResponseWriter, form url.Values) {
+	name := form.Get("name")
+	if name == "" {
+		writeError(w, http.StatusBadRequest, "Parameter [name] is required")
+		return
+	}
+
+	rm := &room{
+		room: chatwork.Room{
+			RoomID:         chatwork.RoomID(s.newID()),
+			Name:           name,
+			Type:           chatwork.RoomTypeGroup,
+			Role:           chatwork.RoleAdmin,
+			Description:    form.Get("description"),
+			IconPath:       iconPath(form.Get("icon_preset")),
+			LastUpdateTime: chatwork.NewTimestamp(time.Now()),
+		},
+	}
+	rm.members = s.members(form)
+	if !hasMember(rm.members, s.me.AccountID) {
+		rm.members = append([]chatwork.Member{s.member(s.me.AccountID, chatwork.RoleAdmin)}, rm.members...)
+	}
+	s.rooms[rm.room.RoomID] = rm
+
+	writeJSON(w, map[string]chatwork.RoomID{"room_id": rm.room.RoomID})
+}
+
+func (s *Server) serveRoom(w http.ResponseWriter, r *http.Request, form url.Values, rm *room) {
+	switch r.Method {
+	case "GET":
+		writeJSON(w, rm.room)
+	case "PUT":
+		if name := form.Get("name"); name != "" {
+			rm.room.Name = name
+		}
+		if description, ok := form["description"]; ok {
+			rm.room.Description = description[0]
+		}
+		if preset := form.Get("icon_preset"); preset != "" {
+			rm.room.IconPath = iconPath(preset)
+		}
+		writeJSON(w, map[string]chatwork.RoomID{"room_id": rm.room.RoomID})
+	case "DELETE":
+		switch form.Get("action_type") {
+		case "leave", "delete":
+			delete(s.rooms, rm.room.RoomID)
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			writeError(w, http.StatusBadRequest, "Invalid value: [action_type]")
+		}
+	default:
+		writeMethodNotAllowed(w)
+	}
+}
+
+func (s *Server) serveMembers(w http.ResponseWriter, r *http.Request, form url.Values, rm *room, rest []string) {
+	if len(rest) != 0 {
+		writeError(w, http.StatusNotFound, "Resource not found")
+		return
+	}
+
+	switch r.Method {
+	case "GET":
+		writeList(w, rm.members)
+	case "PUT":
+		members := s.members(form)
+		if len(form["members_admin_ids"]) == 0 || len(members) == 0 {
+			writeError(w, http.StatusBadRequest, "Parameter [members_admin_ids] is required")
+			return
+		}
+		rm.members = members
+
+		result := map[string][]chatwork.AccountID{"admin": {}, "member": {}, "readonly": {}}
+		for _, m := range members {
+			result[string(m.Role)] = append(result[string(m.Role)], m.AccountID)
+		}
+		writeJSON(w, result)
+	default:
+		writeMethodNotAllowed(w)
+	}
+}
+
+// members builds a member list from the members_*_ids form fields.
+func (s *Server) members(form url.Values) []chatwork.Member {
+	var members []chatwork.Member
+	for _, role := range []chatwork.Role{chatwork.RoleAdmin, chatwork.RoleMember, chatwork.RoleReadonly} {
+		for _, id := range splitIDs(form.Get("members_" + string(role) + "_ids")) {
+			if !hasMember(members, id) {
+				members = append(members, s.member(id, role))
+			}
+		}
+	}
+	return members
+}
+
+func (s *Server) serveMessages(w http.ResponseWriter, r *http.Request, form url.Values, rm *room, rest []string) {
+	if len(rest) == 0 {
+		switch r.Method {
+		case "GET":
+			s.listMessages(w, form, rm)
+		case "POST":
+			body := form.Get("body")
+			if body == "" {
+				writeError(w, http.StatusBadRequest, "Parameter [body] is required")
+				return
+			}
+			id := s.addMessage(rm, chatwork.Message{Account: s.meUser(), Body: body})
+			writeJSON(w, chatwork.MessageCreatedResponse{MessageID: id})
+		default:
+			writeMethodNotAllowed(w)
+		}
+		return
+	}
+	if len(rest) != 1 {
+		writeError(w, http.StatusNotFound, "Resource not found")
+		return
+	}
+
+	switch rest[0] {
+	case "read":
+		switch r.Method {
+		case "GET":
+			writeJSON(w, unreadCount(rm))
+		case "PUT":
+			rm.room.UnreadNum = 0
+			rm.room.MentionNum = 0
+			writeJSON(w, unreadCount(rm))
+		default:
+			writeMethodNotAllowed(w)
+		}
+		return
+	case "unread":
+		if r.Method != "GET" {
+			writeMethodNotAllowed(w)
+			return
+		}
+		writeJSON(w, unreadCount(rm))
+		return
+	}
+
+	index := -1
+	for i, m := range rm.messages {
+		if string(m.MessageID) == rest[0] {
+			index = i
+		}
+	}
+	if index < 0 {
+		writeError(w, http.StatusNotFound, "Message not found")
+		return
+	}
+	message := &rm.messages[index]
+
+	switch r.Method {
+	case "GET":
+		writeJSON(w, message)
+	case "PUT":
+		if message.Account.AccountID != s.me.AccountID {
+			writeError(w, http.StatusForbidden, "You don't have permission to edit this message")
+			return
+		}
+		body := form.Get("body")
+		if body == "" {
+			writeError(w, http.StatusBadRequest, "Parameter [body] is required")
+			return
+		}
+		message.Body = body
+		message.UpdateTime = chatwork.NewTimestamp(time.Now())
+		writeJSON(w, chatwork.MessageCreatedResponse{MessageID: message.MessageID})
+	case "DELETE":
+		if message.Account.AccountID != s.me.AccountID {
+			writeError(w, http.StatusForbidden, "You don't have permission to delete this message")
+			return
+		}
+		id := message.MessageID
+		rm.messages = append(rm.messages[:index], rm.messages[index+1:]...)
+		rm.room.MessageNum--
+		if rm.fetched > index {
+			rm.fetched--
+		}
+		writeJSON(w, chatwork.MessageCreatedResponse{MessageID: id})
+	default:
+		writeMethodNotAllowed(w)
+	}
+}
+
+// listMessages mirrors the API: a forced listing returns the latest 100
+// messages, while an unforced one only returns messages that have not been
+// listed before, with 204 No Content when there are none.
+func (s *Server) listMessages(w http.ResponseWriter, form url.Values, rm *room) {
+	start := rm.fetched
+	if form.Get("force") == "1" {
+		start = 0
+	}
+	if n := len(rm.messages); n-start > maxListedMessages {
+		start = n - maxListedMessages
+	}
+	messages := rm.messages[start:]
+	rm.fetched = len(rm.messages)
+
+	if len(messages) == 0 {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+	writeJSON(w, messages)
+}
+
+func unreadCount(rm *room) map[string]int {
+	return map[string]int{
+		"unread_num":  rm.room.UnreadNum,
+		"mention_num": rm.room.MentionNum,
+	}
+}
+
+func (s *Server) serveTasks(w http.ResponseWriter, r *http.Request, form url.Values, rm *room, rest []string) {
+	if len(rest) == 0 {
+		switch r.Method {
+		case "GET":
+			s.listTasks(w, form, rm)
+		case "POST":
+			s.createTasks(w, form, rm)
+		default:
+			writeMethodNotAllowed(w)
+		}
+		return
+	}
+
+	var task *chatwork.Task
+	for i := range rm.tasks {
+		if rm.tasks[i].TaskID.String() == rest[0] {
+			task = &rm.tasks[i]
+		}
+	}
+	if task == nil || len(rest) > 2 || (len(rest) == 2 && rest[1] != "status") {
+		writeError(w, http.StatusNotFound, "Resource not found")
+		return
+	}
+
+	switch {
+	case len(rest) == 1 && r.Method == "GET":
+		writeJSON(w, task)
+	case len(rest) == 2 && r.Method == "PUT":
+		status := chatwork.TaskStatus(form.Get("body"))
+		if status.Validate() != nil {
+			writeError(w, http.StatusBadRequest, "Invalid value: [body]")
+			return
+		}
+		task.Status = status
+		writeJSON(w, map[string]chatwork.TaskID{"task_id": task.TaskID})
+	default:
+		writeMethodNotAllowed(w)
+	}
+}
+
+func (s *Server) listTasks(w http.ResponseWriter, form url.Values, rm *room) {
+	accountID := chatwork.AccountID(atoi(form.Get("account_id")))
+	assignedBy := chatwork.AccountID(atoi(form.Get("assigned_by_account_id")))
+	status := chatwork.TaskStatus(form.Get("status"))
+
+	tasks := []chatwork.Task{}
+	for _, t := range rm.tasks {
+		if accountID > 0 && t.Account.AccountID != accountID {
+			continue
+		}
+		if assignedBy > 0 && t.AssignedByAccount.AccountID != assignedBy {
+			continue
+		}
+		if status != "" && t.Status != status {
+			continue
+		}
+		tasks = append(tasks, t)
+	}
+	writeList(w, tasks)
+}
+
+func (s *Server) createTasks(w http.ResponseWriter, form url.Values, rm *room) {
+	body := form.Get("body")
+	toIDs := splitIDs(form.Get("to_ids"))
+	if body == "" || len(toIDs) == 0 {
+		writeError(w, http.StatusBadRequest, "Parameters [body] and [to_ids] are required")
+		return
+	}
+
+	limitType := chatwork.LimitType(form.Get("limit_type"))
+	if limitType == "" {
+		limitType = chatwork.LimitTypeTime
+		if form.Get("limit") == "" {
+			limitType = chatwork.LimitTypeNone
+		}
+	}
+	if limitType.Validate() != nil {
+		writeError(w, http.StatusBadRequest, "Invalid value: [limit_type]")
+		return
+	}
+
+	result := chatwork.TaskCreatedResponse{TaskIDs: []chatwork.TaskID{}}
+	for _, id := range toIDs {
+		task := chatwork.Task{
+			TaskID:            chatwork.TaskID(s.newID()),
+			Account:           s.user(rm, id),
+			AssignedByAccount: s.meUser(),
+			Body:              body,
+			LimitTime:         chatwork.Timestamp(atoi(form.Get("limit"))),
+			Status:            chatwork.TaskStatusOpen,
+			LimitType:         limitType,
+		}
+		rm.tasks = append(rm.tasks, task)
+		rm.room.TaskNum++
+		result.TaskIDs = append(result.TaskIDs, task.TaskID)
+	}
+	writeJSON(w, result)
+}
+
+func (s *Server) serveFiles(w http.ResponseWriter, r *http.Request, form url.Values, rm *room, rest []string) {
+	if r.Method == "POST" && len(rest) == 0 {
+		s.uploadFile(w, r, rm)
+		return
+	}
+	if r.Method != "GET" || len(rest) > 1 {
+		writeMethodNotAllowed(w)
+		return
+	}
+
+	if len(rest) == 0 {
+		accountID := chatwork.AccountID(atoi(form.Get("account_id")))
+		files := []chatwork.File{}
+		for _, f := range rm.files {
+			if accountID == 0 || f.meta.Account.AccountID == accountID {
+				files = append(files, f.meta)
+			}
+		}
+		writeList(w, files)
+		return
+	}
+
+	for _, f := range rm.files {
+		if strconv.Itoa(f.meta.FileID) != rest[0] {
+			continue
+		}
+		meta := f.meta
+		if form.Get("create_download_url") == "1" {
+			meta.DownloadURL = s.URL + "/download/" + rm.room.RoomID.String() + "/" + rest[0]
+		}
+		writeJSON(w, meta)
+		return
+	}
+	writeError(w, http.StatusNotFound, "File not found")
+}
+
+// uploadFile stores a file uploaded as multipart form data, along with the
+// message announcing it.
+func (s *Server) uploadFile(w http.ResponseWriter, r *http.Request, rm *room) {
+	part, header, err := r.FormFile("file")
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "Parameter [file] is required")
+		return
+	}
+	defer part.Close()
+	content, err := io.ReadAll(part)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	f := chatwork.File{
+		FileID:     s.newID(),
+		Account:    s.meUser(),
+		Filename:   header.Filename,
+		Filesize:   len(content),
+		UploadTime: chatwork.NewTimestamp(time.Now()),
+	}
+	body := fmt.Sprintf("[info][title][dtext:file_uploaded][/title][download:%d]%s[/download][/info]", f.FileID, f.Filename)
+	if message := r.FormValue("message"); message != "" {
+		body = message + "\n" + body
+	}
+	f.MessageID = s.addMessage(rm, chatwork.Message{Account: s.meUser(), Body: body})
+	rm.files = append(rm.files, file{meta: f, content: content})
+	rm.room.FileNum++
+
+	writeJSON(w, map[string]int{"file_id": f.FileID})
+}
+
+// serveDownload serves file contents from download URLs, which are not
+// authenticated with the API token, and supports range requests.
+func (s *Server) serveDownload(w http.ResponseWriter, r *http.Request) {
+	segments := strings.Split(strings.TrimPrefix(r.URL.Path, "/download/"), "/")
+	if len(segments) == 2 {
+		if rm, ok := s.rooms[chatwork.RoomID(atoi(segments[0]))]; ok {
+			for _, f := range rm.files {
+				if strconv.Itoa(f.meta.FileID) == segments[1] {
+					http.ServeContent(w, r, f.meta.Filename, f.meta.UploadTime.Time(), bytes.NewReader(f.content))
+					return
+				}
+			}
+		}
+	}
+	http.Error(w, "Forbidden", http.StatusForbidden)
+}
+
+// readForm returns the query and form body parameters of r. Unlike
+// http.Request.ParseForm, it also reads the body of DELETE requests.
+func readForm(r *http.Request) (url.Values, error) {
+	form := r.URL.Query()
+	if r.Body == nil || !strings.HasPrefix(r.Header.Get("Content-Type"), "application/x-www-form-urlencoded") {
+		return form, nil
+	}
+
+	data, err := io.ReadAll(r.Body)
+	if err != nil {
+		return nil, err
+	}
+	body, err := url.ParseQuery(string(data))
+	if err != nil {
+		return nil, err
+	}
+	for k, v := range body {
+		form[k] = append(form[k], v...)
+	}
+	return form, nil
+}
+
+func writeJSON(w http.ResponseWriter, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+// writeList writes a JSON array, or 204 No Content for an empty list as the
+// API does for list endpoints without results.
+func writeList[T any](w http.ResponseWriter, items []T) {
+	if len(items) == 0 {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+	writeJSON(w, items)
+}
+
+func writeError(w http.ResponseWriter, status int, messages ...string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(map[string][]string{"errors": messages})
+}
+
+func writeMethodNotAllowed(w http.ResponseWriter) {
+	writeError(w, http.StatusMethodNotAllowed, "Method not allowed")
+}
+
+func splitIDs(s string) []chatwork.AccountID {
+	var ids []chatwork.AccountID
+	for _, part := range strings.Split(s, ",") {
+		if id := atoi(strings.TrimSpace(part)); id > 0 {
+			ids = append(ids, chatwork.AccountID(id))
+		}
+	}
+	return ids
+}
+
+func hasMember(members []chatwork.Member, accountID chatwork.AccountID) bool {
+	for _, m := range members {
+		if m.AccountID == accountID {
+			return true
+		}
+	}
+	return false
+}
+
+func iconPath(preset string) string {
+	if preset == "" {
+		preset = "group"
+	}
+	return "https://appdata.chatwork.com/icon/ico_" + preset + ".png"
+}
+
+func atoi(s string) int {
+	n, _ := strconv.Atoi(s)
+	return n
+}
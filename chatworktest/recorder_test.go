@@ -0,0 +1,78 @@
+package chatworktest
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/nashirox/chatwork-go"
+)
+
+func TestRecorder(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "cassette.json")
+	ctx := context.Background()
+
+	srv := NewServer()
+	roomID := srv.AddRoom(chatwork.Room{Name: "General"})
+
+	rec, err := NewRecorder(path, RecorderRecord)
+	if err != nil {
+		t.Fatalf("NewRecorder returned error: %v", err)
+	}
+	rec.Sanitize = func(i *Interaction) {
+		i.Response.Body = strings.ReplaceAll(i.Response.Body, "Test User", "REDACTED")
+	}
+
+	client := srv.Client(chatwork.OptionHTTPClient(rec.HTTPClient()))
+	if _, _, err := client.Messages.SendMessage(ctx, roomID, "hello"); err != nil {
+		t.Fatalf("SendMessage returned error: %v", err)
+	}
+	if _, _, err := client.Me.Get(ctx); err != nil {
+		t.Fatalf("Get returned error: %v", err)
+	}
+	if err := rec.Stop(); err != nil {
+		t.Fatalf("Stop returned error: %v", err)
+	}
+	srv.Close()
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(data), Token) || strings.Contains(string(data), "Test User") {
+		t.Errorf("Cassette contains sensitive data:\n%s", data)
+	}
+
+	// Replay without the server.
+	rec, err = NewRecorder(path, RecorderReplay)
+	if err != nil {
+		t.Fatalf("NewRecorder returned error: %v", err)
+	}
+	replayClient := chatwork.New(Token, chatwork.OptionHTTPClient(rec.HTTPClient()))
+	replayClient.BaseURL = client.BaseURL
+
+	created, _, err := replayClient.Messages.SendMessage(ctx, roomID, "hello")
+	if err != nil {
+		t.Fatalf("SendMessage returned error: %v", err)
+	}
+	if created.MessageID == "" {
+		t.Error("Expected the recorded message ID")
+	}
+	me, _, err := replayClient.Me.Get(ctx)
+	if err != nil {
+		t.Fatalf("Get returned error: %v", err)
+	}
+	if me.Name != "REDACTED" {
+		t.Errorf("Expected the sanitized name, got %q", me.Name)
+	}
+
+	// Every interaction is replayed once, and unrecorded requests fail.
+	if _, _, err := replayClient.Me.Get(ctx); err == nil {
+		t.Error("Expected an error for a request that was not recorded")
+	}
+	if _, _, err := replayClient.Messages.SendMessage(ctx, roomID, "other"); err == nil {
+		t.Error("Expected an error for a different request body")
+	}
+}
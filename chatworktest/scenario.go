@@ -0,0 +1,81 @@
+package chatworktest
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/nashirox/chatwork-go"
+)
+
+// Scenario is a timeline of server-side changes, such as messages posted
+// and members joining, that a Server plays out as a Clock advances. With
+// clients that use the clock, tests of watchers and monitors become
+// deterministic:
+//
+//	clock := chatworktest.NewClock(time.Now())
+//	client := srv.Client(chatwork.OptionClock(clock))
+//
+//	srv.Scenario(clock).
+//		AddMessage(5*time.Second, roomID, chatwork.Message{Body: "hello"}).
+//		AddMember(10*time.Second, roomID, chatwork.Member{AccountID: 2})
+//
+//	messages := client.Watch(ctx, roomID, &chatwork.WatchOptions{Interval: 10 * time.Second})
+//	clock.BlockUntil(1) // the first poll is done
+//	clock.Advance(10 * time.Second)
+//	m := <-messages // "hello"
+type Scenario struct {
+	srv   *Server
+	clock *Clock
+	start time.Time
+}
+
+// Scenario returns an empty Scenario of changes to s, at offsets from the
+// current time of clock.
+func (s *Server) Scenario(clock *Clock) *Scenario {
+	return &Scenario{srv: s, clock: clock, start: clock.Now()}
+}
+
+// At schedules change to be applied to the server at offset, and returns sc.
+func (sc *Scenario) At(offset time.Duration, change func(*Server)) *Scenario {
+	sc.clock.schedule(sc.start.Add(offset), func() { change(sc.srv) })
+	return sc
+}
+
+// AddMessage schedules m to be posted to a room at offset, as with
+// Server.AddMessage, and returns sc. The send time defaults to the time of
+// the clock. A message from another account increases the room's unread
+// count, and its mention count if it mentions the authenticated account.
+func (sc *Scenario) AddMessage(offset time.Duration, roomID chatwork.RoomID, m chatwork.Message) *Scenario {
+	return sc.At(offset, func(s *Server) {
+		if m.SendTime == 0 {
+			m.SendTime = chatwork.NewTimestamp(sc.clock.Now())
+		}
+		s.AddMessage(roomID, m)
+		s.markUnread(roomID, m)
+	})
+}
+
+// AddMember schedules m to join a room at offset, as with Server.AddMember,
+// and returns sc.
+func (sc *Scenario) AddMember(offset time.Duration, roomID chatwork.RoomID, m chatwork.Member) *Scenario {
+	return sc.At(offset, func(s *Server) {
+		s.AddMember(roomID, m)
+	})
+}
+
+// markUnread counts m as unread in a room unless it was posted by the
+// authenticated account.
+func (s *Server) markUnread(roomID chatwork.RoomID, m chatwork.Message) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if m.Account.AccountID == 0 || m.Account.AccountID == s.me.AccountID {
+		return
+	}
+	rm := s.mustRoom(roomID)
+	rm.room.UnreadNum++
+	if strings.Contains(m.Body, fmt.Sprintf("[To:%d]", s.me.AccountID)) {
+		rm.room.MentionNum++
+	}
+}
@@ -0,0 +1,101 @@
+package chatworktest
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/nashirox/chatwork-go"
+)
+
+func TestScenario_Watch(t *testing.T) {
+	srv := NewServer()
+	defer srv.Close()
+
+	roomID := srv.AddRoom(chatwork.Room{Name: "General"})
+	clock := NewClock(time.Date(2024, 1, 1, 9, 0, 0, 0, time.UTC))
+	client := srv.Client(chatwork.OptionClock(clock))
+
+	bob := chatwork.User{AccountID: 2, Name: "Bob"}
+	srv.Scenario(clock).
+		AddMessage(5*time.Second, roomID, chatwork.Message{Account: bob, Body: "hello"}).
+		AddMember(10*time.Second, roomID, chatwork.Member{AccountID: 2}).
+		AddMessage(25*time.Second, roomID, chatwork.Message{Account: bob, Body: "still there?"})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	messages := client.Watch(ctx, roomID, &chatwork.WatchOptions{Interval: 10 * time.Second})
+
+	clock.BlockUntil(1)
+	clock.Advance(10 * time.Second)
+	m := <-messages
+	if m.Body != "hello" || !m.SendTime.Time().Equal(clock.Now().Add(-5*time.Second)) {
+		t.Errorf("Expected the message posted at 5s, got %q sent at %v", m.Body, m.SendTime.Time())
+	}
+	if members := srv.Members(roomID); len(members) != 2 {
+		t.Errorf("Expected the member who joined at 10s, got %+v", members)
+	}
+
+	// The next poll, at 20s, sees nothing new; the one at 30s the second message.
+	clock.BlockUntil(1)
+	clock.Advance(10 * time.Second)
+	select {
+	case m := <-messages:
+		t.Fatalf("Expected no message before 25s, got %q", m.Body)
+	case <-time.After(50 * time.Millisecond):
+	}
+	clock.BlockUntil(1)
+	clock.Advance(10 * time.Second)
+	if m := <-messages; m.Body != "still there?" {
+		t.Errorf("Expected the message posted at 25s, got %q", m.Body)
+	}
+}
+
+func TestScenario_UnreadMonitor(t *testing.T) {
+	srv := NewServer()
+	defer srv.Close()
+
+	roomID := srv.AddRoom(chatwork.Room{Name: "General"})
+	clock := NewClock(time.Date(2024, 1, 1, 9, 0, 0, 0, time.UTC))
+	client := srv.Client(chatwork.OptionClock(clock))
+
+	srv.Scenario(clock).
+		AddMessage(20*time.Second, roomID, chatwork.Message{Account: chatwork.User{AccountID: 2}, Body: "[To:1] Review please"}).
+		AddMessage(25*time.Second, roomID, chatwork.Message{Body: "My own message"})
+
+	events := make(chan chatwork.UnreadEvent, 1)
+	monitor := chatwork.NewUnreadMonitor(client, func(e chatwork.UnreadEvent) { events <- e })
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go monitor.Run(ctx)
+
+	clock.BlockUntil(1)
+	clock.Advance(30 * time.Second)
+	e := <-events
+	if e.Room.RoomID != roomID || e.UnreadIncrease != 1 || e.MentionIncrease != 1 {
+		t.Errorf("Expected one unread mention, got %+v", e)
+	}
+}
+
+func TestClock_CanceledWaiters(t *testing.T) {
+	srv := NewServer()
+	defer srv.Close()
+
+	roomID := srv.AddRoom(chatwork.Room{Name: "General"})
+	clock := NewClock(time.Date(2024, 1, 1, 9, 0, 0, 0, time.UTC))
+	client := srv.Client(chatwork.OptionClock(clock))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	messages := client.Watch(ctx, roomID, &chatwork.WatchOptions{Interval: 10 * time.Second})
+	clock.BlockUntil(1)
+	cancel()
+	for range messages {
+	}
+
+	clock.mu.Lock()
+	waiters := len(clock.waiters)
+	clock.mu.Unlock()
+	if waiters != 0 {
+		t.Errorf("Expected the canceled watcher to stop waiting, got %d waiters", waiters)
+	}
+}
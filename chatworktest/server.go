@@ -0,0 +1,409 @@
+// Package chatworktest provides an in-memory ChatWork API server for testing
+// code that uses the chatwork package.
+//
+// A Server implements the v2 endpoints covered by the chatwork client. Tests
+// register rooms, messages, tasks, and other data, exercise their code
+// against the client returned by Server.Client, and then inspect the
+// resulting state:
+//
+//	srv := chatworktest.NewServer()
+//	defer srv.Close()
+//
+//	roomID := srv.AddRoom(chatwork.Room{Name: "General"})
+//	client := srv.Client()
+//
+//	// ... code under test posts to roomID using client ...
+//
+//	messages := srv.Messages(roomID)
+package chatworktest
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/nashirox/chatwork-go"
+)
+
+// Token is the API token accepted by every Server.
+const Token = "chatworktest-token"
+
+// Server is an in-memory ChatWork API server. It is safe for concurrent use.
+type Server struct {
+	// Base URL of the server, without the "/v2" API prefix
+	URL string
+
+	srv *httptest.Server
+
+	mu       sync.Mutex
+	me       chatwork.Me
+	rooms    map[chatwork.RoomID]*room
+	contacts []chatwork.Contact
+	requests []chatwork.IncomingRequest
+	failures map[string]failure
+	nextID   int
+}
+
+// room is the state kept for a single chat room.
+type room struct {
+	room     chatwork.Room
+	members  []chatwork.Member
+	messages []chatwork.Message
+	tasks    []chatwork.Task
+	files    []file
+	fetched  int // number of messages already returned by unforced listings
+}
+
+type file struct {
+	meta    chatwork.File
+	content []byte
+}
+
+type failure struct {
+	status   int
+	messages []string
+}
+
+// NewServer starts a Server. The authenticated account is "Test User" with
+// account ID 1 until changed with SetMe. Callers should Close the server when
+// done.
+func NewServer() *Server {
+	s := &Server{
+		me: chatwork.Me{
+			AccountID:  1,
+			Name:       "Test User",
+			ChatworkID: "testuser",
+		},
+		rooms:    make(map[chatwork.RoomID]*room),
+		failures: make(map[string]failure),
+		nextID:   1000,
+	}
+	s.srv = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+	s.URL = s.srv.URL
+	return s
+}
+
+// Close shuts down the server.
+func (s *Server) Close() {
+	s.srv.Close()
+}
+
+// Client returns a chatwork.Client authenticated with Token and pointed at
+// the server. Options are applied as in chatwork.New.
+func (s *Server) Client(options ...chatwork.ClientOption) *chatwork.Client {
+	options = append([]chatwork.ClientOption{chatwork.OptionHTTPClient(s.srv.Client())}, options...)
+	client := chatwork.New(Token, options...)
+	client.BaseURL = &url.URL{Scheme: "http", Host: s.srv.Listener.Addr().String(), Path: "/v2"}
+	return client
+}
+
+// SetMe sets the account that the server authenticates requests as.
+// Messages and tasks posted through the API are attributed to it.
+func (s *Server) SetMe(me chatwork.Me) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.me = me
+}
+
+// AddRoom registers a room and returns its ID. A zero RoomID is replaced
+// with a generated one, and Type and Role default to "group" and "admin".
+// The authenticated account is added as a member with the room's role.
+func (s *Server) AddRoom(r chatwork.Room) chatwork.RoomID {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if r.RoomID == 0 {
+		r.RoomID = chatwork.RoomID(s.newID())
+	}
+	if r.Type == "" {
+		r.Type = chatwork.RoomTypeGroup
+	}
+	if r.Role == "" {
+		r.Role = chatwork.RoleAdmin
+	}
+	if r.LastUpdateTime == 0 {
+		r.LastUpdateTime = chatwork.NewTimestamp(time.Now())
+	}
+
+	s.rooms[r.RoomID] = &room{
+		room:    r,
+		members: []chatwork.Member{s.member(s.me.AccountID, r.Role)},
+	}
+	return r.RoomID
+}
+
+// AddMember registers a member of a room. Registering an account that is
+// already a member replaces it.
+func (s *Server) AddMember(roomID chatwork.RoomID, m chatwork.Member) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	rm := s.mustRoom(roomID)
+	if m.Role == "" {
+		m.Role = chatwork.RoleMember
+	}
+	for i := range rm.members {
+		if rm.members[i].AccountID == m.AccountID {
+			rm.members[i] = m
+			return
+		}
+	}
+	rm.members = append(rm.members, m)
+}
+
+// AddMessage registers a message in a room and returns its ID. A message
+// without an account is attributed to the authenticated account, and the
+// ID and send time are generated when empty.
+func (s *Server) AddMessage(roomID chatwork.RoomID, m chatwork.Message) chatwork.MessageID {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	rm := s.mustRoom(roomID)
+	if m.Account.AccountID == 0 {
+		m.Account = s.meUser()
+	}
+	return s.addMessage(rm, m)
+}
+
+// AddTask registers a task in a room and returns its ID. The task is
+// assigned by the authenticated account unless AssignedByAccount is set,
+// and its status defaults to "open".
+func (s *Server) AddTask(roomID chatwork.RoomID, t chatwork.Task) chatwork.TaskID {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	rm := s.mustRoom(roomID)
+	if t.TaskID == 0 {
+		t.TaskID = chatwork.TaskID(s.newID())
+	}
+	if t.AssignedByAccount.AccountID == 0 {
+		t.AssignedByAccount = s.meUser()
+	}
+	if t.Status == "" {
+		t.Status = chatwork.TaskStatusOpen
+	}
+	if t.LimitType == "" {
+		t.LimitType = chatwork.LimitTypeNone
+	}
+	rm.tasks = append(rm.tasks, t)
+	rm.room.TaskNum++
+	return t.TaskID
+}
+
+// AddFile registers a file in a room and returns its ID. The content is
+// served from the file's download URL, and Filesize defaults to its length.
+func (s *Server) AddFile(roomID chatwork.RoomID, f chatwork.File, content []byte) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	rm := s.mustRoom(roomID)
+	if f.FileID == 0 {
+		f.FileID = s.newID()
+	}
+	if f.Account.AccountID == 0 {
+		f.Account = s.meUser()
+	}
+	if f.Filesize == 0 {
+		f.Filesize = len(content)
+	}
+	if f.UploadTime == 0 {
+		f.UploadTime = chatwork.NewTimestamp(time.Now())
+	}
+	f.DownloadURL = ""
+	rm.files = append(rm.files, file{meta: f, content: content})
+	rm.room.FileNum++
+	return f.FileID
+}
+
+// AddContact registers a contact of the authenticated account.
+func (s *Server) AddContact(c chatwork.Contact) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.contacts = append(s.contacts, c)
+}
+
+// AddIncomingRequest registers a pending contact request and returns its ID.
+func (s *Server) AddIncomingRequest(r chatwork.IncomingRequest) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if r.RequestID == 0 {
+		r.RequestID = s.newID()
+	}
+	s.requests = append(s.requests, r)
+	return r.RequestID
+}
+
+// Fail makes the server answer requests with the given method and path with
+// an error response until Fail is called again for them with status 0.
+// The path is relative to the API root, such as "rooms/123/messages".
+func (s *Server) Fail(method, path string, status int, messages ...string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	key := method + " " + strings.Trim(path, "/")
+	if status == 0 {
+		delete(s.failures, key)
+		return
+	}
+	if len(messages) == 0 {
+		messages = []string{http.StatusText(status)}
+	}
+	s.failures[key] = failure{status: status, messages: messages}
+}
+
+// Room returns the current state of a room.
+func (s *Server) Room(roomID chatwork.RoomID) (chatwork.Room, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	rm, ok := s.rooms[roomID]
+	if !ok {
+		return chatwork.Room{}, false
+	}
+	return rm.room, true
+}
+
+// Rooms returns the current state of all rooms, ordered by ID.
+func (s *Server) Rooms() []chatwork.Room {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	rooms := make([]chatwork.Room, 0, len(s.rooms))
+	for _, rm := range s.sortedRooms() {
+		rooms = append(rooms, rm.room)
+	}
+	return rooms
+}
+
+// Members returns the members of a room.
+func (s *Server) Members(roomID chatwork.RoomID) []chatwork.Member {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if rm, ok := s.rooms[roomID]; ok {
+		return append([]chatwork.Member(nil), rm.members...)
+	}
+	return nil
+}
+
+// Messages returns the messages in a room, oldest first.
+func (s *Server) Messages(roomID chatwork.RoomID) []chatwork.Message {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if rm, ok := s.rooms[roomID]; ok {
+		return append([]chatwork.Message(nil), rm.messages...)
+	}
+	return nil
+}
+
+// Tasks returns the tasks in a room.
+func (s *Server) Tasks(roomID chatwork.RoomID) []chatwork.Task {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if rm, ok := s.rooms[roomID]; ok {
+		return append([]chatwork.Task(nil), rm.tasks...)
+	}
+	return nil
+}
+
+// Contacts returns the contacts of the authenticated account.
+func (s *Server) Contacts() []chatwork.Contact {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]chatwork.Contact(nil), s.contacts...)
+}
+
+// mustRoom returns the room with the given ID and panics if it does not
+// exist, as registering data for an unknown room is a mistake in the test.
+func (s *Server) mustRoom(roomID chatwork.RoomID) *room {
+	rm, ok := s.rooms[roomID]
+	if !ok {
+		panic("chatworktest: unknown room " + roomID.String())
+	}
+	return rm
+}
+
+func (s *Server) sortedRooms() []*room {
+	rooms := make([]*room, 0, len(s.rooms))
+	for _, rm := range s.rooms {
+		rooms = append(rooms, rm)
+	}
+	sort.Slice(rooms, func(i, j int) bool {
+		return rooms[i].room.RoomID < rooms[j].room.RoomID
+	})
+	return rooms
+}
+
+func (s *Server) addMessage(rm *room, m chatwork.Message) chatwork.MessageID {
+	if m.MessageID == "" {
+		m.MessageID = chatwork.MessageID(strconv.Itoa(s.newID()))
+	}
+	if m.SendTime == 0 {
+		m.SendTime = chatwork.NewTimestamp(time.Now())
+	}
+	rm.messages = append(rm.messages, m)
+	rm.room.MessageNum++
+	rm.room.LastUpdateTime = m.SendTime
+	return m.MessageID
+}
+
+func (s *Server) newID() int {
+	s.nextID++
+	return s.nextID
+}
+
+func (s *Server) meUser() chatwork.User {
+	return chatwork.User{
+		AccountID:      s.me.AccountID,
+		Name:           s.me.Name,
+		AvatarImageURL: s.me.AvatarImageURL,
+	}
+}
+
+// member builds the member entry for an account, using what is known about
+// it from the authenticated account and its contacts.
+func (s *Server) member(accountID chatwork.AccountID, role chatwork.Role) chatwork.Member {
+	m := chatwork.Member{AccountID: accountID, Role: role}
+	switch {
+	case accountID == s.me.AccountID:
+		m.Name = s.me.Name
+		m.ChatworkID = s.me.ChatworkID
+		m.OrganizationID = s.me.OrganizationID
+		m.OrganizationName = s.me.OrganizationName
+		m.Department = s.me.Department
+		m.AvatarImageURL = s.me.AvatarImageURL
+	default:
+		for _, c := range s.contacts {
+			if c.AccountID == accountID {
+				m.Name = c.Name
+				m.ChatworkID = c.ChatworkID
+				m.OrganizationID = c.OrganizationID
+				m.OrganizationName = c.OrganizationName
+				m.Department = c.Department
+				m.AvatarImageURL = c.AvatarImageURL
+				return m
+			}
+		}
+		m.Name = "User " + accountID.String()
+	}
+	return m
+}
+
+// user returns the account information for accountID as seen in a room.
+func (s *Server) user(rm *room, accountID chatwork.AccountID) chatwork.User {
+	for _, m := range rm.members {
+		if m.AccountID == accountID {
+			return chatwork.User{AccountID: accountID, Name: m.Name, AvatarImageURL: m.AvatarImageURL}
+		}
+	}
+	m := s.member(accountID, "")
+	return chatwork.User{AccountID: accountID, Name: m.Name, AvatarImageURL: m.AvatarImageURL}
+}
@@ -0,0 +1,177 @@
+package chatworktest
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/nashirox/chatwork-go"
+)
+
+func TestServer_Messages(t *testing.T) {
+	srv := NewServer()
+	defer srv.Close()
+
+	roomID := srv.AddRoom(chatwork.Room{Name: "General"})
+	srv.AddMessage(roomID, chatwork.Message{
+		Account: chatwork.User{AccountID: 2, Name: "Alice"},
+		Body:    "hello",
+	})
+
+	client := srv.Client()
+	ctx := context.Background()
+
+	created, _, err := client.Messages.SendMessage(ctx, roomID, "hi there")
+	if err != nil {
+		t.Fatalf("SendMessage returned error: %v", err)
+	}
+
+	messages, _, err := client.Messages.List(ctx, roomID, &chatwork.MessageListParams{Force: 1})
+	if err != nil {
+		t.Fatalf("List returned error: %v", err)
+	}
+	if len(messages) != 2 || messages[1].MessageID != created.MessageID || messages[1].Account.AccountID != 1 {
+		t.Fatalf("unexpected messages: %+v", messages)
+	}
+
+	// Nothing new since the last listing.
+	messages, resp, err := client.Messages.List(ctx, roomID, nil)
+	if err != nil {
+		t.Fatalf("List returned error: %v", err)
+	}
+	if resp.StatusCode != http.StatusNoContent || len(messages) != 0 {
+		t.Errorf("expected 204 without messages, got %d with %d", resp.StatusCode, len(messages))
+	}
+
+	// Other accounts' messages cannot be edited.
+	other := srv.Messages(roomID)[0]
+	_, _, err = client.Messages.Update(ctx, roomID, other.MessageID, &chatwork.MessageUpdateParams{Body: "x"})
+	if !errors.Is(err, chatwork.ErrForbidden) {
+		t.Errorf("expected ErrForbidden, got %v", err)
+	}
+
+	if _, _, err := client.Messages.Delete(ctx, roomID, created.MessageID); err != nil {
+		t.Fatalf("Delete returned error: %v", err)
+	}
+	if got := len(srv.Messages(roomID)); got != 1 {
+		t.Errorf("expected 1 message after delete, got %d", got)
+	}
+}
+
+func TestServer_RoomsAndMembers(t *testing.T) {
+	srv := NewServer()
+	defer srv.Close()
+
+	srv.AddContact(chatwork.Contact{AccountID: 2, Name: "Alice"})
+	client := srv.Client()
+	ctx := context.Background()
+
+	room, _, err := client.Rooms.Create(ctx, &chatwork.RoomCreateParams{
+		Name:             "Project",
+		MembersAdminIDs:  []chatwork.AccountID{1},
+		MembersMemberIDs: []chatwork.AccountID{2},
+	})
+	if err != nil {
+		t.Fatalf("Create returned error: %v", err)
+	}
+
+	members, _, err := client.Rooms.GetMembers(ctx, room.RoomID)
+	if err != nil {
+		t.Fatalf("GetMembers returned error: %v", err)
+	}
+	if len(members) != 2 || members[1].Name != "Alice" || members[1].Role != "member" {
+		t.Errorf("unexpected members: %+v", members)
+	}
+
+	if _, err := client.Rooms.Leave(ctx, room.RoomID); err != nil {
+		t.Fatalf("Leave returned error: %v", err)
+	}
+	if _, _, err := client.Rooms.Get(ctx, room.RoomID); !errors.Is(err, chatwork.ErrNotFound) {
+		t.Errorf("expected ErrNotFound after leaving, got %v", err)
+	}
+}
+
+func TestServer_Tasks(t *testing.T) {
+	srv := NewServer()
+	defer srv.Close()
+
+	roomID := srv.AddRoom(chatwork.Room{Name: "General"})
+	client := srv.Client()
+	ctx := context.Background()
+
+	created, _, err := client.Tasks.CreateSimple(ctx, roomID, "review", []chatwork.AccountID{1, 2})
+	if err != nil {
+		t.Fatalf("CreateSimple returned error: %v", err)
+	}
+	if len(created.TaskIDs) != 2 {
+		t.Fatalf("expected 2 task IDs, got %v", created.TaskIDs)
+	}
+
+	if _, _, err := client.Tasks.Complete(ctx, roomID, created.TaskIDs[0]); err != nil {
+		t.Fatalf("Complete returned error: %v", err)
+	}
+
+	open, _, err := client.MyTasks.GetOpen(ctx)
+	if err != nil {
+		t.Fatalf("GetOpen returned error: %v", err)
+	}
+	if len(open) != 0 {
+		t.Errorf("expected no open tasks for me, got %+v", open)
+	}
+
+	tasks, _, err := client.Rooms.GetTasks(ctx, roomID, &chatwork.TaskListParams{AccountID: 2})
+	if err != nil {
+		t.Fatalf("GetTasks returned error: %v", err)
+	}
+	if len(tasks) != 1 || tasks[0].Status != "open" {
+		t.Errorf("unexpected tasks: %+v", tasks)
+	}
+}
+
+func TestServer_Files(t *testing.T) {
+	srv := NewServer()
+	defer srv.Close()
+
+	roomID := srv.AddRoom(chatwork.Room{Name: "General"})
+	content := []byte("file contents")
+	fileID := srv.AddFile(roomID, chatwork.File{Filename: "a.txt"}, content)
+
+	client := srv.Client()
+	var buf bytes.Buffer
+	file, err := chatwork.NewDownloadManager(client).Download(context.Background(), roomID, fileID, &buf)
+	if err != nil {
+		t.Fatalf("Download returned error: %v", err)
+	}
+	if file.Filename != "a.txt" || !bytes.Equal(buf.Bytes(), content) {
+		t.Errorf("unexpected download %q of %+v", buf.String(), file)
+	}
+}
+
+func TestServer_AuthAndFailures(t *testing.T) {
+	srv := NewServer()
+	defer srv.Close()
+
+	ctx := context.Background()
+
+	client := chatwork.New("wrong-token", chatwork.OptionHTTPClient(srv.srv.Client()))
+	client.BaseURL = srv.Client().BaseURL
+	if _, _, err := client.Me.Get(ctx); !errors.Is(err, chatwork.ErrUnauthorized) {
+		t.Errorf("expected ErrUnauthorized, got %v", err)
+	}
+
+	srv.Fail("GET", "me", http.StatusInternalServerError)
+	if _, _, err := srv.Client().Me.Get(ctx); !errors.Is(err, chatwork.ErrServerError) {
+		t.Errorf("expected ErrServerError, got %v", err)
+	}
+
+	srv.Fail("GET", "me", 0)
+	me, _, err := srv.Client().Me.Get(ctx)
+	if err != nil {
+		t.Fatalf("Get returned error: %v", err)
+	}
+	if me.AccountID != 1 {
+		t.Errorf("expected account 1, got %d", me.AccountID)
+	}
+}
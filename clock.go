@@ -0,0 +1,53 @@
+package chatwork
+
+import (
+	"context"
+	"time"
+)
+
+// Clock tells the time and waits between polls for the polling helpers of a
+// client: Client.Watch and UnreadMonitor. Tests can replace it with a fake
+// clock, such as chatworktest.Clock, to play out polling without waiting.
+type Clock interface {
+	Now() time.Time
+
+	// NewTimer sends the time on the returned channel once d has elapsed.
+	// Calling stop releases the timer if it has not fired.
+	NewTimer(d time.Duration) (c <-chan time.Time, stop func())
+}
+
+// OptionClock sets the Clock used by the polling helpers of the client.
+// Requests, retries and rate limiting keep using the time package.
+func OptionClock(clock Clock) ClientOption {
+	return func(c *Client) {
+		c.clock = clock
+	}
+}
+
+// now returns the current time of the client's clock.
+func (c *Client) now() time.Time {
+	if c.clock == nil {
+		return time.Now()
+	}
+	return c.clock.Now()
+}
+
+// sleep pauses for d of the client's clock or until ctx is done.
+func (c *Client) sleep(ctx context.Context, d time.Duration) error {
+	if c.clock == nil {
+		return sleepContext(ctx, d)
+	}
+	if d <= 0 {
+		return ctx.Err()
+	}
+
+	timer, stop := c.clock.NewTimer(d)
+	defer stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer:
+		return nil
+	}
+}
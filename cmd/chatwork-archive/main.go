@@ -0,0 +1,33 @@
+// Command chatwork-archive inspects archives written by the chatwork package.
+//
+// Usage:
+//
+//	chatwork-archive verify <manifest>
+//
+// The verify subcommand checks that the files listed in an archive manifest
+// were neither truncated nor modified. It exits with status 1 if they were.
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/nashirox/chatwork-go"
+)
+
+const usage = "usage: chatwork-archive verify <manifest>"
+
+func main() {
+	if len(os.Args) != 3 || os.Args[1] != "verify" {
+		fmt.Fprintln(os.Stderr, usage)
+		os.Exit(2)
+	}
+
+	manifest, err := chatwork.VerifyArchive(os.Args[2])
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+
+	fmt.Printf("OK: %d files, %d records\n", len(manifest.Files), manifest.Records())
+}
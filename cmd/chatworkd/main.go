@@ -0,0 +1,83 @@
+// Command chatworkd is a gateway that lets services in an organization send
+// ChatWork messages over a small HTTP API, through a single rate-limited
+// ChatWork account, without handling its API token themselves.
+//
+// Usage:
+//
+//	CHATWORK_API_TOKEN=... CHATWORKD_TOKENS=token1,token2 chatworkd [-addr :8080] [-rooms 1,2,3]
+//
+// Clients authenticate with "Authorization: Bearer <token>", using one of the
+// tokens in CHATWORKD_TOKENS. The -rooms flag restricts the rooms that are listed
+// and can be posted to. The API is:
+//
+//	GET  /healthz                      200 when the gateway is up
+//	GET  /v1/rooms                     the rooms of the ChatWork account, within -rooms
+//	POST /v1/rooms/{room_id}/messages  send {"body": "...", "to": [account IDs], "to_all": false}
+//
+// Errors are returned as {"error": "..."}, with the status of the ChatWork
+// API's response where there is one.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/nashirox/chatwork-go"
+)
+
+func main() {
+	addr := flag.String("addr", ":8080", "address to listen on")
+	rooms := flag.String("rooms", "", "comma-separated IDs of the rooms that can be posted to (default all)")
+	flag.Parse()
+
+	token := os.Getenv("CHATWORK_API_TOKEN")
+	tokens := splitList(os.Getenv("CHATWORKD_TOKENS"))
+	if token == "" || len(tokens) == 0 {
+		fmt.Fprintln(os.Stderr, "chatworkd: CHATWORK_API_TOKEN and CHATWORKD_TOKENS must be set")
+		os.Exit(2)
+	}
+
+	var roomIDs []chatwork.RoomID
+	for _, s := range splitList(*rooms) {
+		id, err := strconv.Atoi(s)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "chatworkd: invalid room ID %q\n", s)
+			os.Exit(2)
+		}
+		roomIDs = append(roomIDs, chatwork.RoomID(id))
+	}
+
+	client := chatwork.New(token,
+		chatwork.OptionRateLimit(chatwork.DefaultRateLimitRequests, chatwork.DefaultRateLimitWindow),
+		chatwork.OptionWaitOnRateLimit(true),
+	)
+
+	httpServer := &http.Server{
+		Addr:              *addr,
+		Handler:           newServer(client, tokens, roomIDs),
+		ReadHeaderTimeout: 10 * time.Second,
+		ReadTimeout:       30 * time.Second,
+		// Sends may wait out a ChatWork rate limit before they respond.
+		WriteTimeout: chatwork.DefaultRateLimitWindow + time.Minute,
+		IdleTimeout:  2 * time.Minute,
+	}
+	log.Printf("chatworkd listening on %s", *addr)
+	log.Fatal(httpServer.ListenAndServe())
+}
+
+// splitList splits a comma-separated list, dropping empty entries.
+func splitList(s string) []string {
+	var list []string
+	for _, item := range strings.Split(s, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			list = append(list, item)
+		}
+	}
+	return list
+}
@@ -0,0 +1,162 @@
+package main
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/nashirox/chatwork-go"
+)
+
+// maxRequestSize limits the size of request bodies.
+const maxRequestSize = 1 << 20
+
+// server serves the gateway API.
+type server struct {
+	client *chatwork.Client
+	tokens []string
+	rooms  map[chatwork.RoomID]bool // rooms that can be posted to, or nil for all
+}
+
+func newServer(client *chatwork.Client, tokens []string, roomIDs []chatwork.RoomID) *server {
+	s := &server{client: client, tokens: tokens}
+	if len(roomIDs) > 0 {
+		s.rooms = make(map[chatwork.RoomID]bool, len(roomIDs))
+		for _, id := range roomIDs {
+			s.rooms[id] = true
+		}
+	}
+	return s
+}
+
+// sendRequest is the body of POST /v1/rooms/{room_id}/messages.
+type sendRequest struct {
+	Body  string               `json:"body"`
+	To    []chatwork.AccountID `json:"to"`
+	ToAll bool                 `json:"to_all"`
+}
+
+func (s *server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Path == "/healthz" {
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+	if !s.authorized(r) {
+		writeError(w, http.StatusUnauthorized, "invalid or missing token")
+		return
+	}
+
+	segments := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	switch {
+	case len(segments) == 2 && segments[0] == "v1" && segments[1] == "rooms":
+		if r.Method != http.MethodGet {
+			writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+			return
+		}
+		s.listRooms(w, r)
+	case len(segments) == 4 && segments[0] == "v1" && segments[1] == "rooms" && segments[3] == "messages":
+		if r.Method != http.MethodPost {
+			writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+			return
+		}
+		roomID, err := strconv.Atoi(segments[2])
+		if err != nil {
+			writeError(w, http.StatusNotFound, "not found")
+			return
+		}
+		s.sendMessage(w, r, chatwork.RoomID(roomID))
+	default:
+		writeError(w, http.StatusNotFound, "not found")
+	}
+}
+
+// authorized reports whether r carries one of the gateway's tokens.
+func (s *server) authorized(r *http.Request) bool {
+	got, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if !ok || got == "" {
+		return false
+	}
+	for _, token := range s.tokens {
+		if subtle.ConstantTimeCompare([]byte(got), []byte(token)) == 1 {
+			return true
+		}
+	}
+	return false
+}
+
+// listRooms lists the rooms of the account that can be posted to.
+func (s *server) listRooms(w http.ResponseWriter, r *http.Request) {
+	rooms, _, err := s.client.Rooms.List(r.Context())
+	if err != nil {
+		writeAPIError(w, err)
+		return
+	}
+	if s.rooms != nil {
+		allowed := make([]*chatwork.Room, 0, len(s.rooms))
+		for _, room := range rooms {
+			if s.rooms[room.RoomID] {
+				allowed = append(allowed, room)
+			}
+		}
+		rooms = allowed
+	}
+	writeJSON(w, http.StatusOK, rooms)
+}
+
+func (s *server) sendMessage(w http.ResponseWriter, r *http.Request, roomID chatwork.RoomID) {
+	if s.rooms != nil && !s.rooms[roomID] {
+		writeError(w, http.StatusForbidden, "room not allowed")
+		return
+	}
+
+	var req sendRequest
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxRequestSize)).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+	if strings.TrimSpace(req.Body) == "" {
+		writeError(w, http.StatusBadRequest, "body is required")
+		return
+	}
+
+	body := chatwork.NewMessageBuilder().To(req.To...).Text(req.Body)
+	params := body.Params()
+	if req.ToAll {
+		params.ToAll()
+	}
+	created, _, err := s.client.Messages.Create(r.Context(), roomID, params)
+	if err != nil {
+		writeAPIError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusCreated, created)
+}
+
+// writeAPIError reports an error from the ChatWork API with its status, or
+// as a bad gateway. A rejected gateway token is a bad gateway too, so that
+// clients do not mistake it for a problem with their own token.
+func writeAPIError(w http.ResponseWriter, err error) {
+	status := http.StatusBadGateway
+	var apiErr *chatwork.APIError
+	if errors.As(err, &apiErr) && apiErr.Response != nil && apiErr.Response.StatusCode != http.StatusUnauthorized {
+		status = apiErr.Response.StatusCode
+	}
+	log.Printf("chatworkd: %v", err)
+	writeError(w, status, err.Error())
+}
+
+func writeError(w http.ResponseWriter, status int, message string) {
+	writeJSON(w, status, map[string]string{"error": message})
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		log.Printf("chatworkd: writing response: %v", err)
+	}
+}
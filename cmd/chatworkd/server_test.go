@@ -0,0 +1,116 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/nashirox/chatwork-go"
+	"github.com/nashirox/chatwork-go/chatworktest"
+)
+
+func TestServer(t *testing.T) {
+	srv := chatworktest.NewServer()
+	defer srv.Close()
+
+	allowed := srv.AddRoom(chatwork.Room{Name: "Alerts"})
+	denied := srv.AddRoom(chatwork.Room{Name: "Board"})
+	s := newServer(srv.Client(), []string{"secret", "other"}, []chatwork.RoomID{allowed})
+
+	messages := "/v1/rooms/" + allowed.String() + "/messages"
+	tests := []struct {
+		name   string
+		method string
+		path   string
+		auth   string
+		body   string
+		status int
+	}{
+		{"health check without token", "GET", "/healthz", "", "", http.StatusOK},
+		{"missing token", "GET", "/v1/rooms", "", "", http.StatusUnauthorized},
+		{"wrong token", "GET", "/v1/rooms", "Bearer wrong", "", http.StatusUnauthorized},
+		{"token without bearer scheme", "GET", "/v1/rooms", "secret", "", http.StatusUnauthorized},
+		{"valid token", "GET", "/v1/rooms", "Bearer other", "", http.StatusOK},
+		{"wrong method for rooms", "POST", "/v1/rooms", "Bearer secret", "", http.StatusMethodNotAllowed},
+		{"wrong method for messages", "GET", messages, "Bearer secret", "", http.StatusMethodNotAllowed},
+		{"unknown path", "GET", "/v1/contacts", "Bearer secret", "", http.StatusNotFound},
+		{"invalid room ID", "POST", "/v1/rooms/abc/messages", "Bearer secret", `{"body":"hi"}`, http.StatusNotFound},
+		{"room not allowed", "POST", "/v1/rooms/" + denied.String() + "/messages", "Bearer secret", `{"body":"hi"}`, http.StatusForbidden},
+		{"invalid body", "POST", messages, "Bearer secret", `{`, http.StatusBadRequest},
+		{"empty message", "POST", messages, "Bearer secret", `{"body":" "}`, http.StatusBadRequest},
+		{"message sent", "POST", messages, "Bearer secret", `{"body":"Deploy finished","to":[1]}`, http.StatusCreated},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, tt.path, strings.NewReader(tt.body))
+			if tt.auth != "" {
+				req.Header.Set("Authorization", tt.auth)
+			}
+			rec := httptest.NewRecorder()
+			s.ServeHTTP(rec, req)
+			if rec.Code != tt.status {
+				t.Errorf("Expected status %d, got %d: %s", tt.status, rec.Code, rec.Body)
+			}
+		})
+	}
+
+	if got := srv.Messages(allowed); len(got) != 1 || !strings.Contains(got[0].Body, "Deploy finished") {
+		t.Errorf("Expected the message to be sent to the allowed room, got %+v", got)
+	}
+	if got := srv.Messages(denied); len(got) != 0 {
+		t.Errorf("Expected no messages in the denied room, got %+v", got)
+	}
+}
+
+func TestServer_ListRooms(t *testing.T) {
+	srv := chatworktest.NewServer()
+	defer srv.Close()
+
+	allowed := srv.AddRoom(chatwork.Room{Name: "Alerts"})
+	srv.AddRoom(chatwork.Room{Name: "Board"})
+
+	list := func(s *server) []chatwork.Room {
+		req := httptest.NewRequest("GET", "/v1/rooms", nil)
+		req.Header.Set("Authorization", "Bearer secret")
+		rec := httptest.NewRecorder()
+		s.ServeHTTP(rec, req)
+		var rooms []chatwork.Room
+		if err := json.NewDecoder(rec.Body).Decode(&rooms); err != nil {
+			t.Fatalf("Invalid response %d: %v", rec.Code, err)
+		}
+		return rooms
+	}
+
+	if rooms := list(newServer(srv.Client(), []string{"secret"}, []chatwork.RoomID{allowed})); len(rooms) != 1 || rooms[0].RoomID != allowed {
+		t.Errorf("Expected only the allowed room to be listed, got %+v", rooms)
+	}
+	if rooms := list(newServer(srv.Client(), []string{"secret"}, nil)); len(rooms) != 2 {
+		t.Errorf("Expected every room to be listed without restriction, got %+v", rooms)
+	}
+}
+
+func TestWriteAPIError(t *testing.T) {
+	srv := chatworktest.NewServer()
+	defer srv.Close()
+	s := newServer(srv.Client(), []string{"secret"}, nil)
+
+	for _, tt := range []struct {
+		upstream int
+		status   int
+	}{
+		{http.StatusUnauthorized, http.StatusBadGateway},
+		{http.StatusForbidden, http.StatusForbidden},
+		{http.StatusServiceUnavailable, http.StatusServiceUnavailable},
+	} {
+		srv.Fail("GET", "rooms", tt.upstream)
+		req := httptest.NewRequest("GET", "/v1/rooms", nil)
+		req.Header.Set("Authorization", "Bearer secret")
+		rec := httptest.NewRecorder()
+		s.ServeHTTP(rec, req)
+		if rec.Code != tt.status {
+			t.Errorf("Expected ChatWork status %d to be reported as %d, got %d", tt.upstream, tt.status, rec.Code)
+		}
+	}
+}
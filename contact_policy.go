@@ -0,0 +1,183 @@
+package chatwork
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Actions decided by a ContactRequestPolicy.
+const (
+	ContactRequestIgnore  = "ignore"
+	ContactRequestApprove = "approve"
+	ContactRequestReject  = "reject"
+)
+
+// ContactRequestRule is a named rule of a ContactRequestPolicy.
+type ContactRequestRule struct {
+	Name string
+
+	// Decide returns the action for r, or "" if the rule does not apply.
+	Decide func(r *IncomingRequest) string
+}
+
+// ContactRequestPolicy decides whether pending contact requests are
+// approved, rejected, or left pending. See IncomingRequestsService.ApplyPolicy.
+type ContactRequestPolicy struct {
+	// Rules in the order they are checked. The first rule that applies to a
+	// request decides its action.
+	Rules []ContactRequestRule
+
+	// Action for requests no rule applies to. Defaults to
+	// ContactRequestIgnore.
+	Default string
+
+	// Decide without approving or rejecting anything, to preview a policy
+	DryRun bool
+}
+
+// ContactRequestResult is the outcome of applying a policy to a request.
+type ContactRequestResult struct {
+	Request *IncomingRequest
+
+	// Action decided for the request, and the rule that decided it ("" for
+	// the default action)
+	Action string
+	Rule   string
+
+	// Why the action failed, if it did
+	Err error
+}
+
+// decide returns the action for r and the rule that decided it.
+func (p *ContactRequestPolicy) decide(r *IncomingRequest) (string, string, error) {
+	for _, rule := range p.Rules {
+		switch action := rule.Decide(r); action {
+		case "":
+			continue
+		case ContactRequestIgnore, ContactRequestApprove, ContactRequestReject:
+			return action, rule.Name, nil
+		default:
+			return "", rule.Name, fmt.Errorf("%w: rule %s decided unknown action %q", ErrInvalidParams, rule.Name, action)
+		}
+	}
+	switch p.Default {
+	case "":
+		return ContactRequestIgnore, "", nil
+	case ContactRequestIgnore, ContactRequestApprove, ContactRequestReject:
+		return p.Default, "", nil
+	default:
+		return "", "", fmt.Errorf("%w: unknown default action %q", ErrInvalidParams, p.Default)
+	}
+}
+
+// ApplyPolicy decides an action for every pending contact request, oldest
+// first, with policy, carries it out, and returns the result for each
+// request.
+//
+// Since the API lists at most 100 requests, the requests are listed again
+// once they have all been decided, until a listing has no new requests.
+// Failures of individual requests do not stop the others. They are joined
+// into the returned error, as is a failure to list the requests.
+func (s *IncomingRequestsService) ApplyPolicy(ctx context.Context, policy *ContactRequestPolicy, opts ...RequestOption) ([]*ContactRequestResult, error) {
+	if policy == nil {
+		return nil, errNilParams
+	}
+
+	var (
+		results []*ContactRequestResult
+		errs    []error
+		seen    = make(map[int]bool)
+	)
+	for {
+		requests, _, err := s.ListFiltered(ctx, nil, opts...)
+		if err != nil {
+			errs = append(errs, err)
+			break
+		}
+
+		fresh := false
+		for _, r := range requests {
+			if seen[r.RequestID] {
+				continue
+			}
+			seen[r.RequestID] = true
+			fresh = true
+
+			result := &ContactRequestResult{Request: r}
+			result.Action, result.Rule, result.Err = policy.decide(r)
+			if result.Err == nil && !policy.DryRun {
+				switch result.Action {
+				case ContactRequestApprove:
+					_, _, result.Err = s.Approve(ctx, r.RequestID, opts...)
+				case ContactRequestReject:
+					_, result.Err = s.Reject(ctx, r.RequestID, opts...)
+				}
+			}
+			if result.Err != nil {
+				errs = append(errs, fmt.Errorf("contact request %d: %w", r.RequestID, result.Err))
+			}
+			results = append(results, result)
+		}
+		// Nothing left the list if every request was ignored or failed.
+		if !fresh || policy.DryRun {
+			break
+		}
+	}
+	return results, errors.Join(errs...)
+}
+
+// ApproveOrganizations returns a rule that approves requests from accounts
+// of the organizations with the given IDs.
+func ApproveOrganizations(organizationIDs ...int) ContactRequestRule {
+	allowed := make(map[int]bool, len(organizationIDs))
+	for _, id := range organizationIDs {
+		allowed[id] = true
+	}
+	return ContactRequestRule{
+		Name: "approve-organizations",
+		Decide: func(r *IncomingRequest) string {
+			if allowed[r.OrganizationID] {
+				return ContactRequestApprove
+			}
+			return ""
+		},
+	}
+}
+
+// ApproveChatworkIDSuffix returns a rule that approves requests from
+// accounts whose Chatwork ID ends with one of suffixes, ignoring case, such
+// as a company's domain or naming convention.
+func ApproveChatworkIDSuffix(suffixes ...string) ContactRequestRule {
+	return ContactRequestRule{
+		Name: "approve-chatwork-id-suffix",
+		Decide: func(r *IncomingRequest) string {
+			id := strings.ToLower(r.ChatworkID)
+			for _, suffix := range suffixes {
+				if id != "" && strings.HasSuffix(id, strings.ToLower(suffix)) {
+					return ContactRequestApprove
+				}
+			}
+			return ""
+		},
+	}
+}
+
+// RejectAccounts returns a rule that rejects requests from the accounts
+// with the given IDs. Put it first to override rules that approve.
+func RejectAccounts(accountIDs ...AccountID) ContactRequestRule {
+	denied := make(map[AccountID]bool, len(accountIDs))
+	for _, id := range accountIDs {
+		denied[id] = true
+	}
+	return ContactRequestRule{
+		Name: "reject-accounts",
+		Decide: func(r *IncomingRequest) string {
+			if denied[r.AccountID] {
+				return ContactRequestReject
+			}
+			return ""
+		},
+	}
+}
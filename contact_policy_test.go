@@ -0,0 +1,72 @@
+package chatwork_test
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"testing"
+
+	"github.com/nashirox/chatwork-go"
+	"github.com/nashirox/chatwork-go/chatworktest"
+)
+
+func TestIncomingRequestsService_ApplyPolicy(t *testing.T) {
+	srv := chatworktest.NewServer()
+	defer srv.Close()
+
+	approved := srv.AddIncomingRequest(chatwork.IncomingRequest{AccountID: 10, OrganizationID: 1})
+	denied := srv.AddIncomingRequest(chatwork.IncomingRequest{AccountID: 20, OrganizationID: 1})
+	byID := srv.AddIncomingRequest(chatwork.IncomingRequest{AccountID: 30, ChatworkID: "carol_ACME"})
+	ignored := srv.AddIncomingRequest(chatwork.IncomingRequest{AccountID: 40, OrganizationID: 2})
+	client := srv.Client()
+	ctx := context.Background()
+
+	policy := &chatwork.ContactRequestPolicy{
+		Rules: []chatwork.ContactRequestRule{
+			chatwork.RejectAccounts(20),
+			chatwork.ApproveOrganizations(1),
+			chatwork.ApproveChatworkIDSuffix("_acme"),
+		},
+		DryRun: true,
+	}
+	want := map[int]string{
+		approved: chatwork.ContactRequestApprove,
+		denied:   chatwork.ContactRequestReject,
+		byID:     chatwork.ContactRequestApprove,
+		ignored:  chatwork.ContactRequestIgnore,
+	}
+
+	results, err := client.IncomingRequests.ApplyPolicy(ctx, policy)
+	if err != nil {
+		t.Fatalf("ApplyPolicy returned error: %v", err)
+	}
+	if len(results) != 4 {
+		t.Fatalf("Expected 4 results, got %d", len(results))
+	}
+	for _, r := range results {
+		if r.Action != want[r.Request.RequestID] {
+			t.Errorf("Expected %s for request %d, got %s (%s)", want[r.Request.RequestID], r.Request.RequestID, r.Action, r.Rule)
+		}
+	}
+	if requests, _, _ := client.IncomingRequests.List(ctx); len(requests) != 4 {
+		t.Errorf("Expected a dry run to change nothing, got %d pending requests", len(requests))
+	}
+
+	policy.DryRun = false
+	srv.Fail("PUT", "/incoming_requests/"+strconv.Itoa(byID), http.StatusInternalServerError)
+	results, err = client.IncomingRequests.ApplyPolicy(ctx, policy)
+	if err == nil {
+		t.Error("Expected the failed approval to be reported")
+	}
+	if len(results) != 4 {
+		t.Errorf("Expected 4 results, got %d", len(results))
+	}
+	requests, _, _ := client.IncomingRequests.List(ctx)
+	var pending []int
+	for _, r := range requests {
+		pending = append(pending, r.RequestID)
+	}
+	if len(pending) != 2 {
+		t.Errorf("Expected the failed and ignored requests to remain, got %v", pending)
+	}
+}
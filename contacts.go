@@ -2,6 +2,8 @@ package chatwork
 
 import (
 	"context"
+	"fmt"
+	"sort"
 	"strconv"
 )
 
@@ -11,22 +13,83 @@ import (
 // ChatWork API docs: https://developer.chatwork.com/reference/contacts
 type ContactsService service
 
-// List returns all contacts of the authenticated user.
+// List returns all contacts of the authenticated user. With
+// OptionContactsCache, they may be served from the cache.
 //
 // ChatWork API docs: https://developer.chatwork.com/reference/get-contacts
-func (s *ContactsService) List(ctx context.Context) ([]*Contact, *Response, error) {
+func (s *ContactsService) List(ctx context.Context, opts ...RequestOption) ([]*Contact, *Response, error) {
 	req, err := s.client.NewRequest("GET", "contacts", nil)
 	if err != nil {
 		return nil, nil, err
 	}
 
-	var contacts []*Contact
-	resp, err := s.client.Do(ctx, req, &contacts)
+	cache := s.client.contactsCache
+	if cache == nil {
+		return doValue[[]*Contact](ctx, s.client, req, opts...)
+	}
+	// Cached contacts are still subject to the client's permissions.
+	if err := s.client.checkPermissions(req); err != nil {
+		return nil, nil, err
+	}
+	key, _ := s.client.contactsCacheKey(nil)
+	if contacts, ok := cache.get(key); ok {
+		return contacts, nil, nil
+	}
+	return s.ForceRefresh(ctx, opts...)
+}
+
+// Filter lists the contacts and returns those for which match returns
+// true, such as ContactOrganizationIs(id).
+func (s *ContactsService) Filter(ctx context.Context, match func(*Contact) bool, opts ...RequestOption) ([]*Contact, *Response, error) {
+	contacts, resp, err := s.List(ctx, opts...)
 	if err != nil {
 		return nil, resp, err
 	}
 
-	return contacts, resp, nil
+	var matched []*Contact
+	for _, c := range contacts {
+		if match(c) {
+			matched = append(matched, c)
+		}
+	}
+	return matched, resp, nil
+}
+
+// FindByChatworkID returns the contact with the given Chatwork ID, the ID
+// users choose for themselves. The error wraps ErrNotFound if there is none.
+func (s *ContactsService) FindByChatworkID(ctx context.Context, chatworkID string, opts ...RequestOption) (*Contact, *Response, error) {
+	contacts, resp, err := s.Filter(ctx, func(c *Contact) bool { return c.ChatworkID == chatworkID }, opts...)
+	if err != nil {
+		return nil, resp, err
+	}
+	if len(contacts) == 0 {
+		return nil, resp, fmt.Errorf("%w: contact %q", ErrNotFound, chatworkID)
+	}
+	return contacts[0], resp, nil
+}
+
+// FindByName returns the first listed contact named name. The error wraps
+// ErrNotFound if there is none.
+func (s *ContactsService) FindByName(ctx context.Context, name string, opts ...RequestOption) (*Contact, *Response, error) {
+	contacts, resp, err := s.Filter(ctx, ContactNameIs(name), opts...)
+	if err != nil {
+		return nil, resp, err
+	}
+	if len(contacts) == 0 {
+		return nil, resp, fmt.Errorf("%w: contact named %q", ErrNotFound, name)
+	}
+	return contacts[0], resp, nil
+}
+
+// ContactNameIs matches contacts named name, for ContactsService.Filter.
+func ContactNameIs(name string) func(*Contact) bool {
+	return func(c *Contact) bool { return c.Name == name }
+}
+
+// ContactOrganizationIs matches contacts of the organization with the
+// given ID, for ContactsService.Filter.
+func ContactOrganizationIs(organizationID int) func(*Contact) bool {
+	return func(c *Contact) bool { return c.OrganizationID == organizationID }
 }
 
 // IncomingRequestsService handles communication with the incoming requests related
@@ -38,63 +101,129 @@ type IncomingRequestsService service
 // List returns all pending contact requests.
 //
 // ChatWork API docs: https://developer.chatwork.com/reference/get-incoming_requests
-func (s *IncomingRequestsService) List(ctx context.Context) ([]*IncomingRequest, *Response, error) {
+func (s *IncomingRequestsService) List(ctx context.Context, opts ...RequestOption) ([]*IncomingRequest, *Response, error) {
 	req, err := s.client.NewRequest("GET", "incoming_requests", nil)
 	if err != nil {
 		return nil, nil, err
 	}
 
-	var requests []*IncomingRequest
-	resp, err := s.client.Do(ctx, req, &requests)
+	return doValue[[]*IncomingRequest](ctx, s.client, req, opts...)
+}
+
+// IncomingRequestListParams selects and orders the requests returned by
+// IncomingRequestsService.ListFiltered. The API reports no time for
+// requests, so they are ordered by ID, which increases with age.
+type IncomingRequestListParams struct {
+	// Only requests from accounts of these organizations, if any
+	OrganizationIDs []int
+
+	// Only requests for which Match returns true, if set
+	Match func(*IncomingRequest) bool
+
+	// Order newest first instead of oldest first
+	NewestFirst bool
+
+	// Maximum number of requests returned; zero returns all
+	Limit int
+}
+
+// ListFiltered lists the pending contact requests and returns those
+// selected by params, which may be nil, oldest first unless NewestFirst
+// is set.
+//
+// The API lists at most 100 requests. Approving or rejecting them makes
+// room for the next ones; the All iterator lists again as needed.
+func (s *IncomingRequestsService) ListFiltered(ctx context.Context, params *IncomingRequestListParams, opts ...RequestOption) ([]*IncomingRequest, *Response, error) {
+	requests, resp, err := s.List(ctx, opts...)
 	if err != nil {
 		return nil, resp, err
 	}
+	if params == nil {
+		params = &IncomingRequestListParams{}
+	}
+
+	organizations := make(map[int]bool, len(params.OrganizationIDs))
+	for _, id := range params.OrganizationIDs {
+		organizations[id] = true
+	}
+	var selected []*IncomingRequest
+	for _, r := range requests {
+		if len(organizations) > 0 && !organizations[r.OrganizationID] {
+			continue
+		}
+		if params.Match != nil && !params.Match(r) {
+			continue
+		}
+		selected = append(selected, r)
+	}
 
-	return requests, resp, nil
+	sort.SliceStable(selected, func(i, j int) bool {
+		if params.NewestFirst {
+			return selected[i].RequestID > selected[j].RequestID
+		}
+		return selected[i].RequestID < selected[j].RequestID
+	})
+	if params.Limit > 0 && len(selected) > params.Limit {
+		selected = selected[:params.Limit]
+	}
+	return selected, resp, nil
+}
+
+// GetByAccountID returns the pending contact request from an account. The
+// error wraps ErrNotFound if there is none.
+func (s *IncomingRequestsService) GetByAccountID(ctx context.Context, accountID AccountID, opts ...RequestOption) (*IncomingRequest, *Response, error) {
+	requests, resp, err := s.ListFiltered(ctx, &IncomingRequestListParams{
+		Match: func(r *IncomingRequest) bool { return r.AccountID == accountID },
+	}, opts...)
+	if err != nil {
+		return nil, resp, err
+	}
+	if len(requests) == 0 {
+		return nil, resp, fmt.Errorf("%w: no contact request from account %d", ErrNotFound, accountID)
+	}
+	return requests[0], resp, nil
 }
 
 // IncomingRequestActionResponse represents the response when approving a contact request.
 type IncomingRequestActionResponse struct {
-	AccountID        int    `json:"account_id"`
-	RoomID           int    `json:"room_id"`
-	Name             string `json:"name"`
-	ChatworkID       string `json:"chatwork_id"`
-	OrganizationID   int    `json:"organization_id"`
-	OrganizationName string `json:"organization_name"`
-	Department       string `json:"department"`
-	AvatarImageURL   string `json:"avatar_image_url"`
+	AccountID        AccountID `json:"account_id"`
+	RoomID           RoomID    `json:"room_id"`
+	Name             string    `json:"name"`
+	ChatworkID       string    `json:"chatwork_id"`
+	OrganizationID   int       `json:"organization_id"`
+	OrganizationName string    `json:"organization_name"`
+	Department       string    `json:"department"`
+	AvatarImageURL   string    `json:"avatar_image_url"`
 }
 
 // Approve approves a contact request.
 //
 // ChatWork API docs: https://developer.chatwork.com/reference/put-incoming_requests-request_id
-func (s *IncomingRequestsService) Approve(ctx context.Context, requestID int) (*IncomingRequestActionResponse, *Response, error) {
+func (s *IncomingRequestsService) Approve(ctx context.Context, requestID int, opts ...RequestOption) (*IncomingRequestActionResponse, *Response, error) {
 	u := "incoming_requests/" + strconv.Itoa(requestID)
 	req, err := s.client.NewRequest("PUT", u, nil)
 	if err != nil {
 		return nil, nil, err
 	}
 
-	result := new(IncomingRequestActionResponse)
-	resp, err := s.client.Do(ctx, req, result)
-	if err != nil {
-		return nil, resp, err
+	result, resp, err := Do[IncomingRequestActionResponse](ctx, s.client, req, opts...)
+	if err == nil && s.client.contactsCache != nil {
+		s.client.contactsCache.invalidate()
 	}
-
-	return result, resp, nil
+	return result, resp, err
 }
 
 // Reject rejects a contact request.
 //
 // ChatWork API docs: https://developer.chatwork.com/reference/delete-incoming_requests-request_id
-func (s *IncomingRequestsService) Reject(ctx context.Context, requestID int) (*Response, error) {
+func (s *IncomingRequestsService) Reject(ctx context.Context, requestID int, opts ...RequestOption) (*Response, error) {
 	u := "incoming_requests/" + strconv.Itoa(requestID)
 	req, err := s.client.NewRequest("DELETE", u, nil)
 	if err != nil {
 		return nil, err
 	}
 
-	resp, err := s.client.Do(ctx, req, nil)
+	resp, err := s.client.Do(ctx, req, nil, opts...)
 	if err != nil {
 		return resp, err
 	}
@@ -0,0 +1,135 @@
+package chatwork
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// contactsCache memoizes the contact list of a client, for each token of
+// its pool. Clients without a pool only use key 0.
+type contactsCache struct {
+	ttl time.Duration
+	now func() time.Time
+
+	mu      sync.Mutex
+	entries map[int]contactsEntry
+}
+
+type contactsEntry struct {
+	contacts []*Contact
+	expires  time.Time
+}
+
+// OptionContactsCache makes ContactsService.List, and the lookups built on
+// it such as FindByName, reuse the listed contacts for ttl instead of
+// listing them on every call. Calls served from the cache return a nil
+// *Response.
+//
+// The cache is invalidated when the client approves a contact request,
+// and can be invalidated with ContactsService.Invalidate or refreshed with
+// ForceRefresh, for example when contacts are added in another client.
+// Clients derived with WithPermissions share the cache.
+//
+// With OptionTokenPool, the tokens usually belong to different accounts, so
+// the contacts are cached for each token of the pool: after a rotation, the
+// contacts of the new token are listed rather than served from the cache of
+// the previous one.
+func OptionContactsCache(ttl time.Duration) ClientOption {
+	return func(c *Client) {
+		c.contactsCache = &contactsCache{ttl: ttl, now: time.Now}
+	}
+}
+
+// get returns copies of the contacts cached for key, if they have not
+// expired.
+func (cc *contactsCache) get(key int) ([]*Contact, bool) {
+	cc.mu.Lock()
+	defer cc.mu.Unlock()
+
+	entry, ok := cc.entries[key]
+	if !ok || !cc.now().Before(entry.expires) {
+		return nil, false
+	}
+	return copyContacts(entry.contacts), true
+}
+
+// set caches copies of contacts for key.
+func (cc *contactsCache) set(key int, contacts []*Contact) {
+	cc.mu.Lock()
+	defer cc.mu.Unlock()
+
+	if cc.entries == nil {
+		cc.entries = make(map[int]contactsEntry)
+	}
+	entry := contactsEntry{contacts: copyContacts(contacts), expires: cc.now().Add(cc.ttl)}
+	if entry.contacts == nil {
+		entry.contacts = []*Contact{}
+	}
+	cc.entries[key] = entry
+}
+
+// invalidate discards the contacts cached for every key.
+func (cc *contactsCache) invalidate() {
+	cc.mu.Lock()
+	defer cc.mu.Unlock()
+
+	cc.entries = nil
+}
+
+// contactsCacheKey returns the key of the contacts cached for the token of
+// the pool that authenticated req, or for the current token of the pool when
+// req is nil. It returns false when the token is not one of the pool.
+func (c *Client) contactsCacheKey(req *http.Request) (int, bool) {
+	if c.tokenPool == nil {
+		return 0, true
+	}
+	if req == nil {
+		return c.tokenPool.active(), true
+	}
+	index := c.tokenPool.index(requestToken(req))
+	return index, index >= 0
+}
+
+// copyContacts returns copies of contacts, so that callers cannot modify
+// the cached ones.
+func copyContacts(contacts []*Contact) []*Contact {
+	if contacts == nil {
+		return nil
+	}
+	copies := make([]*Contact, len(contacts))
+	for i, c := range contacts {
+		copied := *c
+		copies[i] = &copied
+	}
+	return copies
+}
+
+// ForceRefresh lists the contacts, bypassing the cache of
+// OptionContactsCache, and caches the result.
+func (s *ContactsService) ForceRefresh(ctx context.Context, opts ...RequestOption) ([]*Contact, *Response, error) {
+	req, err := s.client.NewRequest("GET", "contacts", nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	contacts, resp, err := doValue[[]*Contact](ctx, s.client, req, opts...)
+	if err != nil {
+		return nil, resp, err
+	}
+	if s.client.contactsCache != nil && resp != nil && resp.Response != nil {
+		if key, ok := s.client.contactsCacheKey(resp.Request); ok {
+			s.client.contactsCache.set(key, contacts)
+		}
+	}
+	return contacts, resp, nil
+}
+
+// Invalidate discards the contacts cached with OptionContactsCache, so
+// that the next call lists them again. It has no effect without the option.
+func (s *ContactsService) Invalidate() {
+	if s.client.contactsCache != nil {
+		s.client.contactsCache.invalidate()
+	}
+}
@@ -0,0 +1,148 @@
+package chatwork_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/nashirox/chatwork-go"
+	"github.com/nashirox/chatwork-go/chatworktest"
+)
+
+func TestContactsService_Find(t *testing.T) {
+	srv := chatworktest.NewServer()
+	defer srv.Close()
+
+	srv.AddContact(chatwork.Contact{AccountID: 10, Name: "Alice", ChatworkID: "alice", OrganizationID: 1})
+	srv.AddContact(chatwork.Contact{AccountID: 20, Name: "Bob", ChatworkID: "bob", OrganizationID: 2})
+	srv.AddContact(chatwork.Contact{AccountID: 30, Name: "Carol", OrganizationID: 1})
+	client := srv.Client()
+	ctx := context.Background()
+
+	c, _, err := client.Contacts.FindByChatworkID(ctx, "bob")
+	if err != nil || c.AccountID != 20 {
+		t.Errorf("Expected account 20, got %v, %v", c, err)
+	}
+	c, _, err = client.Contacts.FindByName(ctx, "Carol")
+	if err != nil || c.AccountID != 30 {
+		t.Errorf("Expected account 30, got %v, %v", c, err)
+	}
+	if _, _, err := client.Contacts.FindByChatworkID(ctx, "dave"); !errors.Is(err, chatwork.ErrNotFound) {
+		t.Errorf("Expected ErrNotFound, got %v", err)
+	}
+
+	contacts, _, err := client.Contacts.Filter(ctx, chatwork.ContactOrganizationIs(1))
+	if err != nil {
+		t.Fatalf("Filter returned error: %v", err)
+	}
+	var ids []chatwork.AccountID
+	for _, c := range contacts {
+		ids = append(ids, c.AccountID)
+	}
+	if want := []chatwork.AccountID{10, 30}; !reflect.DeepEqual(ids, want) {
+		t.Errorf("Expected contacts %v, got %v", want, ids)
+	}
+}
+
+func TestIncomingRequestsService_ListFiltered(t *testing.T) {
+	srv := chatworktest.NewServer()
+	defer srv.Close()
+
+	srv.AddIncomingRequest(chatwork.IncomingRequest{RequestID: 3, AccountID: 30, OrganizationID: 1})
+	srv.AddIncomingRequest(chatwork.IncomingRequest{RequestID: 1, AccountID: 10, OrganizationID: 1})
+	srv.AddIncomingRequest(chatwork.IncomingRequest{RequestID: 2, AccountID: 20, OrganizationID: 2})
+	client := srv.Client()
+	ctx := context.Background()
+
+	ids := func(requests []*chatwork.IncomingRequest, _ *chatwork.Response, err error) []int {
+		if err != nil {
+			t.Fatalf("ListFiltered returned error: %v", err)
+		}
+		var ids []int
+		for _, r := range requests {
+			ids = append(ids, r.RequestID)
+		}
+		return ids
+	}
+
+	tests := []struct {
+		params *chatwork.IncomingRequestListParams
+		want   []int
+	}{
+		{nil, []int{1, 2, 3}},
+		{&chatwork.IncomingRequestListParams{OrganizationIDs: []int{1}}, []int{1, 3}},
+		{&chatwork.IncomingRequestListParams{NewestFirst: true, Limit: 2}, []int{3, 2}},
+		{&chatwork.IncomingRequestListParams{Match: func(r *chatwork.IncomingRequest) bool { return r.AccountID > 10 }}, []int{2, 3}},
+	}
+	for _, tt := range tests {
+		got := ids(client.IncomingRequests.ListFiltered(ctx, tt.params))
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("Expected requests %v, got %v", tt.want, got)
+		}
+	}
+
+	r, _, err := client.IncomingRequests.GetByAccountID(ctx, 20)
+	if err != nil || r.RequestID != 2 {
+		t.Errorf("Expected request 2, got %v, %v", r, err)
+	}
+	if _, _, err := client.IncomingRequests.GetByAccountID(ctx, 40); !errors.Is(err, chatwork.ErrNotFound) {
+		t.Errorf("Expected ErrNotFound, got %v", err)
+	}
+}
+
+func TestOptionContactsCache(t *testing.T) {
+	srv := chatworktest.NewServer()
+	defer srv.Close()
+
+	srv.AddContact(chatwork.Contact{AccountID: 10, Name: "Alice"})
+	requestID := srv.AddIncomingRequest(chatwork.IncomingRequest{AccountID: 20, Name: "Bob"})
+
+	var listed int
+	client := srv.Client(
+		chatwork.OptionContactsCache(time.Hour),
+		chatwork.OptionOnRequest(func(r *http.Request) {
+			if strings.HasSuffix(r.URL.Path, "/contacts") {
+				listed++
+			}
+		}),
+	)
+	ctx := context.Background()
+
+	for i := 0; i < 3; i++ {
+		if _, _, err := client.Contacts.FindByName(ctx, "Alice"); err != nil {
+			t.Fatalf("FindByName returned error: %v", err)
+		}
+	}
+	if listed != 1 {
+		t.Errorf("Expected the contacts to be listed once, got %d", listed)
+	}
+
+	contacts, _, _ := client.Contacts.List(ctx)
+	contacts[0].Name = "Mallory"
+	if _, _, err := client.Contacts.FindByName(ctx, "Alice"); err != nil {
+		t.Errorf("Expected the cache to be unaffected by callers, got %v", err)
+	}
+
+	if _, _, err := client.IncomingRequests.Approve(ctx, requestID); err != nil {
+		t.Fatalf("Approve returned error: %v", err)
+	}
+	if _, _, err := client.Contacts.FindByName(ctx, "Bob"); err != nil {
+		t.Errorf("Expected approving a request to invalidate the cache, got %v", err)
+	}
+
+	client.Contacts.Invalidate()
+	client.Contacts.List(ctx)
+	client.Contacts.ForceRefresh(ctx)
+	if listed != 4 {
+		t.Errorf("Expected 4 listings, got %d", listed)
+	}
+
+	derived := client.WithPermissions(chatwork.Permissions{DisabledServices: []string{"Contacts"}})
+	if _, _, err := derived.Contacts.List(ctx); !errors.Is(err, chatwork.ErrPermissionDenied) {
+		t.Errorf("Expected cached contacts to respect permissions, got %v", err)
+	}
+}
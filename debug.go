@@ -0,0 +1,149 @@
+package chatwork
+
+import (
+	"bytes"
+	"io"
+	"log/slog"
+	"net/http"
+	"os"
+	"sort"
+	"strings"
+	"time"
+)
+
+// redacted replaces credentials in debug output.
+const redacted = "[REDACTED]"
+
+// OptionDebug enables debug mode for the client.
+// When enabled, the client will log detailed information about API requests and responses.
+// This is useful for troubleshooting and development.
+//
+// Each request is logged with its method, URL, status code, and latency.
+// The API token is never written to the log. Use OptionLogger to send the
+// output to your own slog.Logger and OptionDebugBodies to include bodies.
+func OptionDebug(debug bool) ClientOption {
+	return func(c *Client) {
+		c.debug = debug
+	}
+}
+
+// OptionLogger sets the logger used for debug output.
+// Records are written at slog.LevelDebug, so the logger's handler must be
+// configured to accept that level. Without this option, debug output goes
+// to standard error.
+//
+// The logger also receives deprecation warnings of the API (see
+// Response.Warnings) at slog.LevelWarn, which otherwise go to slog.Default.
+func OptionLogger(logger *slog.Logger) ClientOption {
+	return func(c *Client) {
+		c.logger = logger
+	}
+}
+
+// OptionDebugBodies includes request and response bodies in debug output.
+// It has no effect unless debug mode is enabled with OptionDebug.
+func OptionDebugBodies(enabled bool) ClientOption {
+	return func(c *Client) {
+		c.debugBodies = enabled
+	}
+}
+
+// OptionDebugCurl adds each request to debug output as an equivalent curl
+// command, with the API token masked, so that it can be reproduced outside
+// of Go. It has no effect unless debug mode is enabled with OptionDebug.
+func OptionDebugCurl(enabled bool) ClientOption {
+	return func(c *Client) {
+		c.debugCurl = enabled
+	}
+}
+
+// debugLogger returns the logger used for debug output.
+func (c *Client) debugLogger() *slog.Logger {
+	if c.logger != nil {
+		return c.logger
+	}
+	return slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelDebug}))
+}
+
+// logExchange writes a single request/response pair to the debug logger.
+//
+// When bodies are logged, the response body is buffered and replaced so that
+// it can still be decoded by the caller.
+func (c *Client) logExchange(req *http.Request, resp *http.Response, latency time.Duration, err error) {
+	token := requestToken(req)
+	redact := func(s string) string {
+		if token == "" {
+			return s
+		}
+		return strings.ReplaceAll(s, token, redacted)
+	}
+
+	attrs := []slog.Attr{
+		slog.String("method", req.Method),
+		slog.String("url", redact(req.URL.String())),
+		slog.Duration("latency", latency),
+	}
+
+	if c.debugCurl {
+		attrs = append(attrs, slog.String("curl", redact(curlCommand(req))))
+	}
+
+	if c.debugBodies && req.GetBody != nil {
+		if body, bodyErr := req.GetBody(); bodyErr == nil {
+			data, _ := io.ReadAll(body)
+			body.Close()
+			attrs = append(attrs, slog.String("request_body", redact(string(data))))
+		}
+	}
+
+	if resp != nil {
+		attrs = append(attrs, slog.Int("status", resp.StatusCode))
+		if c.debugBodies {
+			data, readErr := io.ReadAll(resp.Body)
+			resp.Body.Close()
+			resp.Body = io.NopCloser(bytes.NewReader(data))
+			if readErr == nil {
+				attrs = append(attrs, slog.String("response_body", redact(string(data))))
+			}
+		}
+	}
+
+	if err != nil {
+		attrs = append(attrs, slog.String("error", redact(err.Error())))
+	}
+
+	c.debugLogger().LogAttrs(req.Context(), slog.LevelDebug, "chatwork: api request", attrs...)
+}
+
+// curlCommand returns a curl command line that sends the same request as req.
+func curlCommand(req *http.Request) string {
+	var b strings.Builder
+	b.WriteString("curl -X " + req.Method + " " + shellQuote(req.URL.String()))
+
+	keys := make([]string, 0, len(req.Header))
+	for key := range req.Header {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		for _, value := range req.Header[key] {
+			b.WriteString(" -H " + shellQuote(key+": "+value))
+		}
+	}
+
+	if req.GetBody != nil {
+		if body, err := req.GetBody(); err == nil {
+			data, _ := io.ReadAll(body)
+			body.Close()
+			if len(data) > 0 {
+				b.WriteString(" --data-raw " + shellQuote(string(data)))
+			}
+		}
+	}
+	return b.String()
+}
+
+// shellQuote quotes s as a single word for POSIX shells.
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
@@ -0,0 +1,108 @@
+package chatwork
+
+import (
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// parseWarnings returns the deprecation notices of a response, from the
+// headers that announce them:
+//
+//   - Deprecation (RFC 9745), with the time the endpoint was deprecated
+//   - Sunset (RFC 8594), with the time it stops working
+//   - Link with relation "deprecation" or "sunset", to documentation
+//   - Warning, with a free-form message
+func parseWarnings(r *http.Response) []string {
+	var warnings []string
+
+	if v := r.Header.Get("Deprecation"); v != "" {
+		warning := "endpoint is deprecated"
+		if t, ok := parseHeaderTime(v); ok {
+			warning += " since " + t.UTC().Format(time.RFC3339)
+		}
+		warnings = append(warnings, warning)
+	}
+	if v := r.Header.Get("Sunset"); v != "" {
+		if t, ok := parseHeaderTime(v); ok {
+			warnings = append(warnings, "endpoint will be removed at "+t.UTC().Format(time.RFC3339))
+		}
+	}
+	for _, link := range r.Header.Values("Link") {
+		for _, part := range strings.Split(link, ",") {
+			target, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+			if strings.Contains(params, `rel="deprecation"`) || strings.Contains(params, `rel="sunset"`) ||
+				strings.Contains(params, "rel=deprecation") || strings.Contains(params, "rel=sunset") {
+				warnings = append(warnings, "see "+strings.Trim(strings.TrimSpace(target), "<>"))
+			}
+		}
+	}
+	for _, v := range r.Header.Values("Warning") {
+		warnings = append(warnings, v)
+	}
+
+	if len(warnings) == 0 {
+		return nil
+	}
+	if r.Request == nil {
+		return warnings
+	}
+	for i, w := range warnings {
+		warnings[i] = r.Request.Method + " " + r.Request.URL.Path + ": " + w
+	}
+	return warnings
+}
+
+// parseHeaderTime parses a structured date (@1688169599) or an HTTP date.
+func parseHeaderTime(v string) (time.Time, bool) {
+	if strings.HasPrefix(v, "@") {
+		seconds, err := strconv.ParseInt(v[1:], 10, 64)
+		if err != nil {
+			return time.Time{}, false
+		}
+		return time.Unix(seconds, 0), true
+	}
+	t, err := http.ParseTime(v)
+	return t, err == nil
+}
+
+// warningLog logs each distinct deprecation warning once per client.
+type warningLog struct {
+	mu     sync.Mutex
+	logged map[string]bool
+}
+
+// log writes the warnings not logged before to logger at slog.LevelWarn.
+func (l *warningLog) log(req *http.Request, logger *slog.Logger, warnings []string) {
+	if l == nil || len(warnings) == 0 {
+		return
+	}
+
+	l.mu.Lock()
+	var fresh []string
+	for _, w := range warnings {
+		if !l.logged[w] {
+			if l.logged == nil {
+				l.logged = make(map[string]bool)
+			}
+			l.logged[w] = true
+			fresh = append(fresh, w)
+		}
+	}
+	l.mu.Unlock()
+
+	for _, w := range fresh {
+		logger.LogAttrs(req.Context(), slog.LevelWarn, "chatwork: api deprecation", slog.String("warning", w))
+	}
+}
+
+// warningLogger returns the logger for deprecation warnings.
+func (c *Client) warningLogger() *slog.Logger {
+	if c.logger != nil {
+		return c.logger
+	}
+	return slog.Default()
+}
@@ -0,0 +1,70 @@
+package chatwork
+
+import (
+	"context"
+	"strings"
+	"text/template"
+)
+
+// DescriptionTemplate renders room descriptions from a text/template.
+//
+// Templates refer to variables with the usual template syntax, for example:
+//
+//	tmpl, err := chatwork.NewDescriptionTemplate(
+//		"On call: {{.OnCall}}\nSprint: {{.Sprint}}\nBoard: {{.BoardURL}}")
+//
+// Missing variables are reported as errors rather than rendered as "<no value>".
+type DescriptionTemplate struct {
+	tmpl *template.Template
+}
+
+// NewDescriptionTemplate parses text into a DescriptionTemplate.
+func NewDescriptionTemplate(text string) (*DescriptionTemplate, error) {
+	tmpl, err := template.New("description").Option("missingkey=error").Parse(text)
+	if err != nil {
+		return nil, err
+	}
+	return &DescriptionTemplate{tmpl: tmpl}, nil
+}
+
+// Render executes the template with the given variables and returns the description.
+// vars is typically a map[string]string or a struct.
+func (t *DescriptionTemplate) Render(vars interface{}) (string, error) {
+	var b strings.Builder
+	if err := t.tmpl.Execute(&b, vars); err != nil {
+		return "", err
+	}
+	return b.String(), nil
+}
+
+// UpdateDescription renders tmpl with vars and sets the result as the room description.
+//
+// The current description is fetched first and the update is skipped when it
+// already matches the rendered text (compared with Normalize), so the method
+// can be called on every tick of a periodic job without extra writes.
+// The returned bool reports whether the description was changed.
+func (s *RoomsService) UpdateDescription(ctx context.Context, roomID RoomID, tmpl *DescriptionTemplate, vars interface{}, opts ...RequestOption) (bool, *Response, error) {
+	description, err := tmpl.Render(vars)
+	if err != nil {
+		return false, nil, err
+	}
+
+	room, resp, err := s.Get(ctx, roomID, opts...)
+	if err != nil {
+		return false, resp, err
+	}
+
+	if Normalize(room.Description) == Normalize(description) {
+		return false, resp, nil
+	}
+
+	params := &RoomUpdateParams{
+		Description: description,
+	}
+	_, resp, err = s.Update(ctx, roomID, params, opts...)
+	if err != nil {
+		return false, resp, err
+	}
+
+	return true, resp, nil
+}
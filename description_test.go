@@ -0,0 +1,49 @@
+package chatwork_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/nashirox/chatwork-go"
+	"github.com/nashirox/chatwork-go/chatworktest"
+)
+
+func TestRoomsService_UpdateDescription(t *testing.T) {
+	srv := chatworktest.NewServer()
+	defer srv.Close()
+
+	roomID := srv.AddRoom(chatwork.Room{Name: "Team", Description: "On call: Alice  \r\nSprint: 41"})
+	updates := 0
+	client := srv.Client(chatwork.OptionOnRequest(func(r *http.Request) {
+		if r.Method == http.MethodPut {
+			updates++
+		}
+	}))
+	ctx := context.Background()
+
+	tmpl, err := chatwork.NewDescriptionTemplate("On call: {{.OnCall}}\nSprint: {{.Sprint}}")
+	if err != nil {
+		t.Fatalf("NewDescriptionTemplate returned error: %v", err)
+	}
+
+	changed, _, err := client.Rooms.UpdateDescription(ctx, roomID, tmpl, map[string]interface{}{"OnCall": "Alice", "Sprint": 41})
+	if err != nil || changed || updates != 0 {
+		t.Errorf("Expected a description differing only in whitespace to be left alone, got %v, %v after %d updates", changed, err, updates)
+	}
+
+	changed, _, err = client.Rooms.UpdateDescription(ctx, roomID, tmpl, map[string]interface{}{"OnCall": "Bob", "Sprint": 42})
+	if err != nil || !changed || updates != 1 {
+		t.Errorf("Expected the description to be updated, got %v, %v after %d updates", changed, err, updates)
+	}
+	if room, _ := srv.Room(roomID); room.Description != "On call: Bob\nSprint: 42" {
+		t.Errorf("Expected the rendered description, got %q", room.Description)
+	}
+
+	if _, _, err := client.Rooms.UpdateDescription(ctx, roomID, tmpl, map[string]interface{}{"OnCall": "Bob"}); err == nil {
+		t.Error("Expected a missing variable to be reported")
+	}
+	if updates != 1 {
+		t.Errorf("Expected no update after a rendering error, got %d updates", updates)
+	}
+}
@@ -0,0 +1,381 @@
+package chatwork
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+var (
+	// ErrDownloadURLExpired is returned when a file download URL is rejected,
+	// which happens once the URL's validity period has passed.
+	ErrDownloadURLExpired = errors.New("chatwork: download URL expired")
+
+	// ErrSizeMismatch is returned when a downloaded file does not have the
+	// size reported by the API.
+	ErrSizeMismatch = errors.New("chatwork: downloaded size does not match file size")
+)
+
+const (
+	defaultDownloadAttempts    = 3
+	defaultDownloadRetryWait   = time.Second
+	defaultDownloadConcurrency = 4
+)
+
+// DownloadManager downloads room files robustly.
+//
+// Download URLs issued by ChatWork expire after a short time. The manager
+// requests a fresh URL when one is rejected, resumes interrupted transfers
+// with HTTP Range requests, and verifies the size of the result.
+// DownloadAll downloads many files concurrently, such as to back up a room.
+type DownloadManager struct {
+	client *Client
+
+	// Maximum number of transfer attempts per file. Defaults to 3.
+	MaxAttempts int
+
+	// Pause between attempts. Defaults to one second.
+	RetryWait time.Duration
+
+	// Number of files DownloadAll transfers at the same time. Defaults to 4.
+	Concurrency int
+
+	// Cap on the combined transfer rate of each call, in bytes per second.
+	// Zero means no cap.
+	BytesPerSecond int64
+
+	// Called as file contents arrive (optional), such as to show progress.
+	// It may be called from several goroutines at once.
+	OnProgress func(*DownloadProgress)
+}
+
+// DownloadProgress reports the transfer of a file by a DownloadManager.
+type DownloadProgress struct {
+	RoomID RoomID
+	File   *File
+
+	// Bytes of the file written so far, including those of earlier runs
+	// that the transfer resumed after
+	Written int64
+}
+
+// NewDownloadManager returns a DownloadManager that uses client for API calls
+// and for fetching file contents.
+func NewDownloadManager(client *Client) *DownloadManager {
+	return &DownloadManager{
+		client:      client,
+		MaxAttempts: defaultDownloadAttempts,
+		RetryWait:   defaultDownloadRetryWait,
+	}
+}
+
+// Download writes the contents of a room file to w and returns its metadata.
+func (m *DownloadManager) Download(ctx context.Context, roomID RoomID, fileID int, w io.Writer, opts ...RequestOption) (*File, error) {
+	return m.download(ctx, roomID, fileID, w, 0, m.newLimiter(), opts)
+}
+
+// DownloadToFile saves a room file at path.
+//
+// If path already holds a partial download, for example from an earlier
+// run that was interrupted, the transfer resumes after the existing bytes.
+func (m *DownloadManager) DownloadToFile(ctx context.Context, roomID RoomID, fileID int, path string, opts ...RequestOption) (*File, error) {
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o600)
+	if err != nil {
+		return nil, err
+	}
+
+	info, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return nil, err
+	}
+
+	file, err := m.download(ctx, roomID, fileID, f, info.Size(), m.newLimiter(), opts)
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	return file, err
+}
+
+// download transfers the file to w, assuming offset bytes were already written.
+func (m *DownloadManager) download(ctx context.Context, roomID RoomID, fileID int, w io.Writer, offset int64, limiter *bandwidthLimiter, opts []RequestOption) (*File, error) {
+	file, _, err := m.client.Rooms.GetFile(ctx, roomID, fileID, true, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return file, m.transfer(ctx, roomID, file, w, offset, limiter, opts)
+}
+
+// transfer writes the contents of file, whose download URL has been
+// requested, to w, assuming offset bytes were already written.
+func (m *DownloadManager) transfer(ctx context.Context, roomID RoomID, file *File, w io.Writer, offset int64, limiter *bandwidthLimiter, opts []RequestOption) error {
+	w = &progressWriter{
+		ctx:     ctx,
+		w:       w,
+		limiter: limiter,
+		progress: DownloadProgress{
+			RoomID:  roomID,
+			File:    file,
+			Written: offset,
+		},
+		report:   m.OnProgress,
+		callback: newRequestConfig(opts).progress,
+	}
+
+	attempts := m.MaxAttempts
+	if attempts < 1 {
+		attempts = 1
+	}
+
+	written := offset
+	for attempt := 1; ; attempt++ {
+		if file.Filesize > 0 && written >= int64(file.Filesize) {
+			break
+		}
+
+		n, err := m.fetch(ctx, file.DownloadURL, w, written)
+		written += n
+		if err == nil {
+			break
+		}
+		if attempt >= attempts || ctx.Err() != nil {
+			return err
+		}
+
+		if sleepErr := sleepContext(ctx, m.RetryWait); sleepErr != nil {
+			return sleepErr
+		}
+		if errors.Is(err, ErrDownloadURLExpired) || file.DownloadURL == "" {
+			refreshed, _, refreshErr := m.client.Rooms.GetFile(ctx, roomID, file.FileID, true, opts...)
+			if refreshErr != nil {
+				return refreshErr
+			}
+			file.DownloadURL = refreshed.DownloadURL
+		}
+	}
+
+	if file.Filesize > 0 && written != int64(file.Filesize) {
+		return fmt.Errorf("%w: got %d bytes, want %d", ErrSizeMismatch, written, file.Filesize)
+	}
+
+	return nil
+}
+
+// fetch copies the contents at downloadURL, starting at offset, to w.
+// It returns the number of bytes written to w.
+func (m *DownloadManager) fetch(ctx context.Context, downloadURL string, w io.Writer, offset int64) (int64, error) {
+	if downloadURL == "" {
+		return 0, ErrDownloadURLExpired
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "GET", downloadURL, nil)
+	if err != nil {
+		return 0, err
+	}
+	if offset > 0 {
+		req.Header.Set("Range", fmt.Sprintf("bytes=%d-", offset))
+	}
+
+	resp, err := m.client.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusPartialContent:
+	case http.StatusOK:
+		// The server ignored the Range header; skip what was already written.
+		if offset > 0 {
+			if _, err := io.CopyN(io.Discard, resp.Body, offset); err != nil {
+				return 0, err
+			}
+		}
+	case http.StatusRequestedRangeNotSatisfiable:
+		// Everything has been transferred already.
+		return 0, nil
+	case http.StatusForbidden, http.StatusUnauthorized:
+		return 0, ErrDownloadURLExpired
+	default:
+		return 0, fmt.Errorf("chatwork: download failed: %s", resp.Status)
+	}
+
+	return io.Copy(w, resp.Body)
+}
+
+// DownloadTarget receives the files downloaded by DownloadManager.DownloadAll.
+type DownloadTarget interface {
+	// Open returns the writer for the contents of file and the number of
+	// bytes of it written before, after which the transfer resumes.
+	Open(file *File) (w io.WriteCloser, offset int64, err error)
+}
+
+// DirTarget is a DownloadTarget that saves files in a directory, named
+// after their ID and file name, such as "123-report.pdf". Partial files
+// left by an interrupted run are resumed.
+type DirTarget string
+
+// Open opens the file for file in the directory for appending.
+func (d DirTarget) Open(file *File) (io.WriteCloser, int64, error) {
+	name := filepath.Base(filepath.Clean("/" + file.Filename))
+	if name == "/" || name == "." {
+		name = "file"
+	}
+	path := filepath.Join(string(d), fmt.Sprintf("%d-%s", file.FileID, name))
+
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o600)
+	if err != nil {
+		return nil, 0, err
+	}
+	info, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return nil, 0, err
+	}
+	return f, info.Size(), nil
+}
+
+// DownloadResult is the outcome of a file downloaded by DownloadAll.
+type DownloadResult struct {
+	FileID int
+
+	// Metadata of the file, if it could be fetched
+	File *File
+
+	// Why the file was not downloaded, if it was not
+	Err error
+}
+
+// DownloadAll downloads files of a room to target, transferring up to
+// Concurrency files at the same time, and returns the results in the order
+// of fileIDs. If fileIDs is nil, all files of the room are downloaded.
+//
+// Each file is retried as with Download. Failures of individual files do
+// not stop the others; they are joined into the returned error.
+//
+// Example:
+//
+//	manager := chatwork.NewDownloadManager(client)
+//	manager.BytesPerSecond = 5 << 20
+//	results, err := manager.DownloadAll(ctx, roomID, nil, chatwork.DirTarget("backup"))
+func (m *DownloadManager) DownloadAll(ctx context.Context, roomID RoomID, fileIDs []int, target DownloadTarget, opts ...RequestOption) ([]*DownloadResult, error) {
+	if fileIDs == nil {
+		files, _, err := m.client.Rooms.GetFiles(ctx, roomID, 0, opts...)
+		if err != nil {
+			return nil, err
+		}
+		fileIDs = make([]int, len(files))
+		for i, file := range files {
+			fileIDs[i] = file.FileID
+		}
+	}
+
+	limiter := m.newLimiter()
+	results := make([]*DownloadResult, len(fileIDs))
+	jobs := make([]BatchJob, len(fileIDs))
+	for i, fileID := range fileIDs {
+		result := &DownloadResult{FileID: fileID}
+		results[i] = result
+		jobs[i] = BatchJob{
+			Name: fmt.Sprintf("file %d", fileID),
+			Run: func(ctx context.Context, client *Client) error {
+				file, _, err := client.Rooms.GetFile(ctx, roomID, result.FileID, true, opts...)
+				if err != nil {
+					return err
+				}
+				result.File = file
+
+				w, offset, err := target.Open(file)
+				if err != nil {
+					return err
+				}
+				err = m.transfer(ctx, roomID, file, w, offset, limiter, opts)
+				if closeErr := w.Close(); err == nil {
+					err = closeErr
+				}
+				return err
+			},
+		}
+	}
+
+	concurrency := m.Concurrency
+	if concurrency <= 0 {
+		concurrency = defaultDownloadConcurrency
+	}
+	batch, err := Batch(ctx, m.client, jobs, &BatchOptions{Concurrency: concurrency})
+	for i, job := range batch {
+		results[i].Err = job.Err
+	}
+	return results, err
+}
+
+// newLimiter returns the bandwidth limiter for a call, or nil if there is
+// no cap.
+func (m *DownloadManager) newLimiter() *bandwidthLimiter {
+	if m.BytesPerSecond <= 0 {
+		return nil
+	}
+	return &bandwidthLimiter{rate: m.BytesPerSecond}
+}
+
+// bandwidthLimiter paces writes so that they do not exceed rate bytes per
+// second on average. It is safe for concurrent use.
+type bandwidthLimiter struct {
+	rate int64
+
+	mu   sync.Mutex
+	next time.Time // when the bytes reserved so far have been paid for
+}
+
+// wait blocks until n more bytes may be written or ctx is done.
+func (l *bandwidthLimiter) wait(ctx context.Context, n int) error {
+	l.mu.Lock()
+	now := time.Now()
+	if l.next.Before(now) {
+		l.next = now
+	}
+	l.next = l.next.Add(time.Duration(int64(n) * int64(time.Second) / l.rate))
+	delay := l.next.Sub(now)
+	l.mu.Unlock()
+
+	return sleepContext(ctx, delay)
+}
+
+// progressWriter passes writes through to w, paced by limiter and reported
+// to report and the callback of WithProgress.
+type progressWriter struct {
+	ctx      context.Context
+	w        io.Writer
+	limiter  *bandwidthLimiter
+	progress DownloadProgress
+	report   func(*DownloadProgress)
+	callback func(transferred, total int64)
+}
+
+func (p *progressWriter) Write(b []byte) (int, error) {
+	if p.limiter != nil {
+		if err := p.limiter.wait(p.ctx, len(b)); err != nil {
+			return 0, err
+		}
+	}
+	n, err := p.w.Write(b)
+	p.progress.Written += int64(n)
+	if p.report != nil && n > 0 {
+		progress := p.progress
+		p.report(&progress)
+	}
+	if p.callback != nil && n > 0 {
+		total := int64(p.progress.File.Filesize)
+		if total <= 0 {
+			total = -1
+		}
+		p.callback(p.progress.Written, total)
+	}
+	return n, err
+}
@@ -0,0 +1,171 @@
+package chatwork
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestDownloadManager_Download(t *testing.T) {
+	content := strings.Repeat("0123456789", 100)
+
+	var server *httptest.Server
+	urlRequests := 0
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/rooms/1/files/2":
+			urlRequests++
+			fmt.Fprintf(w, `{"file_id": 2, "filename": "a.txt", "filesize": %d, "download_url": "%s/blob/%d"}`,
+				len(content), server.URL, urlRequests)
+		case "/blob/1":
+			if r.Header.Get("Range") != "" {
+				// The first URL has expired by the time the transfer is resumed.
+				w.WriteHeader(http.StatusForbidden)
+				return
+			}
+			// Deliver half of the file before the connection drops.
+			w.Header().Set("Content-Length", fmt.Sprint(len(content)))
+			w.Write([]byte(content[:len(content)/2]))
+		case "/blob/2":
+			if r.Header.Get("Range") != fmt.Sprintf("bytes=%d-", len(content)/2) {
+				t.Errorf("Expected the transfer to resume at byte %d, got Range %q", len(content)/2, r.Header.Get("Range"))
+			}
+			http.ServeContent(w, r, "a.txt", time.Time{}, strings.NewReader(content))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client := New(testToken)
+	client.BaseURL, _ = url.Parse(server.URL)
+
+	manager := NewDownloadManager(client)
+	manager.RetryWait = 0
+
+	var buf bytes.Buffer
+	var transferred, total int64
+	progress := WithProgress(func(n, size int64) {
+		if n < transferred {
+			t.Errorf("Progress went back from %d to %d", transferred, n)
+		}
+		transferred, total = n, size
+	})
+	file, err := manager.Download(context.Background(), 1, 2, &buf, progress)
+	if err != nil {
+		t.Fatalf("Download returned error: %v", err)
+	}
+	if transferred != int64(len(content)) || total != int64(len(content)) {
+		t.Errorf("Expected progress to end at %d of %d bytes, got %d of %d", len(content), len(content), transferred, total)
+	}
+	if file.FileID != 2 {
+		t.Errorf("Expected file metadata for file 2, got %+v", file)
+	}
+	if buf.String() != content {
+		t.Errorf("Downloaded content does not match: got %d bytes", buf.Len())
+	}
+	if urlRequests != 2 {
+		t.Errorf("Expected the download URL to be refreshed once, got %d requests", urlRequests)
+	}
+}
+
+func TestDownloadManager_SizeMismatch(t *testing.T) {
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/blob" {
+			w.Write([]byte("short"))
+			return
+		}
+		fmt.Fprintf(w, `{"file_id": 2, "filesize": 3, "download_url": "%s/blob"}`, server.URL)
+	}))
+	defer server.Close()
+
+	client := New(testToken)
+	client.BaseURL, _ = url.Parse(server.URL)
+
+	_, err := NewDownloadManager(client).Download(context.Background(), 1, 2, &bytes.Buffer{})
+	if !errors.Is(err, ErrSizeMismatch) {
+		t.Errorf("Expected ErrSizeMismatch, got %v", err)
+	}
+}
+
+func TestDownloadManager_DownloadAll(t *testing.T) {
+	contents := map[string]string{"1": strings.Repeat("a", 1000), "2": strings.Repeat("b", 2000)}
+
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/rooms/7/files":
+			w.Write([]byte(`[{"file_id": 1}, {"file_id": 2}, {"file_id": 3}]`))
+		case strings.HasPrefix(r.URL.Path, "/rooms/7/files/"):
+			id := strings.TrimPrefix(r.URL.Path, "/rooms/7/files/")
+			content, ok := contents[id]
+			if !ok {
+				w.WriteHeader(http.StatusNotFound)
+				w.Write([]byte(`{"errors": ["File not found"]}`))
+				return
+			}
+			fmt.Fprintf(w, `{"file_id": %s, "filename": "../report-%s.txt", "filesize": %d, "download_url": "%s/blob/%s"}`,
+				id, id, len(content), server.URL, id)
+		case strings.HasPrefix(r.URL.Path, "/blob/"):
+			content := contents[strings.TrimPrefix(r.URL.Path, "/blob/")]
+			http.ServeContent(w, r, "", time.Time{}, strings.NewReader(content))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client := New(testToken)
+	client.BaseURL, _ = url.Parse(server.URL)
+
+	dir := t.TempDir()
+	// A partial file left by an earlier run is resumed.
+	if err := os.WriteFile(filepath.Join(dir, "2-report-2.txt"), []byte(contents["2"][:500]), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	var mu sync.Mutex
+	written := make(map[int]int64)
+	manager := NewDownloadManager(client)
+	manager.RetryWait = 0
+	manager.BytesPerSecond = 20000
+	manager.OnProgress = func(p *DownloadProgress) {
+		mu.Lock()
+		written[p.File.FileID] = p.Written
+		mu.Unlock()
+	}
+
+	start := time.Now()
+	results, err := manager.DownloadAll(context.Background(), 7, nil, DirTarget(dir))
+	if !errors.Is(err, ErrNotFound) {
+		t.Errorf("Expected the missing file to be reported, got %v", err)
+	}
+	// 2500 bytes at 20000 bytes per second
+	if elapsed := time.Since(start); elapsed < 100*time.Millisecond {
+		t.Errorf("Expected the transfer to be throttled, finished in %v", elapsed)
+	}
+
+	if len(results) != 3 || results[0].Err != nil || results[1].Err != nil || results[2].FileID != 3 || results[2].Err == nil {
+		t.Fatalf("Unexpected results %+v", results)
+	}
+	for id, content := range contents {
+		data, err := os.ReadFile(filepath.Join(dir, id+"-report-"+id+".txt"))
+		if err != nil || string(data) != content {
+			t.Errorf("Unexpected content of file %s: %d bytes, %v", id, len(data), err)
+		}
+	}
+	if written[1] != 1000 || written[2] != 2000 {
+		t.Errorf("Expected progress up to the file sizes, got %v", written)
+	}
+}
@@ -0,0 +1,64 @@
+package chatwork
+
+import (
+	"io"
+	"log/slog"
+	"net/http"
+)
+
+// OptionDryRun keeps the client from sending POST, PUT, and DELETE requests,
+// so that automation that changes many rooms, members, or tasks can be tried
+// safely. GET requests are sent as usual.
+//
+// Mutating calls are still validated, and checked against permissions and
+// policies, but are then logged at slog.LevelInfo instead of being sent. The
+// log goes to the logger set with OptionLogger, or slog.Default.
+//
+// A call that is not sent succeeds with a synthetic "200 OK" Response whose
+// DryRun field is true, and whose Request has the request options, such as
+// WithHeader and WithTimeout, applied. Its result is left empty, so for
+// example the message returned by MessagesService.SendMessage has no ID.
+func OptionDryRun(enabled bool) ClientOption {
+	return func(c *Client) {
+		c.dryRun = enabled
+	}
+}
+
+// skipDryRun logs req and returns a synthetic response for it if it must not
+// be sent because of OptionDryRun, or nil otherwise.
+func (c *Client) skipDryRun(req *http.Request) *Response {
+	if !c.dryRun || req.Method == http.MethodGet || req.Method == http.MethodHead {
+		return nil
+	}
+
+	attrs := []slog.Attr{
+		slog.String("method", req.Method),
+		slog.String("url", req.URL.String()),
+	}
+	if req.GetBody != nil {
+		if body, err := req.GetBody(); err == nil {
+			data, _ := io.ReadAll(body)
+			body.Close()
+			attrs = append(attrs, slog.String("request_body", string(data)))
+		}
+	}
+	logger := c.logger
+	if logger == nil {
+		logger = slog.Default()
+	}
+	logger.LogAttrs(req.Context(), slog.LevelInfo, "chatwork: dry run", attrs...)
+
+	return &Response{
+		Response: &http.Response{
+			Status:     "200 OK",
+			StatusCode: http.StatusOK,
+			Proto:      "HTTP/1.1",
+			ProtoMajor: 1,
+			ProtoMinor: 1,
+			Header:     make(http.Header),
+			Body:       http.NoBody,
+			Request:    req,
+		},
+		DryRun: true,
+	}
+}
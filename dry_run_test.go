@@ -0,0 +1,63 @@
+package chatwork_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/nashirox/chatwork-go"
+	"github.com/nashirox/chatwork-go/chatworktest"
+)
+
+func TestOptionDryRun(t *testing.T) {
+	srv := chatworktest.NewServer()
+	defer srv.Close()
+
+	roomID := srv.AddRoom(chatwork.Room{Name: "Team"})
+	var log bytes.Buffer
+	client := srv.Client(chatwork.OptionDryRun(true), chatwork.OptionLogger(slog.New(slog.NewTextHandler(&log, nil))))
+	ctx := context.Background()
+
+	created, resp, err := client.Messages.SendMessage(ctx, roomID, "Deploy finished")
+	if err != nil {
+		t.Fatalf("SendMessage returned error: %v", err)
+	}
+	if !resp.DryRun || resp.StatusCode != 200 || created.MessageID != "" {
+		t.Errorf("Expected a synthetic response, got status %d, dry run %v, message %q", resp.StatusCode, resp.DryRun, created.MessageID)
+	}
+	if messages := srv.Messages(roomID); len(messages) != 0 {
+		t.Errorf("Expected no message to be sent, got %d", len(messages))
+	}
+	if !strings.Contains(log.String(), "method=POST") || !strings.Contains(log.String(), "Deploy+finished") {
+		t.Errorf("Expected the request to be logged, got %q", log.String())
+	}
+
+	// The synthetic response carries the request as it would have been sent.
+	_, resp, err = client.Messages.SendMessage(ctx, roomID, "Deploy finished",
+		chatwork.WithHeader("X-Request-Id", "deploy-1"), chatwork.WithTimeout(time.Minute))
+	if err != nil {
+		t.Fatalf("SendMessage returned error: %v", err)
+	}
+	if resp.Request.Header.Get("X-Request-Id") != "deploy-1" {
+		t.Errorf("Expected the request options to be applied, got headers %v", resp.Request.Header)
+	}
+	if _, ok := resp.Request.Context().Deadline(); !ok {
+		t.Error("Expected the request timeout to be applied")
+	}
+
+	room, resp, err := client.Rooms.Get(ctx, roomID)
+	if err != nil {
+		t.Fatalf("Get returned error: %v", err)
+	}
+	if resp.DryRun || room.Name != "Team" {
+		t.Errorf("Expected GET requests to be sent, got %+v", room)
+	}
+
+	if _, _, err := client.Tasks.Create(ctx, roomID, nil); !errors.Is(err, chatwork.ErrInvalidParams) {
+		t.Errorf("Expected invalid parameters to be rejected, got %v", err)
+	}
+}
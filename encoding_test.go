@@ -0,0 +1,130 @@
+package chatwork
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"testing/quick"
+)
+
+var trickyStrings = []string{
+	"",
+	"a&b=c",
+	"name=value&other=1",
+	"line1\nline2\r\nline3",
+	"100% sure + more",
+	"#fragment?query",
+	"[To:123] [info][title]hi[/title]body[/info]",
+	"日本語のメッセージ 🎉👍",
+	"\x00\x01 control",
+	"\xff\xfe invalid utf-8",
+	strings.Repeat("long body ", 5000),
+}
+
+// formBody encodes params through NewFormRequest and parses the result back.
+func formBody(t *testing.T, params interface{}) url.Values {
+	t.Helper()
+
+	client := New(testToken)
+	req, err := client.NewFormRequest("POST", "rooms/1/messages", params)
+	if err != nil {
+		t.Fatalf("NewFormRequest returned error: %v", err)
+	}
+
+	data, err := io.ReadAll(req.Body)
+	if err != nil {
+		t.Fatalf("Failed to read request body: %v", err)
+	}
+
+	values, err := url.ParseQuery(string(data))
+	if err != nil {
+		t.Fatalf("Request body is not valid form data: %v", err)
+	}
+	return values
+}
+
+func TestFormEncoding_RoundTrip(t *testing.T) {
+	for _, body := range trickyStrings {
+		values := formBody(t, &MessageCreateParams{Body: body})
+		if got := values.Get("body"); got != body {
+			t.Errorf("Body %q was encoded as %q", body, got)
+		}
+		if len(values) != 1 {
+			t.Errorf("Body %q produced unexpected fields: %v", body, values)
+		}
+	}
+}
+
+func TestFormEncoding_Property(t *testing.T) {
+	roundTrip := func(name, description string) bool {
+		values := formBody(t, &RoomCreateParams{Name: name, Description: description})
+		return values.Get("name") == name && values.Get("description") == description
+	}
+
+	if err := quick.Check(roundTrip, nil); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestNewFormRequest_KeepsBasePath(t *testing.T) {
+	client := New(testToken)
+
+	req, err := client.NewFormRequest("POST", "rooms", nil)
+	if err != nil {
+		t.Fatalf("NewFormRequest returned error: %v", err)
+	}
+
+	if want := defaultBaseURL + "/rooms"; req.URL.String() != want {
+		t.Errorf("Expected URL %s, got %s", want, req.URL.String())
+	}
+}
+
+func TestMessageIDEscaping(t *testing.T) {
+	var gotPath, gotQuery string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath = r.URL.EscapedPath()
+		gotQuery = r.URL.RawQuery
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	client := New(testToken)
+	client.BaseURL, _ = url.Parse(server.URL)
+
+	if _, _, err := client.Messages.Get(context.Background(), 1, "../2?force=1#x"); err != nil {
+		t.Fatalf("Messages.Get returned error: %v", err)
+	}
+
+	if !strings.HasPrefix(gotPath, "/rooms/1/messages/") || strings.Contains(gotPath, "/../") {
+		t.Errorf("Message ID escaped the messages path: %s", gotPath)
+	}
+	if gotQuery != "" {
+		t.Errorf("Message ID leaked into the query string: %s", gotQuery)
+	}
+
+	if _, _, err := client.Rooms.GetMessagesReadStatus(context.Background(), 1, "5&force=1"); err != nil {
+		t.Fatalf("Rooms.GetMessagesReadStatus returned error: %v", err)
+	}
+
+	query, _ := url.ParseQuery(gotQuery)
+	if query.Get("message_id") != "5&force=1" || query.Has("force") {
+		t.Errorf("Message ID was not escaped in the query string: %s", gotQuery)
+	}
+}
+
+func FuzzFormEncoding(f *testing.F) {
+	for _, s := range trickyStrings {
+		f.Add(s)
+	}
+
+	f.Fuzz(func(t *testing.T, body string) {
+		values := formBody(t, &MessageUpdateParams{Body: body})
+		if got := values.Get("body"); got != body {
+			t.Errorf("Body %q was encoded as %q", body, got)
+		}
+	})
+}
@@ -0,0 +1,152 @@
+package chatwork
+
+import "fmt"
+
+// Role is the role of a member in a room, and of the authenticated user in
+// the rooms it participates in.
+type Role string
+
+// Roles of room members.
+const (
+	RoleAdmin    Role = "admin"
+	RoleMember   Role = "member"
+	RoleReadonly Role = "readonly"
+)
+
+// String returns the role as the API sends it.
+func (r Role) String() string {
+	return string(r)
+}
+
+// Validate returns an error wrapping ErrInvalidParams if r is not one of
+// the Role constants.
+func (r Role) Validate() error {
+	switch r {
+	case RoleAdmin, RoleMember, RoleReadonly:
+		return nil
+	default:
+		return fmt.Errorf("%w: invalid member role %q", ErrInvalidParams, string(r))
+	}
+}
+
+// RoomType is the type of a room.
+type RoomType string
+
+// Types of rooms.
+const (
+	RoomTypeGroup  RoomType = "group"
+	RoomTypeDirect RoomType = "direct"
+	RoomTypeMy     RoomType = "my"
+)
+
+// String returns the room type as the API sends it.
+func (t RoomType) String() string {
+	return string(t)
+}
+
+// Validate returns an error wrapping ErrInvalidParams if t is not one of
+// the RoomType constants.
+func (t RoomType) Validate() error {
+	switch t {
+	case RoomTypeGroup, RoomTypeDirect, RoomTypeMy:
+		return nil
+	default:
+		return fmt.Errorf("%w: invalid room type %q", ErrInvalidParams, string(t))
+	}
+}
+
+// IconPreset is one of the icons a group chat can be given on creation or
+// update.
+type IconPreset string
+
+// Icons of group chats.
+const (
+	IconPresetGroup    IconPreset = "group"
+	IconPresetCheck    IconPreset = "check"
+	IconPresetDocument IconPreset = "document"
+	IconPresetMeeting  IconPreset = "meeting"
+	IconPresetEvent    IconPreset = "event"
+	IconPresetProject  IconPreset = "project"
+	IconPresetBusiness IconPreset = "business"
+	IconPresetStudy    IconPreset = "study"
+	IconPresetSecurity IconPreset = "security"
+	IconPresetStar     IconPreset = "star"
+	IconPresetIdea     IconPreset = "idea"
+	IconPresetHeart    IconPreset = "heart"
+	IconPresetMagcup   IconPreset = "magcup"
+	IconPresetBeer     IconPreset = "beer"
+	IconPresetMusic    IconPreset = "music"
+	IconPresetSports   IconPreset = "sports"
+	IconPresetTravel   IconPreset = "travel"
+)
+
+// String returns the icon preset as the API sends it.
+func (p IconPreset) String() string {
+	return string(p)
+}
+
+// Validate returns an error wrapping ErrInvalidParams if p is not one of
+// the IconPreset constants.
+func (p IconPreset) Validate() error {
+	switch p {
+	case IconPresetGroup, IconPresetCheck, IconPresetDocument, IconPresetMeeting,
+		IconPresetEvent, IconPresetProject, IconPresetBusiness, IconPresetStudy,
+		IconPresetSecurity, IconPresetStar, IconPresetIdea, IconPresetHeart,
+		IconPresetMagcup, IconPresetBeer, IconPresetMusic, IconPresetSports,
+		IconPresetTravel:
+		return nil
+	default:
+		return fmt.Errorf("%w: invalid icon preset %q", ErrInvalidParams, string(p))
+	}
+}
+
+// TaskStatus is the status of a task.
+type TaskStatus string
+
+// Statuses of tasks.
+const (
+	TaskStatusOpen TaskStatus = "open"
+	TaskStatusDone TaskStatus = "done"
+)
+
+// String returns the task status as the API sends it.
+func (s TaskStatus) String() string {
+	return string(s)
+}
+
+// Validate returns an error wrapping ErrInvalidParams if s is not one of
+// the TaskStatus constants.
+func (s TaskStatus) Validate() error {
+	switch s {
+	case TaskStatusOpen, TaskStatusDone:
+		return nil
+	default:
+		return fmt.Errorf("%w: task status must be %q or %q, got %q", ErrInvalidParams, TaskStatusOpen, TaskStatusDone, string(s))
+	}
+}
+
+// LimitType is the type of a task's deadline.
+type LimitType string
+
+// Types of task deadlines.
+const (
+	LimitTypeNone LimitType = "none" // no deadline
+	LimitTypeDate LimitType = "date" // due on a date
+	LimitTypeTime LimitType = "time" // due at a time
+)
+
+// String returns the limit type as the API sends it.
+func (t LimitType) String() string {
+	return string(t)
+}
+
+// Validate returns an error wrapping ErrInvalidParams if t is not one of
+// the LimitType constants.
+func (t LimitType) Validate() error {
+	switch t {
+	case LimitTypeNone, LimitTypeDate, LimitTypeTime:
+		return nil
+	default:
+		return fmt.Errorf("%w: invalid task limit type %q", ErrInvalidParams, string(t))
+	}
+}
@@ -0,0 +1,48 @@
+package chatwork
+
+import (
+	"errors"
+	"net/http"
+)
+
+// Sentinel errors classifying API failures.
+//
+// An *APIError unwraps to the sentinel matching its HTTP status code, so
+// callers can test for a class of failure without inspecting the response:
+//
+//	if errors.Is(err, chatwork.ErrNotFound) {
+//		// the room or message does not exist
+//	}
+var (
+	ErrBadRequest   = errors.New("chatwork: bad request")
+	ErrUnauthorized = errors.New("chatwork: unauthorized")
+	ErrForbidden    = errors.New("chatwork: forbidden")
+	ErrNotFound     = errors.New("chatwork: not found")
+	ErrRateLimited  = errors.New("chatwork: rate limited")
+	ErrServerError  = errors.New("chatwork: server error")
+)
+
+// Unwrap returns the sentinel error for the response status code, or nil if
+// the status code has no dedicated sentinel.
+func (r *APIError) Unwrap() error {
+	if r.Response == nil {
+		return nil
+	}
+
+	switch code := r.Response.StatusCode; {
+	case code == http.StatusBadRequest:
+		return ErrBadRequest
+	case code == http.StatusUnauthorized:
+		return ErrUnauthorized
+	case code == http.StatusForbidden:
+		return ErrForbidden
+	case code == http.StatusNotFound:
+		return ErrNotFound
+	case code == http.StatusTooManyRequests:
+		return ErrRateLimited
+	case code >= 500:
+		return ErrServerError
+	default:
+		return nil
+	}
+}
@@ -0,0 +1,234 @@
+// Package eventbus fans out ChatWork events, captured by watchers or
+// webhooks, to subscribers in the same process and to message brokers such
+// as NATS or Kafka, so that other services can react to them.
+//
+// Events are published to brokers as JSON. A Broker is a single method, so
+// any broker client can be plugged in with a few lines, for example NATS:
+//
+//	bus := eventbus.New(eventbus.BrokerFunc(func(ctx context.Context, topic string, data []byte) error {
+//		return nc.Publish(topic, data)
+//	}))
+//
+//	// Webhook events
+//	handler.OnEvent(bus.HandleWebhook)
+//
+//	// Watched rooms
+//	go bus.PublishMessages(ctx, client.WatchShard(ctx, ring, node, roomIDs, nil))
+package eventbus
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/nashirox/chatwork-go"
+	"github.com/nashirox/chatwork-go/webhook"
+)
+
+// Sources of events.
+const (
+	SourceWatch   = "watch"
+	SourceWebhook = "webhook"
+)
+
+// ErrUnknownEvent is returned by FromWebhook for payloads of event types this
+// package does not know.
+var ErrUnknownEvent = errors.New("eventbus: unknown event type")
+
+// Event is a ChatWork event. Type is one of the webhook event types, such as
+// webhook.EventMessageCreated.
+type Event struct {
+	Type   string `json:"type"`
+	Source string `json:"source"`
+
+	RoomID    chatwork.RoomID    `json:"room_id"`
+	MessageID chatwork.MessageID `json:"message_id"`
+	AccountID chatwork.AccountID `json:"account_id"` // Sender of the message
+
+	// Account mentioned, for webhook.EventMentionToMe
+	ToAccountID chatwork.AccountID `json:"to_account_id,omitempty"`
+
+	Body       string             `json:"body"`
+	SendTime   chatwork.Timestamp `json:"send_time"`
+	UpdateTime chatwork.Timestamp `json:"update_time,omitempty"`
+}
+
+// FromMessage returns the message_created event of a message posted to a
+// room, as seen by a watcher.
+func FromMessage(roomID chatwork.RoomID, m *chatwork.Message) *Event {
+	return &Event{
+		Type:       webhook.EventMessageCreated,
+		Source:     SourceWatch,
+		RoomID:     roomID,
+		MessageID:  m.MessageID,
+		AccountID:  m.Account.AccountID,
+		Body:       m.Body,
+		SendTime:   m.SendTime,
+		UpdateTime: m.UpdateTime,
+	}
+}
+
+// FromWebhook returns the event of a webhook payload. Events that cannot be
+// decoded are reported with webhook.ErrMalformedEvent.
+func FromWebhook(p *webhook.Payload) (*Event, error) {
+	e := &Event{Type: p.EventType, Source: SourceWebhook}
+	switch p.EventType {
+	case webhook.EventMessageCreated, webhook.EventMessageUpdated:
+		var m webhook.MessageEvent
+		if err := json.Unmarshal(p.Event, &m); err != nil {
+			return nil, fmt.Errorf("%w %s: %w", webhook.ErrMalformedEvent, p.EventType, err)
+		}
+		e.RoomID, e.MessageID, e.AccountID = m.RoomID, m.MessageID, m.AccountID
+		e.Body, e.SendTime, e.UpdateTime = m.Body, m.SendTime, m.UpdateTime
+	case webhook.EventMentionToMe:
+		var m webhook.MentionEvent
+		if err := json.Unmarshal(p.Event, &m); err != nil {
+			return nil, fmt.Errorf("%w %s: %w", webhook.ErrMalformedEvent, p.EventType, err)
+		}
+		e.RoomID, e.MessageID, e.AccountID, e.ToAccountID = m.RoomID, m.MessageID, m.FromAccountID, m.ToAccountID
+		e.Body, e.SendTime, e.UpdateTime = m.Body, m.SendTime, m.UpdateTime
+	default:
+		return nil, fmt.Errorf("%w %q", ErrUnknownEvent, p.EventType)
+	}
+	return e, nil
+}
+
+// Broker publishes encoded events to a message broker.
+type Broker interface {
+	Publish(ctx context.Context, topic string, data []byte) error
+}
+
+// BrokerFunc adapts a function to a Broker.
+type BrokerFunc func(ctx context.Context, topic string, data []byte) error
+
+// Publish calls f.
+func (f BrokerFunc) Publish(ctx context.Context, topic string, data []byte) error {
+	return f(ctx, topic, data)
+}
+
+// DefaultTopic returns the topic of an event: "chatwork." followed by its
+// type, such as "chatwork.message_created".
+func DefaultTopic(e *Event) string {
+	return "chatwork." + e.Type
+}
+
+// Bus delivers published events to its subscribers and brokers. A Bus is
+// safe for concurrent use.
+type Bus struct {
+	brokers []Broker
+
+	// Topic of each event at the brokers. Defaults to DefaultTopic.
+	Topic func(*Event) string
+
+	// Called with errors from PublishMessages (optional)
+	OnError func(error)
+
+	mu     sync.RWMutex
+	nextID int
+	subs   map[int]*subscription
+}
+
+type subscription struct {
+	types   map[string]bool // nil for every type
+	handler func(context.Context, *Event)
+}
+
+// New returns a Bus that publishes to brokers.
+func New(brokers ...Broker) *Bus {
+	return &Bus{
+		brokers: brokers,
+		Topic:   DefaultTopic,
+		subs:    make(map[int]*subscription),
+	}
+}
+
+// Subscribe calls handler, in the publishing goroutine, with every published
+// event of the given types, or of every type if none are given. It returns a
+// function that ends the subscription.
+func (b *Bus) Subscribe(handler func(ctx context.Context, e *Event), types ...string) (unsubscribe func()) {
+	sub := &subscription{handler: handler}
+	if len(types) > 0 {
+		sub.types = make(map[string]bool, len(types))
+		for _, t := range types {
+			sub.types[t] = true
+		}
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	id := b.nextID
+	b.nextID++
+	b.subs[id] = sub
+
+	return func() {
+		b.mu.Lock()
+		defer b.mu.Unlock()
+		delete(b.subs, id)
+	}
+}
+
+// Publish delivers e to the subscribers, then to every broker. Errors of the
+// brokers are joined; a failing broker does not keep e from the others.
+func (b *Bus) Publish(ctx context.Context, e *Event) error {
+	b.mu.RLock()
+	var handlers []func(context.Context, *Event)
+	for _, sub := range b.subs {
+		if sub.types == nil || sub.types[e.Type] {
+			handlers = append(handlers, sub.handler)
+		}
+	}
+	b.mu.RUnlock()
+
+	for _, handler := range handlers {
+		handler(ctx, e)
+	}
+
+	if len(b.brokers) == 0 {
+		return nil
+	}
+	data, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+	topic := DefaultTopic
+	if b.Topic != nil {
+		topic = b.Topic
+	}
+
+	var errs []error
+	for _, broker := range b.brokers {
+		if err := broker.Publish(ctx, topic(e), data); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// HandleWebhook publishes the event of a webhook payload, ignoring unknown
+// event types. It has the signature of webhook.Handler.OnEvent callbacks, so
+// that a failed publication is reported to ChatWork as a failed delivery.
+// Undecodable events are reported with webhook.ErrMalformedEvent, which the
+// Handler answers with 400 so that ChatWork does not retry them.
+func (b *Bus) HandleWebhook(ctx context.Context, p *webhook.Payload) error {
+	e, err := FromWebhook(p)
+	if errors.Is(err, ErrUnknownEvent) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	return b.Publish(ctx, e)
+}
+
+// PublishMessages publishes the messages received from a watcher, such as
+// chatwork.Client.WatchShard, until the channel is closed. Errors are
+// reported to OnError.
+func (b *Bus) PublishMessages(ctx context.Context, messages <-chan chatwork.RoomMessage) {
+	for m := range messages {
+		if err := b.Publish(ctx, FromMessage(m.RoomID, m.Message)); err != nil && b.OnError != nil {
+			b.OnError(err)
+		}
+	}
+}
@@ -0,0 +1,72 @@
+package eventbus
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/nashirox/chatwork-go"
+	"github.com/nashirox/chatwork-go/webhook"
+)
+
+func TestBus(t *testing.T) {
+	published := make(map[string][]*Event)
+	broker := BrokerFunc(func(ctx context.Context, topic string, data []byte) error {
+		var e Event
+		if err := json.Unmarshal(data, &e); err != nil {
+			return err
+		}
+		published[topic] = append(published[topic], &e)
+		return nil
+	})
+	failing := BrokerFunc(func(ctx context.Context, topic string, data []byte) error {
+		return errors.New("unavailable")
+	})
+	bus := New(broker)
+
+	var mentions []*Event
+	unsubscribe := bus.Subscribe(func(ctx context.Context, e *Event) {
+		mentions = append(mentions, e)
+	}, webhook.EventMentionToMe)
+
+	ctx := context.Background()
+	payload := &webhook.Payload{
+		EventType: webhook.EventMentionToMe,
+		Event:     json.RawMessage(`{"from_account_id":1,"to_account_id":2,"room_id":3,"message_id":"4","body":"[To:2] hi"}`),
+	}
+	if err := bus.HandleWebhook(ctx, payload); err != nil {
+		t.Fatalf("HandleWebhook returned error: %v", err)
+	}
+	if err := bus.HandleWebhook(ctx, &webhook.Payload{EventType: "room_created"}); err != nil {
+		t.Errorf("Expected unknown events to be ignored, got %v", err)
+	}
+	malformed := &webhook.Payload{EventType: webhook.EventMessageCreated, Event: json.RawMessage(`[]`)}
+	if err := bus.HandleWebhook(ctx, malformed); !errors.Is(err, webhook.ErrMalformedEvent) {
+		t.Errorf("Expected ErrMalformedEvent for an undecodable event, got %v", err)
+	}
+
+	messages := make(chan chatwork.RoomMessage, 1)
+	messages <- chatwork.RoomMessage{RoomID: 3, Message: &chatwork.Message{MessageID: "5", Body: "hello"}}
+	close(messages)
+	bus.PublishMessages(ctx, messages)
+
+	if len(mentions) != 1 || mentions[0].AccountID != 1 || mentions[0].ToAccountID != 2 {
+		t.Errorf("Unexpected mentions delivered to the subscriber: %+v", mentions)
+	}
+	if got := published["chatwork.mention_to_me"]; len(got) != 1 || got[0].Source != SourceWebhook {
+		t.Errorf("Unexpected mentions published: %+v", got)
+	}
+	if got := published["chatwork.message_created"]; len(got) != 1 || got[0].MessageID != "5" || got[0].Source != SourceWatch {
+		t.Errorf("Unexpected messages published: %+v", got)
+	}
+
+	unsubscribe()
+	bus = New(failing, broker)
+	if err := bus.HandleWebhook(ctx, payload); err == nil {
+		t.Error("Expected the broker error to be returned")
+	}
+	if len(mentions) != 1 || len(published["chatwork.mention_to_me"]) != 2 {
+		t.Error("Expected the event to reach the other broker only")
+	}
+}
@@ -49,7 +49,7 @@ func ExampleTasksService_Create() {
 	// タスクの作成
 	params := &chatwork.TaskCreateParams{
 		Body:  "プレゼン資料を作成する",
-		ToIDs: []int{123456, 789012},
+		ToIDs: []chatwork.AccountID{123456, 789012},
 	}
 
 	resp, _, err := client.Tasks.Create(ctx, 123456, params)
@@ -0,0 +1,345 @@
+package chatwork
+
+import (
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ExportCompression selects how ExportWriter compresses its output files.
+type ExportCompression int
+
+// Supported export compressions.
+const (
+	ExportCompressionNone ExportCompression = iota
+	ExportCompressionGzip
+)
+
+// ExportWriterOptions configures an ExportWriter.
+type ExportWriterOptions struct {
+	// Directory in which output files are created (required)
+	Dir string
+
+	// File name prefix. Defaults to "export".
+	Prefix string
+
+	// File extension without compression suffix. Defaults to ".jsonl".
+	Extension string
+
+	// Compression applied to each output file
+	Compression ExportCompression
+
+	// Start a new file once this many uncompressed bytes have been written
+	// to the current one. Zero disables size-based rotation.
+	MaxBytes int64
+
+	// Start a new file when the (UTC) date changes
+	RotateDaily bool
+
+	// Clock used for file names and daily rotation. Defaults to time.Now.
+	Now func() time.Time
+}
+
+// ExportWriter is an io.WriteCloser that streams export output into a series
+// of optionally compressed files, rotating them by size or date.
+//
+// Files are named "<prefix>-<YYYYMMDD>-<n><ext>[.gz]". Rotation only happens
+// between calls to Write, so writing one record per call guarantees that no
+// record is split across files. An ExportWriter is safe for concurrent use.
+//
+// On Close, an ArchiveManifest describing the files is written next to them
+// as "<prefix>-manifest.json", so that the archive can later be checked with
+// VerifyArchive.
+//
+// Repeated exports into the same directory, such as those of a daily job,
+// form a single archive: numbering continues after the existing files, and
+// the new files are added to the existing manifest, which keeps its
+// creation time. A manifest of another version is not added to.
+type ExportWriter struct {
+	opts ExportWriterOptions
+
+	mu       sync.Mutex
+	file     *os.File
+	gz       *gzip.Writer
+	out      io.Writer
+	written  int64
+	day      string
+	seq      int
+	files    []string
+	manifest ArchiveManifest
+	current  *ArchiveFile // manifest entry of the current file
+	fileHash hash.Hash    // hash of the current file's bytes on disk
+	lineHash hash.Hash    // hash of the current, unfinished record
+	partial  bool         // whether part of a record has been written
+}
+
+// NewExportWriter creates the output directory if needed and returns an ExportWriter.
+// The first file is created on the first Write.
+func NewExportWriter(opts ExportWriterOptions) (*ExportWriter, error) {
+	if opts.Dir == "" {
+		return nil, fmt.Errorf("chatwork: export directory is required")
+	}
+	if opts.Prefix == "" {
+		opts.Prefix = "export"
+	}
+	if opts.Extension == "" {
+		opts.Extension = ".jsonl"
+	}
+	if opts.Now == nil {
+		opts.Now = time.Now
+	}
+	if err := os.MkdirAll(opts.Dir, 0o750); err != nil {
+		return nil, err
+	}
+
+	w := &ExportWriter{
+		opts: opts,
+		manifest: ArchiveManifest{
+			Version:   ArchiveManifestVersion,
+			CreatedAt: opts.Now().UTC(),
+		},
+	}
+	existing, err := ReadArchiveManifest(w.manifestPath())
+	switch {
+	case err == nil:
+		if existing.Version != ArchiveManifestVersion {
+			return nil, fmt.Errorf("chatwork: cannot add to an archive with manifest version %d", existing.Version)
+		}
+		w.manifest = *existing
+	case !errors.Is(err, fs.ErrNotExist):
+		return nil, err
+	}
+	return w, nil
+}
+
+// Write writes p to the current output file, rotating first if required.
+// Each line of output is one record of the archive.
+func (w *ExportWriter) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.write(p, time.Time{})
+}
+
+// WriteRecord writes v as a JSON line. The record time at, such as a
+// message's send time, is included in the time range of the manifest.
+func (w *ExportWriter) WriteRecord(v interface{}, at time.Time) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	_, err = w.write(append(data, '\n'), at)
+	return err
+}
+
+func (w *ExportWriter) write(p []byte, at time.Time) (int, error) {
+	day := w.opts.Now().UTC().Format("20060102")
+	if w.needsRotation(day) {
+		if err := w.rotate(day); err != nil {
+			return 0, err
+		}
+	}
+
+	n, err := w.out.Write(p)
+	w.written += int64(n)
+	w.track(p[:n], at)
+	return n, err
+}
+
+// track updates the manifest entry of the current file with written data.
+func (w *ExportWriter) track(p []byte, at time.Time) {
+	for len(p) > 0 {
+		i := bytes.IndexByte(p, '\n')
+		if i < 0 {
+			w.lineHash.Write(p)
+			w.partial = true
+			break
+		}
+		w.lineHash.Write(p[:i])
+		w.finishRecord()
+		p = p[i+1:]
+	}
+
+	if at.IsZero() {
+		return
+	}
+	at = at.UTC()
+	if w.current.FirstTime.IsZero() || at.Before(w.current.FirstTime) {
+		w.current.FirstTime = at
+	}
+	if at.After(w.current.LastTime) {
+		w.current.LastTime = at
+	}
+}
+
+func (w *ExportWriter) finishRecord() {
+	w.current.RecordHashes = append(w.current.RecordHashes, hex.EncodeToString(w.lineHash.Sum(nil)))
+	w.current.Records++
+	w.lineHash.Reset()
+	w.partial = false
+}
+
+// Close flushes and closes the current output file and writes the manifest.
+func (w *ExportWriter) Close() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if err := w.closeCurrent(); err != nil {
+		return err
+	}
+	return w.writeManifest()
+}
+
+// Manifest returns the manifest of the files closed so far.
+func (w *ExportWriter) Manifest() ArchiveManifest {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	manifest := w.manifest
+	manifest.Files = append([]ArchiveFile(nil), w.manifest.Files...)
+	return manifest
+}
+
+func (w *ExportWriter) writeManifest() error {
+	data, err := json.MarshalIndent(w.manifest, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	path := w.manifestPath()
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, append(data, '\n'), 0o600); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
+
+func (w *ExportWriter) manifestPath() string {
+	return filepath.Join(w.opts.Dir, w.opts.Prefix+"-manifest.json")
+}
+
+// Files returns the paths of all files created so far, in creation order.
+func (w *ExportWriter) Files() []string {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return append([]string(nil), w.files...)
+}
+
+func (w *ExportWriter) needsRotation(day string) bool {
+	switch {
+	case w.out == nil:
+		return true
+	case w.opts.RotateDaily && day != w.day:
+		return true
+	case w.opts.MaxBytes > 0 && w.written >= w.opts.MaxBytes:
+		return true
+	default:
+		return false
+	}
+}
+
+func (w *ExportWriter) rotate(day string) error {
+	if err := w.closeCurrent(); err != nil {
+		return err
+	}
+
+	if day != w.day {
+		seq, err := w.lastSeq(day)
+		if err != nil {
+			return err
+		}
+		w.day = day
+		w.seq = seq
+	}
+	w.seq++
+
+	name := fmt.Sprintf("%s-%s-%d%s", w.opts.Prefix, day, w.seq, w.opts.Extension)
+	if w.opts.Compression == ExportCompressionGzip {
+		name += ".gz"
+	}
+	path := filepath.Join(w.opts.Dir, name)
+
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_EXCL, 0o600)
+	if err != nil {
+		return err
+	}
+
+	w.file = file
+	w.fileHash = sha256.New()
+	w.lineHash = sha256.New()
+	w.current = &ArchiveFile{Name: name}
+	w.out = io.MultiWriter(file, w.fileHash)
+	if w.opts.Compression == ExportCompressionGzip {
+		w.gz = gzip.NewWriter(w.out)
+		w.out = w.gz
+	}
+	w.written = 0
+	w.files = append(w.files, path)
+
+	return nil
+}
+
+// lastSeq returns the highest sequence number of the files for day that
+// already exist in the directory, or 0 if there are none.
+func (w *ExportWriter) lastSeq(day string) (int, error) {
+	entries, err := os.ReadDir(w.opts.Dir)
+	if err != nil {
+		return 0, err
+	}
+
+	last := 0
+	for _, entry := range entries {
+		rest, ok := strings.CutPrefix(entry.Name(), w.opts.Prefix+"-"+day+"-")
+		if !ok {
+			continue
+		}
+		rest = strings.TrimSuffix(rest, ".gz")
+		rest, ok = strings.CutSuffix(rest, w.opts.Extension)
+		if !ok {
+			continue
+		}
+		if seq, err := strconv.Atoi(rest); err == nil && seq > last {
+			last = seq
+		}
+	}
+	return last, nil
+}
+
+func (w *ExportWriter) closeCurrent() error {
+	if w.file == nil {
+		return nil
+	}
+
+	var err error
+	if w.gz != nil {
+		err = w.gz.Close()
+	}
+	if closeErr := w.file.Close(); err == nil {
+		err = closeErr
+	}
+
+	// A final record without a trailing newline still counts.
+	if w.partial {
+		w.finishRecord()
+	}
+	w.current.SHA256 = hex.EncodeToString(w.fileHash.Sum(nil))
+	w.manifest.Files = append(w.manifest.Files, *w.current)
+
+	w.file, w.gz, w.out, w.current = nil, nil, nil, nil
+	return err
+}
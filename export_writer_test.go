@@ -0,0 +1,174 @@
+package chatwork
+
+import (
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestExportWriter_RotatesAndCompresses(t *testing.T) {
+	now := time.Date(2024, 1, 1, 23, 0, 0, 0, time.UTC)
+	w, err := NewExportWriter(ExportWriterOptions{
+		Dir:         t.TempDir(),
+		Compression: ExportCompressionGzip,
+		MaxBytes:    10,
+		RotateDaily: true,
+		Now:         func() time.Time { return now },
+	})
+	if err != nil {
+		t.Fatalf("NewExportWriter returned error: %v", err)
+	}
+
+	w.Write([]byte("record-1\n"))
+	w.Write([]byte("record-2\n")) // still under MaxBytes before this write
+	w.Write([]byte("record-3\n")) // rotates by size
+	now = now.Add(2 * time.Hour)
+	w.Write([]byte("record-4\n")) // rotates by date
+	if err := w.Close(); err != nil {
+		t.Fatalf("Close returned error: %v", err)
+	}
+
+	files := w.Files()
+	want := []string{"export-20240101-1.jsonl.gz", "export-20240101-2.jsonl.gz", "export-20240102-1.jsonl.gz"}
+	if len(files) != len(want) {
+		t.Fatalf("Expected files %v, got %v", want, files)
+	}
+	for i, path := range files {
+		if filepath.Base(path) != want[i] {
+			t.Errorf("Expected file %s, got %s", want[i], filepath.Base(path))
+		}
+	}
+
+	f, err := os.Open(files[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	gz, err := gzip.NewReader(f)
+	if err != nil {
+		t.Fatalf("Output is not gzip-compressed: %v", err)
+	}
+	data, _ := io.ReadAll(gz)
+	if string(data) != "record-1\nrecord-2\n" {
+		t.Errorf("Unexpected first file contents %q", data)
+	}
+}
+
+func TestExportWriter_ContinuesExistingExport(t *testing.T) {
+	dir := t.TempDir()
+	created := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	clock := created
+	now := func() time.Time { return clock }
+	export := func(record string) *ExportWriter {
+		w, err := NewExportWriter(ExportWriterOptions{Dir: dir, Now: now})
+		if err != nil {
+			t.Fatalf("NewExportWriter returned error: %v", err)
+		}
+		if _, err := w.Write([]byte(record + "\n")); err != nil {
+			t.Fatalf("Write returned error: %v", err)
+		}
+		if err := w.Close(); err != nil {
+			t.Fatalf("Close returned error: %v", err)
+		}
+		return w
+	}
+
+	export("first run")
+	clock = clock.Add(time.Hour)
+	second := export("second run")
+	if files := second.Files(); len(files) != 1 || filepath.Base(files[0]) != "export-20240101-2.jsonl" {
+		t.Errorf("Expected the second run to continue numbering, got %v", files)
+	}
+
+	manifest, err := VerifyArchive(filepath.Join(dir, "export-manifest.json"))
+	if err != nil {
+		t.Fatalf("VerifyArchive returned error: %v", err)
+	}
+	if len(manifest.Files) != 2 || manifest.Records() != 2 {
+		t.Errorf("Expected the manifest to list both runs, got %+v", manifest.Files)
+	}
+	if !manifest.CreatedAt.Equal(created) {
+		t.Errorf("Expected the manifest to keep its creation time %v, got %v", created, manifest.CreatedAt)
+	}
+
+	if err := os.WriteFile(filepath.Join(dir, "export-manifest.json"), []byte(`{"version": 2}`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := NewExportWriter(ExportWriterOptions{Dir: dir, Now: now}); err == nil {
+		t.Error("Expected a manifest of another version to be rejected")
+	}
+}
+
+func TestVerifyArchive(t *testing.T) {
+	dir := t.TempDir()
+	w, err := NewExportWriter(ExportWriterOptions{
+		Dir:         dir,
+		Compression: ExportCompressionGzip,
+		MaxBytes:    40,
+	})
+	if err != nil {
+		t.Fatalf("NewExportWriter returned error: %v", err)
+	}
+
+	sent := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	for i := 0; i < 5; i++ {
+		message := &Message{MessageID: MessageID(fmt.Sprint(i + 1)), Body: "hello"}
+		if err := w.WriteRecord(message, sent.Add(time.Duration(i)*time.Minute)); err != nil {
+			t.Fatalf("WriteRecord returned error: %v", err)
+		}
+	}
+	if err := w.Close(); err != nil {
+		t.Fatalf("Close returned error: %v", err)
+	}
+
+	path := filepath.Join(dir, "export-manifest.json")
+	manifest, err := VerifyArchive(path)
+	if err != nil {
+		t.Fatalf("VerifyArchive returned error: %v", err)
+	}
+	if manifest.Records() != 5 || len(manifest.Files) < 2 {
+		t.Errorf("Expected 5 records in several files, got %d in %d", manifest.Records(), len(manifest.Files))
+	}
+	if !manifest.Files[0].FirstTime.Equal(sent) {
+		t.Errorf("Expected the first record time %v, got %v", sent, manifest.Files[0].FirstTime)
+	}
+
+	// Truncate the last file.
+	last := filepath.Join(dir, manifest.Files[len(manifest.Files)-1].Name)
+	data, err := os.ReadFile(last)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(last, data[:len(data)/2], 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := VerifyArchive(path); !errors.Is(err, ErrArchiveCorrupt) {
+		t.Errorf("Expected ErrArchiveCorrupt for a truncated file, got %v", err)
+	}
+}
+
+func TestVerifyArchive_ModifiedRecord(t *testing.T) {
+	dir := t.TempDir()
+	w, err := NewExportWriter(ExportWriterOptions{Dir: dir})
+	if err != nil {
+		t.Fatalf("NewExportWriter returned error: %v", err)
+	}
+	w.Write([]byte("record-1\nrecord-2\n"))
+	w.Close()
+
+	name := w.Files()[0]
+	if err := os.WriteFile(name, []byte("record-1\nrecord-X\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = VerifyArchive(filepath.Join(dir, "export-manifest.json"))
+	if !errors.Is(err, ErrArchiveCorrupt) || !strings.Contains(err.Error(), "record 2") {
+		t.Errorf("Expected record 2 to be reported, got %v", err)
+	}
+}
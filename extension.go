@@ -0,0 +1,117 @@
+package chatwork
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+)
+
+// ErrUnknownExtension is returned by ExtensionOf for names that no package
+// has registered.
+var ErrUnknownExtension = errors.New("chatwork: unknown extension")
+
+// ExtensionFactory creates the instance of an extension for a client.
+//
+// Extensions send their requests with the client's NewRequest and Do, or
+// the generic Do, so that they share its HTTP client, authentication,
+// rate limiting, retries, middlewares, and hooks.
+type ExtensionFactory func(c *Client) any
+
+var (
+	extensionsMu sync.RWMutex
+	extensions   = make(map[string]ExtensionFactory)
+)
+
+// RegisterExtension makes an extension, typically a service for endpoints
+// this package does not cover, available to every client under name.
+// It is meant to be called from the init function of the package that
+// provides the extension:
+//
+//	func init() {
+//		chatwork.RegisterExtension("example.com/audit", func(c *chatwork.Client) any {
+//			return &AuditService{client: c}
+//		})
+//	}
+//
+// RegisterExtension panics if name is empty, factory is nil, or name is
+// already registered.
+func RegisterExtension(name string, factory ExtensionFactory) {
+	extensionsMu.Lock()
+	defer extensionsMu.Unlock()
+
+	if name == "" {
+		panic("chatwork: RegisterExtension with empty name")
+	}
+	if factory == nil {
+		panic("chatwork: RegisterExtension with nil factory for " + name)
+	}
+	if _, dup := extensions[name]; dup {
+		panic("chatwork: RegisterExtension called twice for " + name)
+	}
+	extensions[name] = factory
+}
+
+// Extensions returns the names of the registered extensions, sorted.
+func Extensions() []string {
+	extensionsMu.RLock()
+	defer extensionsMu.RUnlock()
+
+	names := make([]string, 0, len(extensions))
+	for name := range extensions {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// extensionSet holds the extension instances of a client.
+type extensionSet struct {
+	mu        sync.Mutex
+	instances map[string]any
+}
+
+// Extension returns the instance of the extension registered under name
+// for c, or nil if there is none. The instance is created on first use and
+// reused afterwards; clients derived with WithPermissions get their own.
+// Callers assert the type they expect:
+//
+//	audit := client.Extension("example.com/audit").(*audit.Service)
+//
+// ExtensionOf does the same and reports a failed assertion as an error.
+func (c *Client) Extension(name string) any {
+	extensionsMu.RLock()
+	factory := extensions[name]
+	extensionsMu.RUnlock()
+	if factory == nil {
+		return nil
+	}
+
+	c.extensions.mu.Lock()
+	defer c.extensions.mu.Unlock()
+	if instance, ok := c.extensions.instances[name]; ok {
+		return instance
+	}
+	if c.extensions.instances == nil {
+		c.extensions.instances = make(map[string]any)
+	}
+	instance := factory(c)
+	c.extensions.instances[name] = instance
+	return instance
+}
+
+// ExtensionOf returns the instance of the extension registered under name
+// for c as a T. The error wraps ErrUnknownExtension if no extension is
+// registered under name.
+func ExtensionOf[T any](c *Client, name string) (T, error) {
+	var zero T
+	instance := c.Extension(name)
+	if instance == nil {
+		return zero, fmt.Errorf("%w: %s", ErrUnknownExtension, name)
+	}
+	t, ok := instance.(T)
+	if !ok {
+		return zero, fmt.Errorf("chatwork: extension %s is %T, not %T", name, instance, zero)
+	}
+	return t, nil
+}
@@ -0,0 +1,75 @@
+package chatwork_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/nashirox/chatwork-go"
+	"github.com/nashirox/chatwork-go/chatworktest"
+)
+
+// auditService is a third-party service for an endpoint the package does
+// not cover.
+type auditService struct {
+	client *chatwork.Client
+}
+
+func (s *auditService) Me(ctx context.Context) (*chatwork.Me, error) {
+	req, err := s.client.NewRequest(http.MethodGet, "me", nil)
+	if err != nil {
+		return nil, err
+	}
+	me, _, err := chatwork.Do[chatwork.Me](ctx, s.client, req)
+	return me, err
+}
+
+func init() {
+	chatwork.RegisterExtension("chatwork-go/test-audit", func(c *chatwork.Client) any {
+		return &auditService{client: c}
+	})
+}
+
+func TestClient_Extension(t *testing.T) {
+	srv := chatworktest.NewServer()
+	defer srv.Close()
+	srv.SetMe(chatwork.Me{AccountID: 1, Name: "Bot"})
+
+	var sent int
+	client := srv.Client(chatwork.OptionOnRequest(func(*http.Request) { sent++ }))
+
+	audit, err := chatwork.ExtensionOf[*auditService](client, "chatwork-go/test-audit")
+	if err != nil {
+		t.Fatalf("ExtensionOf returned error: %v", err)
+	}
+	if client.Extension("chatwork-go/test-audit") != audit {
+		t.Error("Expected the extension to be created once per client")
+	}
+	me, err := audit.Me(context.Background())
+	if err != nil || me.Name != "Bot" {
+		t.Fatalf("Expected the extension to call the API, got %v, %v", me, err)
+	}
+	if sent != 1 {
+		t.Errorf("Expected the extension to use the client's hooks, got %d requests", sent)
+	}
+
+	derived := client.WithPermissions(chatwork.Permissions{DisabledServices: []string{"Me"}})
+	derivedAudit := derived.Extension("chatwork-go/test-audit").(*auditService)
+	if derivedAudit == audit {
+		t.Error("Expected derived clients to get their own extension")
+	}
+	if _, err := derivedAudit.Me(context.Background()); !errors.Is(err, chatwork.ErrPermissionDenied) {
+		t.Errorf("Expected the derived client's permissions to apply, got %v", err)
+	}
+
+	if client.Extension("chatwork-go/missing") != nil {
+		t.Error("Expected nil for an unregistered extension")
+	}
+	if _, err := chatwork.ExtensionOf[*auditService](client, "chatwork-go/missing"); !errors.Is(err, chatwork.ErrUnknownExtension) {
+		t.Errorf("Expected ErrUnknownExtension, got %v", err)
+	}
+	if _, err := chatwork.ExtensionOf[*chatwork.Client](client, "chatwork-go/test-audit"); err == nil {
+		t.Error("Expected an error for the wrong type")
+	}
+}
@@ -0,0 +1,322 @@
+package chatwork
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/textproto"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// FileCategory is a coarse classification of a file based on its name.
+type FileCategory string
+
+// File categories returned by File.ContentCategory.
+const (
+	FileCategoryImage    FileCategory = "image"
+	FileCategoryDocument FileCategory = "document"
+	FileCategoryArchive  FileCategory = "archive"
+	FileCategoryOther    FileCategory = "other"
+)
+
+// fileCategories maps lower-cased file extensions to their category.
+var fileCategories = map[string]FileCategory{
+	".png":  FileCategoryImage,
+	".jpg":  FileCategoryImage,
+	".jpeg": FileCategoryImage,
+	".gif":  FileCategoryImage,
+	".bmp":  FileCategoryImage,
+	".webp": FileCategoryImage,
+	".svg":  FileCategoryImage,
+	".heic": FileCategoryImage,
+	".tif":  FileCategoryImage,
+	".tiff": FileCategoryImage,
+
+	".pdf":  FileCategoryDocument,
+	".doc":  FileCategoryDocument,
+	".docx": FileCategoryDocument,
+	".xls":  FileCategoryDocument,
+	".xlsx": FileCategoryDocument,
+	".ppt":  FileCategoryDocument,
+	".pptx": FileCategoryDocument,
+	".odt":  FileCategoryDocument,
+	".ods":  FileCategoryDocument,
+	".odp":  FileCategoryDocument,
+	".txt":  FileCategoryDocument,
+	".md":   FileCategoryDocument,
+	".csv":  FileCategoryDocument,
+	".rtf":  FileCategoryDocument,
+
+	".zip": FileCategoryArchive,
+	".gz":  FileCategoryArchive,
+	".tgz": FileCategoryArchive,
+	".tar": FileCategoryArchive,
+	".bz2": FileCategoryArchive,
+	".xz":  FileCategoryArchive,
+	".7z":  FileCategoryArchive,
+	".rar": FileCategoryArchive,
+	".lzh": FileCategoryArchive,
+}
+
+// Extension returns the lower-cased extension of the file name, including the dot.
+func (f *File) Extension() string {
+	return strings.ToLower(path.Ext(f.Filename))
+}
+
+// MIMEType returns the MIME type associated with the file extension,
+// or "application/octet-stream" if it is unknown.
+func (f *File) MIMEType() string {
+	if t := mime.TypeByExtension(f.Extension()); t != "" {
+		return t
+	}
+	return "application/octet-stream"
+}
+
+// ContentCategory classifies the file as an image, document, archive, or other
+// file based on its extension.
+func (f *File) ContentCategory() FileCategory {
+	if category, ok := fileCategories[f.Extension()]; ok {
+		return category
+	}
+	if strings.HasPrefix(f.MIMEType(), "image/") {
+		return FileCategoryImage
+	}
+	return FileCategoryOther
+}
+
+// IsImage reports whether the file is an image.
+func (f *File) IsImage() bool {
+	return f.ContentCategory() == FileCategoryImage
+}
+
+// HumanSize returns the file size in a human-readable form such as "1.5 MB".
+// Sizes use binary multiples (1 KB = 1024 bytes).
+func (f *File) HumanSize() string {
+	const unit = 1024
+	size := f.Filesize
+	if size < unit {
+		return fmt.Sprintf("%d B", size)
+	}
+
+	div, exp := int64(unit), 0
+	for n := int64(size) / unit; n >= unit && exp < 4; n /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %cB", float64(size)/float64(div), "KMGTP"[exp])
+}
+
+// multipartUpload is the body of a RoomsService.UploadFile request. A
+// goroutine writes the multipart form into a pipe as the body is read, so
+// the file contents are never held in memory.
+type multipartUpload struct {
+	*io.PipeReader
+	contentType string
+	done        chan struct{}
+}
+
+func newMultipartUpload(filename string, content io.Reader, params *FileUploadParams) *multipartUpload {
+	pr, pw := io.Pipe()
+	mw := multipart.NewWriter(pw)
+	upload := &multipartUpload{
+		PipeReader:  pr,
+		contentType: mw.FormDataContentType(),
+		done:        make(chan struct{}),
+	}
+
+	go func() {
+		defer close(upload.done)
+		pw.CloseWithError(writeMultipartFile(mw, filename, content, params))
+	}()
+	return upload
+}
+
+// wait closes the body, in case it was not sent, and waits until content is
+// no longer read.
+func (u *multipartUpload) wait() {
+	u.Close()
+	<-u.done
+}
+
+// quoteEscaper escapes values of Content-Disposition parameters.
+var quoteEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`)
+
+// writeMultipartFile writes the fields of an upload to mw, followed by
+// content as the "file" part.
+func writeMultipartFile(mw *multipart.Writer, filename string, content io.Reader, params *FileUploadParams) error {
+	if params.Message != "" {
+		if err := mw.WriteField("message", params.Message); err != nil {
+			return err
+		}
+	}
+
+	contentType := params.ContentType
+	if contentType == "" {
+		contentType = (&File{Filename: filename}).MIMEType()
+	}
+	header := make(textproto.MIMEHeader)
+	header.Set("Content-Disposition", fmt.Sprintf(`form-data; name="file"; filename="%s"`, quoteEscaper.Replace(filename)))
+	header.Set("Content-Type", contentType)
+	part, err := mw.CreatePart(header)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(part, content); err != nil {
+		return err
+	}
+	return mw.Close()
+}
+
+// GetFilesByCategory returns the files in a room that belong to one of the given categories.
+//
+// If accountID is specified (non-zero), only files uploaded by that user are considered.
+// This fetches the file list and filters it locally.
+func (s *RoomsService) GetFilesByCategory(ctx context.Context, roomID RoomID, accountID AccountID, categories []FileCategory, opts ...RequestOption) ([]*File, *Response, error) {
+	allFiles, resp, err := s.GetFiles(ctx, roomID, accountID, opts...)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	var files []*File
+	for _, file := range allFiles {
+		for _, category := range categories {
+			if file.ContentCategory() == category {
+				files = append(files, file)
+				break
+			}
+		}
+	}
+
+	return files, resp, nil
+}
+
+// GetImages returns the image files in a room.
+//
+// This is a convenience method that calls GetFilesByCategory with FileCategoryImage.
+func (s *RoomsService) GetImages(ctx context.Context, roomID RoomID, accountID AccountID, opts ...RequestOption) ([]*File, *Response, error) {
+	return s.GetFilesByCategory(ctx, roomID, accountID, []FileCategory{FileCategoryImage}, opts...)
+}
+
+// FileIterParams represents optional parameters for iterating over room files.
+type FileIterParams struct {
+	// Only iterate files uploaded by this account (non-zero)
+	AccountID AccountID
+
+	// Resume after the position returned by a previous FileIterator.Token
+	StartToken string
+}
+
+// FileIterator yields the files of a room one at a time.
+//
+// Files are visited in ascending FileID order. The position after the most
+// recently returned file is available from Token, so a job that is restarted
+// can continue where it stopped by passing the token as StartToken.
+//
+// Example:
+//
+//	it := client.Rooms.IterFiles(ctx, roomID, &chatwork.FileIterParams{StartToken: saved})
+//	for it.Next() {
+//		archive(it.File())
+//		saved = it.Token()
+//	}
+//	if err := it.Err(); err != nil {
+//		log.Fatal(err)
+//	}
+type FileIterator struct {
+	rooms  *RoomsService
+	ctx    context.Context
+	roomID RoomID
+	params FileIterParams
+	opts   []RequestOption
+
+	fetched bool
+	files   []*File
+	current *File
+	after   int
+	err     error
+}
+
+// IterFiles returns an iterator over the files in a room.
+//
+// Nothing is fetched until the first call to Next.
+func (s *RoomsService) IterFiles(ctx context.Context, roomID RoomID, params *FileIterParams, opts ...RequestOption) *FileIterator {
+	it := &FileIterator{
+		rooms:  s,
+		ctx:    ctx,
+		roomID: roomID,
+		opts:   opts,
+	}
+	if params != nil {
+		it.params = *params
+	}
+	if it.params.StartToken != "" {
+		it.after, it.err = decodeFileToken(it.params.StartToken)
+	}
+	return it
+}
+
+// Next advances to the next file and reports whether there is one.
+func (it *FileIterator) Next() bool {
+	if it.err != nil {
+		return false
+	}
+
+	if !it.fetched {
+		it.fetched = true
+		files, _, err := it.rooms.GetFiles(it.ctx, it.roomID, it.params.AccountID, it.opts...)
+		if err != nil {
+			it.err = err
+			return false
+		}
+		sort.Slice(files, func(i, j int) bool { return files[i].FileID < files[j].FileID })
+		for len(files) > 0 && files[0].FileID <= it.after {
+			files = files[1:]
+		}
+		it.files = files
+	}
+
+	if len(it.files) == 0 {
+		it.current = nil
+		return false
+	}
+
+	it.current = it.files[0]
+	it.files = it.files[1:]
+	it.after = it.current.FileID
+	return true
+}
+
+// File returns the file at the current position.
+func (it *FileIterator) File() *File {
+	return it.current
+}
+
+// Err returns the error that stopped the iteration, if any.
+func (it *FileIterator) Err() error {
+	return it.err
+}
+
+// Token returns an opaque position token for resuming after the current file.
+func (it *FileIterator) Token() string {
+	return base64.RawURLEncoding.EncodeToString([]byte("files:" + strconv.Itoa(it.after)))
+}
+
+// decodeFileToken parses a token produced by FileIterator.Token.
+func decodeFileToken(token string) (int, error) {
+	data, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil || !strings.HasPrefix(string(data), "files:") {
+		return 0, fmt.Errorf("chatwork: invalid file iterator token %q", token)
+	}
+
+	after, err := strconv.Atoi(strings.TrimPrefix(string(data), "files:"))
+	if err != nil {
+		return 0, fmt.Errorf("chatwork: invalid file iterator token %q", token)
+	}
+	return after, nil
+}
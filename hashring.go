@@ -0,0 +1,228 @@
+package chatwork
+
+import (
+	"context"
+	"hash/fnv"
+	"sort"
+	"strconv"
+	"sync"
+)
+
+// defaultRingReplicas is the number of points each node has on a HashRing.
+const defaultRingReplicas = 128
+
+// HashRing assigns rooms to nodes, such as the instances of a bot, by
+// consistent hashing of room IDs, so that each instance polls only its share
+// of the rooms. When a node joins or leaves, only the rooms of that node
+// move.
+//
+// Membership is managed by the application, for example from service
+// discovery, by calling Add and Remove. A HashRing is safe for concurrent use.
+type HashRing struct {
+	mu      sync.RWMutex
+	nodes   map[string]bool
+	points  []ringPoint
+	changed chan struct{}
+}
+
+type ringPoint struct {
+	hash uint64
+	node string
+}
+
+// NewHashRing returns a HashRing with the given nodes.
+func NewHashRing(nodes ...string) *HashRing {
+	r := &HashRing{
+		nodes:   make(map[string]bool),
+		changed: make(chan struct{}),
+	}
+	for _, node := range nodes {
+		r.nodes[node] = true
+	}
+	r.rebuild()
+	return r
+}
+
+// Add adds a node to the ring.
+func (r *HashRing) Add(node string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if !r.nodes[node] {
+		r.nodes[node] = true
+		r.rebuild()
+		r.notify()
+	}
+}
+
+// Remove removes a node from the ring.
+func (r *HashRing) Remove(node string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.nodes[node] {
+		delete(r.nodes, node)
+		r.rebuild()
+		r.notify()
+	}
+}
+
+// Nodes returns the nodes of the ring in ascending order.
+func (r *HashRing) Nodes() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	nodes := make([]string, 0, len(r.nodes))
+	for node := range r.nodes {
+		nodes = append(nodes, node)
+	}
+	sort.Strings(nodes)
+	return nodes
+}
+
+// Owner returns the node that roomID is assigned to, or "" if the ring is empty.
+func (r *HashRing) Owner(roomID RoomID) string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.owner(roomID)
+}
+
+// Rooms returns the rooms among roomIDs that are assigned to node.
+func (r *HashRing) Rooms(node string, roomIDs []RoomID) []RoomID {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var owned []RoomID
+	for _, roomID := range roomIDs {
+		if r.owner(roomID) == node {
+			owned = append(owned, roomID)
+		}
+	}
+	return owned
+}
+
+// Changed returns a channel that is closed at the next change of membership.
+func (r *HashRing) Changed() <-chan struct{} {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.changed
+}
+
+func (r *HashRing) owner(roomID RoomID) string {
+	if len(r.points) == 0 {
+		return ""
+	}
+	h := ringHash(roomID.String())
+	i := sort.Search(len(r.points), func(i int) bool { return r.points[i].hash >= h })
+	if i == len(r.points) {
+		i = 0
+	}
+	return r.points[i].node
+}
+
+func (r *HashRing) rebuild() {
+	r.points = r.points[:0]
+	for node := range r.nodes {
+		for i := 0; i < defaultRingReplicas; i++ {
+			r.points = append(r.points, ringPoint{hash: ringHash(node + "#" + strconv.Itoa(i)), node: node})
+		}
+	}
+	sort.Slice(r.points, func(i, j int) bool {
+		if r.points[i].hash != r.points[j].hash {
+			return r.points[i].hash < r.points[j].hash
+		}
+		return r.points[i].node < r.points[j].node
+	})
+}
+
+func (r *HashRing) notify() {
+	close(r.changed)
+	r.changed = make(chan struct{})
+}
+
+// ringHash hashes key with FNV-1a, followed by the MurmurHash3 finalizer to
+// spread similar keys, such as consecutive room IDs, over the ring.
+func ringHash(key string) uint64 {
+	h := fnv.New64a()
+	h.Write([]byte(key))
+	x := h.Sum64()
+	x ^= x >> 33
+	x *= 0xff51afd7ed558ccd
+	x ^= x >> 33
+	x *= 0xc4ceb9fe1a85ec53
+	x ^= x >> 33
+	return x
+}
+
+// RoomMessage is a message together with the room it was posted to.
+type RoomMessage struct {
+	RoomID  RoomID
+	Message *Message
+}
+
+// WatchShard watches the rooms among roomIDs that ring assigns to node, as
+// with Watch, and sends their messages on the returned channel. When the
+// membership of ring changes, rooms that moved to other nodes are no longer
+// watched and rooms that moved to node are watched from then on.
+//
+// A room that moves starts with the messages posted after its first poll by
+// the new node, so messages posted during the handover may be missed.
+//
+// The channel is closed when ctx is done.
+func (c *Client) WatchShard(ctx context.Context, ring *HashRing, node string, roomIDs []RoomID, opts *WatchOptions) <-chan RoomMessage {
+	out := make(chan RoomMessage)
+
+	go func() {
+		var wg sync.WaitGroup
+		defer func() {
+			wg.Wait()
+			close(out)
+		}()
+
+		watching := make(map[RoomID]context.CancelFunc)
+		defer func() {
+			for _, cancel := range watching {
+				cancel()
+			}
+		}()
+
+		for {
+			changed := ring.Changed()
+			owned := make(map[RoomID]bool)
+			for _, roomID := range ring.Rooms(node, roomIDs) {
+				owned[roomID] = true
+			}
+
+			for roomID, cancel := range watching {
+				if !owned[roomID] {
+					cancel()
+					delete(watching, roomID)
+				}
+			}
+			for roomID := range owned {
+				if _, ok := watching[roomID]; ok {
+					continue
+				}
+				roomCtx, cancel := context.WithCancel(ctx)
+				watching[roomID] = cancel
+
+				wg.Add(1)
+				go func(roomID RoomID, messages <-chan *Message) {
+					defer wg.Done()
+					for m := range messages {
+						select {
+						case out <- RoomMessage{RoomID: roomID, Message: m}:
+						case <-roomCtx.Done():
+						}
+					}
+				}(roomID, c.Watch(roomCtx, roomID, opts))
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-changed:
+			}
+		}
+	}()
+
+	return out
+}
@@ -0,0 +1,52 @@
+package chatwork
+
+import "testing"
+
+func TestHashRing(t *testing.T) {
+	roomIDs := make([]RoomID, 1000)
+	for i := range roomIDs {
+		roomIDs[i] = RoomID(100000 + i)
+	}
+
+	ring := NewHashRing("a", "b", "c")
+	before := make(map[RoomID]string)
+	total := 0
+	for _, node := range ring.Nodes() {
+		rooms := ring.Rooms(node, roomIDs)
+		if len(rooms) < 200 || len(rooms) > 466 {
+			t.Errorf("Expected node %s to own about a third of the rooms, got %d", node, len(rooms))
+		}
+		total += len(rooms)
+		for _, roomID := range rooms {
+			before[roomID] = node
+		}
+	}
+	if total != len(roomIDs) {
+		t.Errorf("Expected every room to be assigned once, got %d assignments", total)
+	}
+
+	changed := ring.Changed()
+	ring.Add("d")
+	select {
+	case <-changed:
+	default:
+		t.Error("Expected Changed to be closed after Add")
+	}
+
+	for _, roomID := range roomIDs {
+		if owner := ring.Owner(roomID); owner != before[roomID] && owner != "d" {
+			t.Errorf("Room %d moved from %s to %s instead of the new node", roomID, before[roomID], owner)
+		}
+	}
+
+	ring.Remove("d")
+	for _, roomID := range roomIDs {
+		if owner := ring.Owner(roomID); owner != before[roomID] {
+			t.Errorf("Room %d did not return to %s after removal, got %s", roomID, before[roomID], owner)
+		}
+	}
+
+	if NewHashRing().Owner(1) != "" {
+		t.Error("Expected an empty ring to have no owners")
+	}
+}
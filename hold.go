@@ -0,0 +1,404 @@
+package chatwork
+
+import (
+	"bufio"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"sync"
+	"time"
+)
+
+// ErrHoldChainBroken is returned when held records are missing, reordered,
+// or do not match their integrity hashes.
+var ErrHoldChainBroken = errors.New("chatwork: hold record chain is broken")
+
+const (
+	defaultHoldInterval = time.Minute
+
+	// maxListedMessages is the maximum number of messages returned by Messages.List.
+	maxListedMessages = 100
+)
+
+// HoldRecordKind identifies what a HoldRecord contains.
+type HoldRecordKind string
+
+// Kinds of held records.
+const (
+	HoldRecordMessage HoldRecordKind = "message"
+	HoldRecordFile    HoldRecordKind = "file"
+	HoldRecordGap     HoldRecordKind = "gap"
+)
+
+// HoldRecord is a single entry archived by a Hold.
+//
+// Records form a hash chain: Hash covers the record's content together with
+// PrevHash, the hash of the preceding record. Removing, altering, or
+// reordering records therefore breaks the chain, which VerifyHoldRecords
+// detects.
+type HoldRecord struct {
+	Seq        int64          `json:"seq"`
+	Kind       HoldRecordKind `json:"kind"`
+	RoomID     RoomID         `json:"room_id"`
+	CapturedAt time.Time      `json:"captured_at"`
+	Message    *Message       `json:"message,omitempty"`
+	File       *File          `json:"file,omitempty"`
+	Gap        *HoldGap       `json:"gap,omitempty"`
+	PrevHash   string         `json:"prev_hash"`
+	Hash       string         `json:"hash"`
+}
+
+// HoldGap describes messages that may have been missed, because more
+// messages were posted to a room between two polls than the API returns.
+type HoldGap struct {
+	RoomID RoomID `json:"room_id"`
+
+	// Last message archived before the gap
+	AfterMessageID MessageID `json:"after_message_id"`
+
+	// First message archived after the gap
+	BeforeMessageID MessageID `json:"before_message_id"`
+}
+
+// computeHash returns the integrity hash of r.
+func (r *HoldRecord) computeHash() (string, error) {
+	unhashed := *r
+	unhashed.Hash = ""
+	data, err := json.Marshal(unhashed)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// HoldSink stores held records. Implementations must be write-once: records
+// are only ever appended and never modified or removed.
+type HoldSink interface {
+	Append(ctx context.Context, record *HoldRecord) error
+}
+
+// Hold continuously archives new messages and file metadata of designated
+// rooms, for organizations with retention or litigation-hold obligations.
+//
+// Every record is chained to the previous one with a SHA-256 hash. Because
+// the API only lists the latest 100 messages of a room, a room that receives
+// more messages than that between two polls cannot be archived completely;
+// such gaps are written to the sink as HoldRecordGap records and reported to
+// OnGap, so they are documented rather than silently lost.
+type Hold struct {
+	client *Client
+	sink   HoldSink
+	rooms  []RoomID
+
+	// Time between polls in Run. Defaults to one minute.
+	Interval time.Duration
+
+	// Called for every detected gap (optional)
+	OnGap func(HoldGap)
+
+	// Called with errors from polls in Run. If nil, Run returns the first error.
+	OnError func(error)
+
+	mu       sync.Mutex
+	seq      int64
+	lastHash string
+	messages map[RoomID]MessageID // newest archived message ID per room
+	files    map[RoomID]int       // newest archived file ID per room
+	updated  Timestamp            // latest update time of the rooms archived completely
+	failed   map[RoomID]bool      // rooms whose previous poll failed
+}
+
+// NewHold returns a Hold that archives the rooms with the given IDs to sink.
+func NewHold(client *Client, sink HoldSink, roomIDs ...RoomID) *Hold {
+	return &Hold{
+		client:   client,
+		sink:     sink,
+		rooms:    roomIDs,
+		Interval: defaultHoldInterval,
+		messages: make(map[RoomID]MessageID),
+		files:    make(map[RoomID]int),
+		failed:   make(map[RoomID]bool),
+	}
+}
+
+// Resume continues the record chain from records previously written by a
+// Hold, such as those returned by OpenHoldFile. It verifies the records
+// first, and afterwards only data newer than what they contain is archived.
+func (h *Hold) Resume(records []*HoldRecord) error {
+	if err := VerifyHoldRecords(records); err != nil {
+		return err
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	for _, r := range records {
+		h.seq = r.Seq
+		h.lastHash = r.Hash
+		switch {
+		case r.Message != nil && compareMessageIDs(r.Message.MessageID, h.messages[r.RoomID]) > 0:
+			h.messages[r.RoomID] = r.Message.MessageID
+		case r.File != nil && r.File.FileID > h.files[r.RoomID]:
+			h.files[r.RoomID] = r.File.FileID
+		}
+	}
+	return nil
+}
+
+// Run polls the rooms every Interval until ctx is done.
+func (h *Hold) Run(ctx context.Context, opts ...RequestOption) error {
+	interval := h.Interval
+	if interval <= 0 {
+		interval = defaultHoldInterval
+	}
+
+	for {
+		if err := h.Poll(ctx, opts...); err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			if h.OnError == nil {
+				return err
+			}
+			h.OnError(err)
+		}
+		if err := sleepContext(ctx, interval); err != nil {
+			return err
+		}
+	}
+}
+
+// Poll archives the messages and files added to the rooms since the last poll.
+//
+// The rooms are listed first, and rooms that were not updated since the
+// previous poll are skipped (see RoomsService.ChangedRooms). If the rooms
+// cannot be listed, every room is polled.
+//
+// API errors in one room do not prevent the others from being archived; they
+// are joined into the returned error. A failure to write to the sink stops
+// the poll, since the chain cannot continue past a record that was not stored.
+func (h *Hold) Poll(ctx context.Context, opts ...RequestOption) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	// Rooms that are not listed, such as rooms the account has left, are
+	// polled anyway so that their errors are reported.
+	var listed map[RoomID]*Room
+	if rooms, _, err := h.client.Rooms.List(ctx, opts...); err == nil {
+		listed = make(map[RoomID]*Room, len(rooms))
+		for _, room := range rooms {
+			listed[room.RoomID] = room
+		}
+	}
+
+	updated := h.updated
+	var errs []error
+	for _, roomID := range h.rooms {
+		room, ok := listed[roomID]
+		if ok && h.updated > 0 && room.LastUpdateTime < h.updated && !h.failed[roomID] {
+			continue
+		}
+
+		err := h.pollRoom(ctx, roomID, opts)
+		var sinkErr *holdSinkError
+		if errors.As(err, &sinkErr) {
+			return sinkErr.err
+		}
+		if err != nil {
+			h.failed[roomID] = true
+			errs = append(errs, fmt.Errorf("room %d: %w", roomID, err))
+			continue
+		}
+		delete(h.failed, roomID)
+		if ok && room.LastUpdateTime > updated {
+			updated = room.LastUpdateTime
+		}
+	}
+	if listed != nil {
+		h.updated = updated
+	}
+	return errors.Join(errs...)
+}
+
+// holdSinkError marks errors returned by the sink.
+type holdSinkError struct {
+	err error
+}
+
+func (e *holdSinkError) Error() string { return e.err.Error() }
+
+func (h *Hold) pollRoom(ctx context.Context, roomID RoomID, opts []RequestOption) error {
+	messages, _, err := h.client.Messages.List(ctx, roomID, &MessageListParams{Force: 1}, opts...)
+	if err != nil {
+		return err
+	}
+	sort.SliceStable(messages, func(i, j int) bool {
+		return compareMessageIDs(messages[i].MessageID, messages[j].MessageID) < 0
+	})
+
+	last := h.messages[roomID]
+	var fresh []*Message
+	for _, m := range messages {
+		if compareMessageIDs(m.MessageID, last) > 0 {
+			fresh = append(fresh, m)
+		}
+	}
+
+	// A full page without any already archived message means older unseen
+	// messages may have scrolled out of reach.
+	if last != "" && len(fresh) == len(messages) && len(messages) >= maxListedMessages {
+		gap := HoldGap{RoomID: roomID, AfterMessageID: last, BeforeMessageID: fresh[0].MessageID}
+		if err := h.append(ctx, &HoldRecord{Kind: HoldRecordGap, RoomID: roomID, Gap: &gap}); err != nil {
+			return err
+		}
+		if h.OnGap != nil {
+			h.OnGap(gap)
+		}
+	}
+
+	for _, m := range fresh {
+		if err := h.append(ctx, &HoldRecord{Kind: HoldRecordMessage, RoomID: roomID, Message: m}); err != nil {
+			return err
+		}
+		h.messages[roomID] = m.MessageID
+	}
+
+	files, _, err := h.client.Rooms.GetFiles(ctx, roomID, 0, opts...)
+	if err != nil {
+		return err
+	}
+	sort.Slice(files, func(i, j int) bool { return files[i].FileID < files[j].FileID })
+
+	for _, f := range files {
+		if f.FileID <= h.files[roomID] {
+			continue
+		}
+		if err := h.append(ctx, &HoldRecord{Kind: HoldRecordFile, RoomID: roomID, File: f}); err != nil {
+			return err
+		}
+		h.files[roomID] = f.FileID
+	}
+
+	return nil
+}
+
+// append links r into the chain and writes it to the sink.
+func (h *Hold) append(ctx context.Context, r *HoldRecord) error {
+	r.Seq = h.seq + 1
+	r.CapturedAt = time.Now().UTC()
+	r.PrevHash = h.lastHash
+
+	hash, err := r.computeHash()
+	if err != nil {
+		return &holdSinkError{err: err}
+	}
+	r.Hash = hash
+
+	if err := h.sink.Append(ctx, r); err != nil {
+		return &holdSinkError{err: err}
+	}
+	h.seq = r.Seq
+	h.lastHash = r.Hash
+	return nil
+}
+
+// VerifyHoldRecords checks that records form an unbroken chain starting at
+// the first record written by a Hold.
+func VerifyHoldRecords(records []*HoldRecord) error {
+	prevHash := ""
+	for i, r := range records {
+		if r.Seq != int64(i+1) {
+			return fmt.Errorf("%w: record %d has sequence number %d", ErrHoldChainBroken, i+1, r.Seq)
+		}
+		if r.PrevHash != prevHash {
+			return fmt.Errorf("%w: record %d does not follow record %d", ErrHoldChainBroken, r.Seq, r.Seq-1)
+		}
+		hash, err := r.computeHash()
+		if err != nil {
+			return err
+		}
+		if hash != r.Hash {
+			return fmt.Errorf("%w: record %d does not match its hash", ErrHoldChainBroken, r.Seq)
+		}
+		prevHash = r.Hash
+	}
+	return nil
+}
+
+// HoldFileSink is a HoldSink that appends records as JSON lines to a file.
+// Each record is synced to disk before Append returns.
+type HoldFileSink struct {
+	mu   sync.Mutex
+	file *os.File
+}
+
+// OpenHoldFile opens or creates the hold file at path and returns a sink
+// that appends to it, along with the records it already contains. Pass the
+// records to Hold.Resume to continue the chain.
+func OpenHoldFile(path string) (*HoldFileSink, []*HoldRecord, error) {
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_RDWR|os.O_APPEND, 0o600)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var records []*HoldRecord
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 64*1024), 16*1024*1024)
+	for scanner.Scan() {
+		record := new(HoldRecord)
+		if err := json.Unmarshal(scanner.Bytes(), record); err != nil {
+			file.Close()
+			return nil, nil, fmt.Errorf("%w: record %d: %v", ErrHoldChainBroken, len(records)+1, err)
+		}
+		records = append(records, record)
+	}
+	if err := scanner.Err(); err != nil {
+		file.Close()
+		return nil, nil, err
+	}
+
+	return &HoldFileSink{file: file}, records, nil
+}
+
+// Append writes record to the end of the file.
+func (s *HoldFileSink) Append(ctx context.Context, record *HoldRecord) error {
+	data, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, err := s.file.Write(append(data, '\n')); err != nil {
+		return err
+	}
+	return s.file.Sync()
+}
+
+// Close closes the file.
+func (s *HoldFileSink) Close() error {
+	return s.file.Close()
+}
+
+// compareMessageIDs orders ChatWork message IDs, which are decimal numbers
+// that may exceed the range of int64.
+func compareMessageIDs(a, b MessageID) int {
+	if len(a) != len(b) {
+		return len(a) - len(b)
+	}
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	default:
+		return 0
+	}
+}
@@ -0,0 +1,241 @@
+package chatwork
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"testing"
+)
+
+type memoryHoldSink struct {
+	mu      sync.Mutex
+	records []*HoldRecord
+}
+
+func (s *memoryHoldSink) Append(ctx context.Context, record *HoldRecord) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.records = append(s.records, record)
+	return nil
+}
+
+// newHoldTestServer serves the messages produced by messages and a single file.
+func newHoldTestServer(t *testing.T, messages func() []*Message) *Client {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/rooms/1/messages":
+			json.NewEncoder(w).Encode(messages())
+		case "/rooms/1/files":
+			w.Write([]byte(`[{"file_id": 7, "filename": "a.txt"}]`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	client := New(testToken)
+	client.BaseURL, _ = url.Parse(server.URL)
+	return client
+}
+
+func messageRange(from, to int) []*Message {
+	var messages []*Message
+	for id := from; id <= to; id++ {
+		messages = append(messages, &Message{MessageID: MessageID(strconv.Itoa(id)), Body: "m" + strconv.Itoa(id)})
+	}
+	return messages
+}
+
+func TestHold_Poll(t *testing.T) {
+	current := messageRange(1001, 1002)
+	client := newHoldTestServer(t, func() []*Message { return current })
+
+	sink := &memoryHoldSink{}
+	hold := NewHold(client, sink, 1)
+
+	var gaps []HoldGap
+	hold.OnGap = func(gap HoldGap) { gaps = append(gaps, gap) }
+
+	ctx := context.Background()
+	if err := hold.Poll(ctx); err != nil {
+		t.Fatalf("Poll returned error: %v", err)
+	}
+	if len(sink.records) != 3 {
+		t.Fatalf("Expected 2 messages and 1 file, got %d records", len(sink.records))
+	}
+
+	// Nothing new.
+	if err := hold.Poll(ctx); err != nil {
+		t.Fatalf("Poll returned error: %v", err)
+	}
+	if len(sink.records) != 3 {
+		t.Fatalf("Expected no new records, got %d", len(sink.records))
+	}
+
+	// 150 messages were posted, but only the latest 100 are listed.
+	current = messageRange(1053, 1152)
+	if err := hold.Poll(ctx); err != nil {
+		t.Fatalf("Poll returned error: %v", err)
+	}
+	if len(gaps) != 1 || gaps[0].AfterMessageID != "1002" || gaps[0].BeforeMessageID != "1053" {
+		t.Errorf("Expected a gap between 1002 and 1053, got %+v", gaps)
+	}
+	if got := sink.records[3]; got.Kind != HoldRecordGap {
+		t.Errorf("Expected the gap to be recorded before the new messages, got %s", got.Kind)
+	}
+	if len(sink.records) != 104 {
+		t.Errorf("Expected 104 records, got %d", len(sink.records))
+	}
+
+	if err := VerifyHoldRecords(sink.records); err != nil {
+		t.Fatalf("VerifyHoldRecords returned error: %v", err)
+	}
+}
+
+func TestVerifyHoldRecords(t *testing.T) {
+	client := newHoldTestServer(t, func() []*Message { return messageRange(1, 3) })
+
+	newRecords := func() []*HoldRecord {
+		sink := &memoryHoldSink{}
+		if err := NewHold(client, sink, 1).Poll(context.Background()); err != nil {
+			t.Fatalf("Poll returned error: %v", err)
+		}
+		return sink.records
+	}
+
+	tests := []struct {
+		name   string
+		tamper func([]*HoldRecord) []*HoldRecord
+	}{
+		{"altered", func(r []*HoldRecord) []*HoldRecord {
+			r[1].Message.Body = "changed"
+			return r
+		}},
+		{"removed", func(r []*HoldRecord) []*HoldRecord {
+			return append(r[:1], r[2:]...)
+		}},
+		{"reordered", func(r []*HoldRecord) []*HoldRecord {
+			r[1], r[2] = r[2], r[1]
+			return r
+		}},
+		{"truncated at the start", func(r []*HoldRecord) []*HoldRecord {
+			return r[1:]
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			records := tt.tamper(newRecords())
+			if err := VerifyHoldRecords(records); !errors.Is(err, ErrHoldChainBroken) {
+				t.Errorf("Expected ErrHoldChainBroken, got %v", err)
+			}
+		})
+	}
+}
+
+func TestHoldFileSink_Resume(t *testing.T) {
+	current := messageRange(1, 2)
+	client := newHoldTestServer(t, func() []*Message { return current })
+	path := filepath.Join(t.TempDir(), "hold.jsonl")
+	ctx := context.Background()
+
+	sink, records, err := OpenHoldFile(path)
+	if err != nil {
+		t.Fatalf("OpenHoldFile returned error: %v", err)
+	}
+	if len(records) != 0 {
+		t.Fatalf("Expected an empty file, got %d records", len(records))
+	}
+	if err := NewHold(client, sink, 1).Poll(ctx); err != nil {
+		t.Fatalf("Poll returned error: %v", err)
+	}
+	sink.Close()
+
+	// A restarted hold only archives what is new.
+	current = messageRange(1, 3)
+	sink, records, err = OpenHoldFile(path)
+	if err != nil {
+		t.Fatalf("OpenHoldFile returned error: %v", err)
+	}
+	hold := NewHold(client, sink, 1)
+	if err := hold.Resume(records); err != nil {
+		t.Fatalf("Resume returned error: %v", err)
+	}
+	if err := hold.Poll(ctx); err != nil {
+		t.Fatalf("Poll returned error: %v", err)
+	}
+	sink.Close()
+
+	_, records, err = OpenHoldFile(path)
+	if err != nil {
+		t.Fatalf("OpenHoldFile returned error: %v", err)
+	}
+	if len(records) != 4 || records[3].Message.MessageID != "3" {
+		t.Fatalf("Expected messages 1-2, file 7, then message 3; got %d records", len(records))
+	}
+	if err := VerifyHoldRecords(records); err != nil {
+		t.Errorf("VerifyHoldRecords returned error: %v", err)
+	}
+}
+
+func TestHold_Poll_skipsUnchangedRooms(t *testing.T) {
+	var (
+		mu      sync.Mutex
+		updated = map[int]Timestamp{1: 90, 2: 100}
+		polled  = map[int]int{}
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		switch r.URL.Path {
+		case "/rooms":
+			json.NewEncoder(w).Encode([]*Room{
+				{RoomID: 1, LastUpdateTime: updated[1]},
+				{RoomID: 2, LastUpdateTime: updated[2]},
+			})
+		case "/rooms/1/messages", "/rooms/2/messages":
+			roomID, _ := strconv.Atoi(r.URL.Path[len("/rooms/") : len("/rooms/")+1])
+			polled[roomID]++
+			w.Write([]byte(`[]`))
+		default:
+			w.Write([]byte(`[]`))
+		}
+	}))
+	defer server.Close()
+
+	client := New(testToken)
+	client.BaseURL, _ = url.Parse(server.URL)
+	hold := NewHold(client, &memoryHoldSink{}, 1, 2)
+
+	ctx := context.Background()
+	for _, update := range []Timestamp{0, 0, 150} {
+		mu.Lock()
+		if update != 0 {
+			updated[2] = update
+		}
+		mu.Unlock()
+		if err := hold.Poll(ctx); err != nil {
+			t.Fatalf("Poll returned error: %v", err)
+		}
+	}
+
+	// Room 1 is only polled first. Room 2 is polled again because its update
+	// time is the latest seen, which is inclusive, and after its update.
+	if polled[1] != 1 || polled[2] != 3 {
+		t.Errorf("Unexpected polls per room: %v", polled)
+	}
+
+	changed, _, err := client.Rooms.ChangedRooms(ctx, 150)
+	if err != nil {
+		t.Fatalf("ChangedRooms returned error: %v", err)
+	}
+	if len(changed) != 1 || changed[0].RoomID != 2 {
+		t.Errorf("Expected room 2 to have changed, got %+v", changed)
+	}
+}
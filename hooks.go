@@ -0,0 +1,45 @@
+package chatwork
+
+import "net/http"
+
+// OptionOnRequest registers a function that is called with every request
+// right before it is sent, including retries. The hook may modify the
+// request, for example to attach a correlation ID header.
+// The option can be given several times; hooks run in registration order.
+func OptionOnRequest(hook func(*http.Request)) ClientOption {
+	return func(c *Client) {
+		c.onRequest = append(c.onRequest, hook)
+	}
+}
+
+// OptionOnResponse registers a function that is called after every round
+// trip with the response and the error that Do is about to return.
+// The response is nil when the request failed before a response arrived.
+// The option can be given several times; hooks run in registration order.
+//
+// Example:
+//
+//	client := chatwork.New("token",
+//		chatwork.OptionOnResponse(func(resp *chatwork.Response, err error) {
+//			if resp != nil {
+//				metrics.Observe(resp.Latency)
+//			}
+//		}),
+//	)
+func OptionOnResponse(hook func(*Response, error)) ClientOption {
+	return func(c *Client) {
+		c.onResponse = append(c.onResponse, hook)
+	}
+}
+
+func (c *Client) runRequestHooks(req *http.Request) {
+	for _, hook := range c.onRequest {
+		hook(req)
+	}
+}
+
+func (c *Client) runResponseHooks(resp *Response, err error) {
+	for _, hook := range c.onResponse {
+		hook(resp, err)
+	}
+}
@@ -0,0 +1,198 @@
+package chatwork
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// ErrUnknownID is returned by IDMap lookups for identities without a mapping.
+var ErrUnknownID = errors.New("chatwork: unknown ID")
+
+// UnknownIDPolicy selects how an IDMap resolves identities without a mapping.
+type UnknownIDPolicy int
+
+// Supported unknown ID policies.
+const (
+	// AccountID returns ErrUnknownID and AccountIDs leaves the identity out.
+	UnknownIDSkip UnknownIDPolicy = iota
+
+	// AccountID and AccountIDs return ErrUnknownID.
+	UnknownIDError
+
+	// AccountID and AccountIDs resolve the identity to IDMap.Fallback.
+	UnknownIDFallback
+)
+
+// IDMap translates external identities, such as GitHub logins, Slack user IDs,
+// or account IDs of another ChatWork organization, into ChatWork account IDs
+// and back. Each identity maps to exactly one account and vice versa.
+//
+// An IDMap is safe for concurrent use.
+type IDMap struct {
+	// How identities without a mapping are resolved
+	Unknown UnknownIDPolicy
+
+	// Account ID used for unknown identities with UnknownIDFallback
+	Fallback AccountID
+
+	mu      sync.RWMutex
+	forward map[string]AccountID
+	reverse map[AccountID]string
+}
+
+// NewIDMap returns an empty IDMap with the UnknownIDSkip policy.
+func NewIDMap() *IDMap {
+	return &IDMap{
+		forward: make(map[string]AccountID),
+		reverse: make(map[AccountID]string),
+	}
+}
+
+// Add maps the external identity to accountID. It fails if either of them is
+// already mapped to something else.
+func (m *IDMap) Add(external string, accountID AccountID) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if existing, ok := m.forward[external]; ok && existing != accountID {
+		return fmt.Errorf("chatwork: %q is already mapped to account %d", external, existing)
+	}
+	if existing, ok := m.reverse[accountID]; ok && existing != external {
+		return fmt.Errorf("chatwork: account %d is already mapped to %q", accountID, existing)
+	}
+	m.forward[external] = accountID
+	m.reverse[accountID] = external
+	return nil
+}
+
+// Len returns the number of mappings.
+func (m *IDMap) Len() int {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return len(m.forward)
+}
+
+// Lookup returns the account ID mapped to external, ignoring the policy.
+func (m *IDMap) Lookup(external string) (AccountID, bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	accountID, ok := m.forward[external]
+	return accountID, ok
+}
+
+// External returns the external identity mapped to accountID.
+func (m *IDMap) External(accountID AccountID) (string, bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	external, ok := m.reverse[accountID]
+	return external, ok
+}
+
+// AccountID returns the account ID mapped to external, resolving unknown
+// identities according to the policy.
+func (m *IDMap) AccountID(external string) (AccountID, error) {
+	if accountID, ok := m.Lookup(external); ok {
+		return accountID, nil
+	}
+	if m.Unknown == UnknownIDFallback {
+		return m.Fallback, nil
+	}
+	return 0, fmt.Errorf("%w: %q", ErrUnknownID, external)
+}
+
+// AccountIDs returns the account IDs mapped to externals, resolving unknown
+// identities according to the policy. Duplicates are removed, so that the
+// result can be used for mentions and member lists as is.
+func (m *IDMap) AccountIDs(externals []string) ([]AccountID, error) {
+	accountIDs := make([]AccountID, 0, len(externals))
+	seen := make(map[AccountID]bool)
+	for _, external := range externals {
+		accountID, err := m.AccountID(external)
+		if err != nil {
+			if m.Unknown == UnknownIDSkip {
+				continue
+			}
+			return nil, err
+		}
+		if !seen[accountID] {
+			seen[accountID] = true
+			accountIDs = append(accountIDs, accountID)
+		}
+	}
+	return accountIDs, nil
+}
+
+// LoadIDMapCSV reads mappings from CSV records of two fields, the external
+// identity and the account ID. A first record whose account ID is not a
+// number is taken as a header and skipped.
+func LoadIDMapCSV(r io.Reader) (*IDMap, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = 2
+	reader.TrimLeadingSpace = true
+
+	m := NewIDMap()
+	for line := 1; ; line++ {
+		record, err := reader.Read()
+		if err == io.EOF {
+			return m, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		accountID, err := strconv.Atoi(strings.TrimSpace(record[1]))
+		if err != nil {
+			if line == 1 {
+				continue
+			}
+			return nil, fmt.Errorf("chatwork: line %d: invalid account ID %q", line, record[1])
+		}
+		if err := m.Add(strings.TrimSpace(record[0]), AccountID(accountID)); err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+	}
+}
+
+// LoadIDMapJSON reads mappings from a JSON object whose keys are external
+// identities and whose values are account IDs.
+func LoadIDMapJSON(r io.Reader) (*IDMap, error) {
+	var entries map[string]AccountID
+	if err := json.NewDecoder(r).Decode(&entries); err != nil {
+		return nil, err
+	}
+
+	m := NewIDMap()
+	for external, accountID := range entries {
+		if err := m.Add(external, accountID); err != nil {
+			return nil, err
+		}
+	}
+	return m, nil
+}
+
+// LoadIDMapFile reads the mappings in the file at path, in CSV or JSON
+// depending on its ".csv" or ".json" extension.
+func LoadIDMapFile(path string) (*IDMap, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	switch ext := strings.ToLower(filepath.Ext(path)); ext {
+	case ".csv":
+		return LoadIDMapCSV(f)
+	case ".json":
+		return LoadIDMapJSON(f)
+	default:
+		return nil, fmt.Errorf("chatwork: unsupported ID map format %q", ext)
+	}
+}
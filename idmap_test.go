@@ -0,0 +1,87 @@
+package chatwork
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestLoadIDMapCSV(t *testing.T) {
+	m, err := LoadIDMapCSV(strings.NewReader("login,account_id\noctocat, 123\nhubot,456\n"))
+	if err != nil {
+		t.Fatalf("LoadIDMapCSV returned error: %v", err)
+	}
+	if m.Len() != 2 {
+		t.Errorf("Expected 2 mappings, got %d", m.Len())
+	}
+	if id, ok := m.Lookup("octocat"); !ok || id != 123 {
+		t.Errorf("Expected octocat to map to 123, got %d, %v", id, ok)
+	}
+	if external, ok := m.External(456); !ok || external != "hubot" {
+		t.Errorf("Expected 456 to map back to hubot, got %q, %v", external, ok)
+	}
+
+	if _, err := LoadIDMapCSV(strings.NewReader("octocat,123\nhubot,x\n")); err == nil || !strings.Contains(err.Error(), "line 2") {
+		t.Errorf("Expected an invalid account ID error on line 2, got %v", err)
+	}
+	if _, err := LoadIDMapCSV(strings.NewReader("octocat,123\nhubot,123\n")); err == nil {
+		t.Error("Expected an error for an account mapped twice")
+	}
+}
+
+func TestLoadIDMapFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "accounts.json")
+	if err := os.WriteFile(path, []byte(`{"U024BE7LH": 123}`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	m, err := LoadIDMapFile(path)
+	if err != nil {
+		t.Fatalf("LoadIDMapFile returned error: %v", err)
+	}
+	if id, ok := m.Lookup("U024BE7LH"); !ok || id != 123 {
+		t.Errorf("Expected U024BE7LH to map to 123, got %d, %v", id, ok)
+	}
+
+	if _, err := LoadIDMapFile(filepath.Join(t.TempDir(), "accounts.yaml")); err == nil {
+		t.Error("Expected an error for an unsupported format")
+	}
+}
+
+func TestIDMap_UnknownPolicies(t *testing.T) {
+	m := NewIDMap()
+	m.Add("alice", 1)
+	m.Add("bob", 2)
+	externals := []string{"alice", "carol", "bob", "alice"}
+
+	tests := []struct {
+		policy  UnknownIDPolicy
+		want    []AccountID
+		wantErr bool
+	}{
+		{UnknownIDSkip, []AccountID{1, 2}, false},
+		{UnknownIDError, nil, true},
+		{UnknownIDFallback, []AccountID{1, 99, 2}, false},
+	}
+	for _, tt := range tests {
+		m.Unknown = tt.policy
+		m.Fallback = 99
+
+		got, err := m.AccountIDs(externals)
+		if tt.wantErr {
+			if !errors.Is(err, ErrUnknownID) {
+				t.Errorf("policy %d: expected ErrUnknownID, got %v", tt.policy, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("policy %d: AccountIDs returned error: %v", tt.policy, err)
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("policy %d: expected %v, got %v", tt.policy, tt.want, got)
+		}
+	}
+}
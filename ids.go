@@ -0,0 +1,36 @@
+package chatwork
+
+import "strconv"
+
+// RoomID identifies a room.
+type RoomID int
+
+// String returns the room ID in decimal, as the API formats it in paths.
+func (id RoomID) String() string {
+	return strconv.Itoa(int(id))
+}
+
+// AccountID identifies an account.
+type AccountID int
+
+// String returns the account ID in decimal, as the API formats it in paths.
+func (id AccountID) String() string {
+	return strconv.Itoa(int(id))
+}
+
+// MessageID identifies a message within its room. Message IDs are numbers
+// too large for int on some platforms, so the API sends them as strings.
+type MessageID string
+
+// String returns the message ID as the API formats it in paths.
+func (id MessageID) String() string {
+	return string(id)
+}
+
+// TaskID identifies a task.
+type TaskID int
+
+// String returns the task ID in decimal, as the API formats it in paths.
+func (id TaskID) String() string {
+	return strconv.Itoa(int(id))
+}
@@ -0,0 +1,228 @@
+package chatwork
+
+import (
+	"bufio"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	// DefaultImportInterval is the default time between the posts of an
+	// Importer. ChatWork limits the messages posted to a room to about ten
+	// every ten seconds, on top of the limit of each token.
+	DefaultImportInterval = time.Second
+
+	// importMaxRateLimitRetries is the number of times an Importer posts a
+	// message again after ChatWork rate limits it.
+	importMaxRateLimitRetries = 3
+)
+
+// notifyingTagPattern matches notation tags that would notify members of the
+// destination room if re-posted as is.
+var notifyingTagPattern = regexp.MustCompile(`(?i)\[(To:\d+|toall|rp aid=[^\]]*)\]`)
+
+// Importer re-posts archived messages into a room, for example to migrate a
+// room between organizations.
+//
+// Since messages can only be posted as the authenticated account, each one
+// is rendered with its original author and send time in the body. Mentions
+// and replies are rendered as plain text so that members of the destination
+// room are not notified about old messages.
+type Importer struct {
+	client *Client
+
+	// Selects the messages to import (optional). All messages are imported by default.
+	Filter func(*Message) bool
+
+	// Renders the body of an imported message. Defaults to FormatImportedMessage.
+	Format func(m *Message, loc *time.Location) string
+
+	// Time zone in which original send times are shown. Defaults to UTC.
+	Location *time.Location
+
+	// Time between posts. Defaults to DefaultImportInterval; a negative
+	// value posts without pausing.
+	Interval time.Duration
+}
+
+// ImportResult reports the outcome of an import.
+type ImportResult struct {
+	// IDs of the posted messages, keyed by the original message ID
+	Imported map[MessageID]MessageID
+
+	// Number of messages excluded by the filter
+	Skipped int
+}
+
+// NewImporter returns an Importer that posts with client.
+func NewImporter(client *Client) *Importer {
+	return &Importer{client: client}
+}
+
+// FormatImportedMessage renders m as an info block titled with its original
+// author and send time.
+func FormatImportedMessage(m *Message, loc *time.Location) string {
+	sent := m.SendTime.Time().In(loc).Format("2006-01-02 15:04 MST")
+	body := notifyingTagPattern.ReplaceAllString(m.Body, "($1)")
+	return fmt.Sprintf("[info][title]%s (%d) %s[/title]%s[/info]", m.Account.Name, m.Account.AccountID, sent, body)
+}
+
+// ImportArchive verifies the archive with the manifest at path and imports
+// the messages it contains into roomID, as Import does.
+//
+// The archive is read as it is imported, rather than loaded into memory, so
+// messages are posted in the order of the archive, which for archives written
+// by ExportWriter is the order they were exported in.
+func (im *Importer) ImportArchive(ctx context.Context, path string, roomID RoomID, opts ...RequestOption) (*ImportResult, error) {
+	result := &ImportResult{Imported: make(map[MessageID]MessageID)}
+	err := EachArchiveMessage(path, func(m *Message) error {
+		return im.post(ctx, roomID, m, result, opts)
+	})
+	return result, err
+}
+
+// Import posts messages into roomID in the order they were originally sent.
+//
+// Posts are paced by Interval, as ChatWork limits the messages posted to a
+// single room, and a post that is rate limited nevertheless is retried after
+// the wait ChatWork asks for. Import stops at the first other error; the
+// result then lists the messages posted so far, so that a Filter excluding
+// them can be used to resume.
+func (im *Importer) Import(ctx context.Context, roomID RoomID, messages []*Message, opts ...RequestOption) (*ImportResult, error) {
+	sorted := append([]*Message(nil), messages...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].SendTime < sorted[j].SendTime
+	})
+
+	result := &ImportResult{Imported: make(map[MessageID]MessageID)}
+	for _, m := range sorted {
+		if err := im.post(ctx, roomID, m, result, opts); err != nil {
+			return result, err
+		}
+	}
+	return result, nil
+}
+
+// post imports m into roomID and records it in result, pausing first if an
+// earlier message was posted.
+func (im *Importer) post(ctx context.Context, roomID RoomID, m *Message, result *ImportResult, opts []RequestOption) error {
+	if im.Filter != nil && !im.Filter(m) {
+		result.Skipped++
+		return nil
+	}
+	format := im.Format
+	if format == nil {
+		format = FormatImportedMessage
+	}
+	loc := im.Location
+	if loc == nil {
+		loc = time.UTC
+	}
+	interval := im.Interval
+	if interval == 0 {
+		interval = DefaultImportInterval
+	}
+
+	if len(result.Imported) > 0 {
+		if err := sleepContext(ctx, interval); err != nil {
+			return err
+		}
+	}
+	for attempt := 0; ; attempt++ {
+		created, _, err := im.client.Messages.SendMessage(ctx, roomID, format(m, loc), opts...)
+		var apiErr *APIError
+		if errors.Is(err, ErrRateLimited) && errors.As(err, &apiErr) && attempt < importMaxRateLimitRetries {
+			wait := apiErr.RetryAfter
+			if wait < interval {
+				wait = interval
+			}
+			if err := sleepContext(ctx, wait); err != nil {
+				return err
+			}
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("message %s: %w", m.MessageID, err)
+		}
+		result.Imported[m.MessageID] = created.MessageID
+		return nil
+	}
+}
+
+// ReadArchiveMessages verifies the archive with the manifest at path and
+// returns the messages it contains. Records that are not messages are ignored.
+// Use EachArchiveMessage for archives too large to hold in memory.
+func ReadArchiveMessages(path string) ([]*Message, error) {
+	var messages []*Message
+	err := EachArchiveMessage(path, func(m *Message) error {
+		messages = append(messages, m)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return messages, nil
+}
+
+// EachArchiveMessage verifies the archive with the manifest at path and calls
+// fn with each message it contains, in the order of the archive, reading its
+// files as a stream. Records that are not messages are ignored. It stops at
+// the first error returned by fn.
+func EachArchiveMessage(path string, fn func(*Message) error) error {
+	manifest, err := VerifyArchive(path)
+	if err != nil {
+		return err
+	}
+
+	dir := filepath.Dir(path)
+	for _, f := range manifest.Files {
+		if err := eachArchiveFileMessage(filepath.Join(dir, f.Name), fn); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func eachArchiveFileMessage(path string, fn func(*Message) error) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	var r io.Reader = file
+	if strings.HasSuffix(path, ".gz") {
+		gz, err := gzip.NewReader(r)
+		if err != nil {
+			return err
+		}
+		defer gz.Close()
+		r = gz
+	}
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 16*1024*1024)
+	for scanner.Scan() {
+		message := new(Message)
+		if err := json.Unmarshal(scanner.Bytes(), message); err != nil {
+			continue
+		}
+		if message.MessageID == "" {
+			continue
+		}
+		if err := fn(message); err != nil {
+			return err
+		}
+	}
+	return scanner.Err()
+}
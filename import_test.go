@@ -0,0 +1,104 @@
+package chatwork
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestImporter_ImportArchive(t *testing.T) {
+	dir := t.TempDir()
+	w, err := NewExportWriter(ExportWriterOptions{Dir: dir, Compression: ExportCompressionGzip})
+	if err != nil {
+		t.Fatalf("NewExportWriter returned error: %v", err)
+	}
+	messages := []*Message{
+		{MessageID: "1", Account: User{AccountID: 20, Name: "Alice"}, Body: "first", SendTime: 1704164640},
+		{MessageID: "2", Account: User{AccountID: 10, Name: "Bob"}, Body: "[To:1] second", SendTime: 1704164700},
+		{MessageID: "3", Account: User{AccountID: 20, Name: "Alice"}, Body: "skip me", SendTime: 1704164760},
+	}
+	for _, m := range messages {
+		w.WriteRecord(m, m.SendTime.Time())
+	}
+	w.Close()
+
+	var bodies []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "POST" || r.URL.Path != "/rooms/99/messages" {
+			t.Errorf("Unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		r.ParseForm()
+		bodies = append(bodies, r.Form.Get("body"))
+		fmt.Fprintf(w, `{"message_id": "new-%d"}`, len(bodies))
+	}))
+	defer server.Close()
+
+	client := New(testToken, OptionRateLimit(100, time.Second))
+	client.BaseURL, _ = url.Parse(server.URL)
+
+	importer := NewImporter(client)
+	importer.Filter = func(m *Message) bool { return m.MessageID != "3" }
+	importer.Interval = time.Millisecond
+
+	result, err := importer.ImportArchive(context.Background(), filepath.Join(dir, "export-manifest.json"), 99)
+	if err != nil {
+		t.Fatalf("ImportArchive returned error: %v", err)
+	}
+
+	if result.Skipped != 1 || result.Imported["1"] != "new-1" || result.Imported["2"] != "new-2" {
+		t.Errorf("Unexpected result %+v", result)
+	}
+	want := []string{
+		"[info][title]Alice (20) 2024-01-02 03:04 UTC[/title]first[/info]",
+		"[info][title]Bob (10) 2024-01-02 03:05 UTC[/title](To:1) second[/info]",
+	}
+	if strings.Join(bodies, "\n") != strings.Join(want, "\n") {
+		t.Errorf("Expected bodies\n%s\ngot\n%s", strings.Join(want, "\n"), strings.Join(bodies, "\n"))
+	}
+}
+
+func TestImporter_Import(t *testing.T) {
+	var bodies []string
+	rateLimited := false
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		r.ParseForm()
+		if len(bodies) == 1 && !rateLimited {
+			rateLimited = true
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		bodies = append(bodies, r.Form.Get("body"))
+		fmt.Fprintf(w, `{"message_id": "new-%d"}`, len(bodies))
+	}))
+	defer server.Close()
+
+	client := New(testToken)
+	client.BaseURL, _ = url.Parse(server.URL)
+
+	importer := NewImporter(client)
+	importer.Interval = 20 * time.Millisecond
+	importer.Format = func(m *Message, loc *time.Location) string { return m.Body }
+
+	start := time.Now()
+	result, err := importer.Import(context.Background(), 99, []*Message{
+		{MessageID: "2", Body: "second", SendTime: 1704164700},
+		{MessageID: "1", Body: "first", SendTime: 1704164640},
+	})
+	if err != nil {
+		t.Fatalf("Import returned error: %v", err)
+	}
+	if strings.Join(bodies, ",") != "first,second" || len(result.Imported) != 2 {
+		t.Errorf("Expected the messages in send order despite the rate limit, got %v", bodies)
+	}
+	// One pause between the posts, and one before the rate limited post is retried.
+	if elapsed := time.Since(start); elapsed < 40*time.Millisecond {
+		t.Errorf("Expected the posts to be paced, took %v", elapsed)
+	}
+}
@@ -0,0 +1,143 @@
+package chatwork
+
+import (
+	"context"
+	"io"
+	"time"
+)
+
+// The interfaces in this file describe the methods of each service, so that
+// code using the client can depend on them and substitute test doubles, such
+// as the mocks in the chatworkmock package:
+//
+//	type Notifier struct {
+//		Messages chatwork.MessagesAPI
+//	}
+//
+//	notifier := &Notifier{Messages: client.Messages}
+//
+// The service structs of Client remain their default implementations. After
+// changing an interface, run go generate in chatworkmock to update the mocks.
+
+// RoomsAPI is the interface implemented by RoomsService.
+type RoomsAPI interface {
+	List(ctx context.Context, opts ...RequestOption) ([]*Room, *Response, error)
+	ChangedRooms(ctx context.Context, since Timestamp, opts ...RequestOption) ([]*Room, *Response, error)
+	Search(ctx context.Context, match func(*Room) bool, opts ...RequestOption) ([]*Room, *Response, error)
+	FindByName(ctx context.Context, name string, opts ...RequestOption) (*Room, *Response, error)
+	ListGroupChats(ctx context.Context, opts ...RequestOption) ([]*Room, *Response, error)
+	ListDirectChats(ctx context.Context, opts ...RequestOption) ([]*Room, *Response, error)
+	GetMyChat(ctx context.Context, opts ...RequestOption) (*Room, *Response, error)
+	Create(ctx context.Context, params *RoomCreateParams, opts ...RequestOption) (*Room, *Response, error)
+	Get(ctx context.Context, roomID RoomID, opts ...RequestOption) (*Room, *Response, error)
+	Update(ctx context.Context, roomID RoomID, params *RoomUpdateParams, opts ...RequestOption) (*Room, *Response, error)
+	Delete(ctx context.Context, roomID RoomID, actionType string, opts ...RequestOption) (*Response, error)
+	Leave(ctx context.Context, roomID RoomID, opts ...RequestOption) (*Response, error)
+	DeleteRoom(ctx context.Context, roomID RoomID, opts ...RequestOption) (*Response, error)
+	GetMembers(ctx context.Context, roomID RoomID, opts ...RequestOption) ([]*Member, *Response, error)
+	GetAllDetails(ctx context.Context, roomIDs []RoomID, concurrency int, opts ...RequestOption) ([]*RoomDetails, error)
+	ReplaceMembers(ctx context.Context, roomID RoomID, params *RoomMembersUpdateParams, opts ...RequestOption) (*RoomMembersUpdateResult, *Response, error)
+	UpdateMembers(ctx context.Context, roomID RoomID, params *RoomMembersUpdateParams, opts ...RequestOption) (*Member, *Response, error)
+	AddMembers(ctx context.Context, roomID RoomID, accountIDs []AccountID, role Role, opts ...RequestOption) (*RoomMembersUpdateResult, *Response, error)
+	RemoveMembers(ctx context.Context, roomID RoomID, accountIDs []AccountID, opts ...RequestOption) (*RoomMembersUpdateResult, *Response, error)
+	ChangeMemberRole(ctx context.Context, roomID RoomID, accountID AccountID, role Role, opts ...RequestOption) (*RoomMembersUpdateResult, *Response, error)
+	DiffMembers(ctx context.Context, roomA, roomB RoomID, opts ...RequestOption) (*MemberDiff, *Response, error)
+	SyncMembers(ctx context.Context, src, dst RoomID, params *SyncMembersParams, opts ...RequestOption) (*MemberDiff, *Response, error)
+	GetMessagesReadStatus(ctx context.Context, roomID RoomID, messageID MessageID, opts ...RequestOption) (*UnreadCount, *Response, error)
+	MarkMessagesAsRead(ctx context.Context, roomID RoomID, messageID MessageID, opts ...RequestOption) (*UnreadCount, *Response, error)
+	GetMessagesUnreadCount(ctx context.Context, roomID RoomID, opts ...RequestOption) (*UnreadCount, *Response, error)
+	GetFiles(ctx context.Context, roomID RoomID, accountID AccountID, opts ...RequestOption) ([]*File, *Response, error)
+	GetFile(ctx context.Context, roomID RoomID, fileID int, createDownloadURL bool, opts ...RequestOption) (*File, *Response, error)
+	UploadFile(ctx context.Context, roomID RoomID, filename string, content io.Reader, params *FileUploadParams, opts ...RequestOption) (*FileUploadedResponse, *Response, error)
+	GetTasks(ctx context.Context, roomID RoomID, params *TaskListParams, opts ...RequestOption) ([]*Task, *Response, error)
+	UpdateDescription(ctx context.Context, roomID RoomID, tmpl *DescriptionTemplate, vars interface{}, opts ...RequestOption) (bool, *Response, error)
+	GetFilesByCategory(ctx context.Context, roomID RoomID, accountID AccountID, categories []FileCategory, opts ...RequestOption) ([]*File, *Response, error)
+	GetImages(ctx context.Context, roomID RoomID, accountID AccountID, opts ...RequestOption) ([]*File, *Response, error)
+	IterFiles(ctx context.Context, roomID RoomID, params *FileIterParams, opts ...RequestOption) *FileIterator
+	ExportHistory(ctx context.Context, roomID RoomID, sink ExportSink, opts ...RequestOption) (*ExportSummary, *Response, error)
+}
+
+// MessagesAPI is the interface implemented by MessagesService.
+type MessagesAPI interface {
+	List(ctx context.Context, roomID RoomID, params *MessageListParams, opts ...RequestOption) ([]*Message, *Response, error)
+	ListAll(ctx context.Context, roomID RoomID, params *MessageListAllParams, opts ...RequestOption) ([]*Message, *Response, error)
+	Create(ctx context.Context, roomID RoomID, params *MessageCreateParams, opts ...RequestOption) (*MessageCreatedResponse, *Response, error)
+	Get(ctx context.Context, roomID RoomID, messageID MessageID, opts ...RequestOption) (*Message, *Response, error)
+	Update(ctx context.Context, roomID RoomID, messageID MessageID, params *MessageUpdateParams, opts ...RequestOption) (*Message, *Response, error)
+	Delete(ctx context.Context, roomID RoomID, messageID MessageID, opts ...RequestOption) (*Message, *Response, error)
+	SendMessage(ctx context.Context, roomID RoomID, body string, opts ...RequestOption) (*MessageCreatedResponse, *Response, error)
+	SendTo(ctx context.Context, roomID RoomID, accountIDs []AccountID, body string, opts ...RequestOption) (*MessageCreatedResponse, *Response, error)
+	SendToAll(ctx context.Context, roomID RoomID, body string, opts ...RequestOption) (*MessageCreatedResponse, *Response, error)
+	SendCode(ctx context.Context, roomID RoomID, code string, opts ...RequestOption) (*MessageCreatedResponse, *Response, error)
+	SendTemplate(ctx context.Context, roomID RoomID, name string, data interface{}, opts ...RequestOption) (*MessageCreatedResponse, *Response, error)
+	SendLong(ctx context.Context, roomID RoomID, body string, opts ...RequestOption) ([]MessageID, *Response, error)
+	Reply(ctx context.Context, roomID RoomID, messageID MessageID, body string, opts ...RequestOption) (*MessageCreatedResponse, *Response, error)
+	Quote(ctx context.Context, roomID RoomID, messageID MessageID, body string, opts ...RequestOption) (*MessageCreatedResponse, *Response, error)
+	SendInfo(ctx context.Context, roomID RoomID, title, body string, opts ...RequestOption) (*MessageCreatedResponse, *Response, error)
+	GetUnreadCount(ctx context.Context, roomID RoomID, opts ...RequestOption) (int, *Response, error)
+	MarkAsRead(ctx context.Context, roomID RoomID, messageID MessageID, opts ...RequestOption) (*Response, error)
+	DeleteMine(ctx context.Context, roomID RoomID, filter func(*Message) bool, opts ...RequestOption) ([]MessageID, *Response, error)
+	NewStatusMessage(ctx context.Context, roomID RoomID, body string, opts ...RequestOption) (*StatusMessage, *Response, error)
+}
+
+// TasksAPI is the interface implemented by TasksService.
+type TasksAPI interface {
+	Create(ctx context.Context, roomID RoomID, params *TaskCreateParams, opts ...RequestOption) (*TaskCreatedResponse, *Response, error)
+	Get(ctx context.Context, roomID RoomID, taskID TaskID, opts ...RequestOption) (*Task, *Response, error)
+	UpdateStatus(ctx context.Context, roomID RoomID, taskID TaskID, status TaskStatus, opts ...RequestOption) (*Task, *Response, error)
+	Complete(ctx context.Context, roomID RoomID, taskID TaskID, opts ...RequestOption) (*Task, *Response, error)
+	Reopen(ctx context.Context, roomID RoomID, taskID TaskID, opts ...RequestOption) (*Task, *Response, error)
+	CreateSimple(ctx context.Context, roomID RoomID, body string, toIDs []AccountID, opts ...RequestOption) (*TaskCreatedResponse, *Response, error)
+	CreateWithDeadline(ctx context.Context, roomID RoomID, body string, toIDs []AccountID, deadline int64, opts ...RequestOption) (*TaskCreatedResponse, *Response, error)
+	CreateWithDueDate(ctx context.Context, roomID RoomID, body string, toIDs []AccountID, due time.Time, dateOnly bool, opts ...RequestOption) (*TaskCreatedResponse, *Response, error)
+}
+
+// MyTasksAPI is the interface implemented by MyTasksService.
+type MyTasksAPI interface {
+	List(ctx context.Context, params *MyTaskListParams, opts ...RequestOption) ([]*MyTask, *Response, error)
+	GetOpen(ctx context.Context, opts ...RequestOption) ([]*MyTask, *Response, error)
+	GetOverdue(ctx context.Context, opts ...RequestOption) ([]*MyTask, *Response, error)
+	ListGroupedByRoom(ctx context.Context, params *MyTaskListParams, opts ...RequestOption) ([]*MyTaskGroup, *Response, error)
+	GetCompleted(ctx context.Context, opts ...RequestOption) ([]*MyTask, *Response, error)
+	GetByRoom(ctx context.Context, roomID RoomID, opts ...RequestOption) ([]*MyTask, *Response, error)
+	CompleteTask(ctx context.Context, roomID RoomID, taskID TaskID, opts ...RequestOption) (*Task, *Response, error)
+	ReopenTask(ctx context.Context, roomID RoomID, taskID TaskID, opts ...RequestOption) (*Task, *Response, error)
+}
+
+// ContactsAPI is the interface implemented by ContactsService.
+type ContactsAPI interface {
+	List(ctx context.Context, opts ...RequestOption) ([]*Contact, *Response, error)
+	Filter(ctx context.Context, match func(*Contact) bool, opts ...RequestOption) ([]*Contact, *Response, error)
+	FindByChatworkID(ctx context.Context, chatworkID string, opts ...RequestOption) (*Contact, *Response, error)
+	FindByName(ctx context.Context, name string, opts ...RequestOption) (*Contact, *Response, error)
+	ForceRefresh(ctx context.Context, opts ...RequestOption) ([]*Contact, *Response, error)
+	Invalidate()
+}
+
+// IncomingRequestsAPI is the interface implemented by IncomingRequestsService.
+type IncomingRequestsAPI interface {
+	List(ctx context.Context, opts ...RequestOption) ([]*IncomingRequest, *Response, error)
+	ListFiltered(ctx context.Context, params *IncomingRequestListParams, opts ...RequestOption) ([]*IncomingRequest, *Response, error)
+	GetByAccountID(ctx context.Context, accountID AccountID, opts ...RequestOption) (*IncomingRequest, *Response, error)
+	Approve(ctx context.Context, requestID int, opts ...RequestOption) (*IncomingRequestActionResponse, *Response, error)
+	Reject(ctx context.Context, requestID int, opts ...RequestOption) (*Response, error)
+	ApplyPolicy(ctx context.Context, policy *ContactRequestPolicy, opts ...RequestOption) ([]*ContactRequestResult, error)
+}
+
+// MeAPI is the interface implemented by MeService.
+type MeAPI interface {
+	Get(ctx context.Context, opts ...RequestOption) (*Me, *Response, error)
+	GetStatus(ctx context.Context, opts ...RequestOption) (*MyStatus, *Response, error)
+	MarkAllAsRead(ctx context.Context, params *MarkAllAsReadParams, opts ...RequestOption) ([]*MarkAsReadResult, error)
+}
+
+// Ensure the services implement their interfaces.
+var (
+	_ RoomsAPI            = (*RoomsService)(nil)
+	_ MessagesAPI         = (*MessagesService)(nil)
+	_ TasksAPI            = (*TasksService)(nil)
+	_ MyTasksAPI          = (*MyTasksService)(nil)
+	_ ContactsAPI         = (*ContactsService)(nil)
+	_ IncomingRequestsAPI = (*IncomingRequestsService)(nil)
+	_ MeAPI               = (*MeService)(nil)
+)
@@ -0,0 +1,39 @@
+package chatwork
+
+import (
+	"reflect"
+	"testing"
+)
+
+// iteratorMethods return Go 1.23 iterators. They are left out of the
+// interfaces, which also build with older versions of Go.
+var iteratorMethods = map[string]bool{"All": true}
+
+// TestServiceInterfaces ensures that each interface covers every method of
+// its service, so that new methods are not forgotten.
+func TestServiceInterfaces(t *testing.T) {
+	tests := []struct {
+		service reflect.Type
+		iface   reflect.Type
+	}{
+		{reflect.TypeOf((*RoomsService)(nil)), reflect.TypeOf((*RoomsAPI)(nil)).Elem()},
+		{reflect.TypeOf((*MessagesService)(nil)), reflect.TypeOf((*MessagesAPI)(nil)).Elem()},
+		{reflect.TypeOf((*TasksService)(nil)), reflect.TypeOf((*TasksAPI)(nil)).Elem()},
+		{reflect.TypeOf((*MyTasksService)(nil)), reflect.TypeOf((*MyTasksAPI)(nil)).Elem()},
+		{reflect.TypeOf((*ContactsService)(nil)), reflect.TypeOf((*ContactsAPI)(nil)).Elem()},
+		{reflect.TypeOf((*IncomingRequestsService)(nil)), reflect.TypeOf((*IncomingRequestsAPI)(nil)).Elem()},
+		{reflect.TypeOf((*MeService)(nil)), reflect.TypeOf((*MeAPI)(nil)).Elem()},
+	}
+
+	for _, tt := range tests {
+		for i := 0; i < tt.service.NumMethod(); i++ {
+			name := tt.service.Method(i).Name
+			if iteratorMethods[name] {
+				continue
+			}
+			if _, ok := tt.iface.MethodByName(name); !ok {
+				t.Errorf("%s is missing %s.%s", tt.iface.Name(), tt.service.Elem().Name(), name)
+			}
+		}
+	}
+}
@@ -0,0 +1,103 @@
+//go:build go1.23
+
+package chatwork
+
+import (
+	"context"
+	"iter"
+)
+
+// All returns an iterator over the rooms of the authenticated account:
+//
+//	for room, err := range client.Rooms.All(ctx) {
+//		if err != nil {
+//			return err
+//		}
+//		fmt.Println(room.Name)
+//	}
+//
+// If listing fails, the iterator yields the error once and stops.
+func (s *RoomsService) All(ctx context.Context, opts ...RequestOption) iter.Seq2[*Room, error] {
+	return seq(func() ([]*Room, error) {
+		rooms, _, err := s.List(ctx, opts...)
+		return rooms, err
+	})
+}
+
+// All returns an iterator over the messages of a room, oldest first, as
+// returned by ListAll. If listing fails, the iterator yields the error once
+// and stops.
+func (s *MessagesService) All(ctx context.Context, roomID RoomID, opts ...RequestOption) iter.Seq2[*Message, error] {
+	return seq(func() ([]*Message, error) {
+		messages, _, err := s.ListAll(ctx, roomID, nil, opts...)
+		return messages, err
+	})
+}
+
+// All returns an iterator over the tasks of the authenticated account
+// selected by params, which may be nil. If listing fails, the iterator yields
+// the error once and stops.
+func (s *MyTasksService) All(ctx context.Context, params *MyTaskListParams, opts ...RequestOption) iter.Seq2[*MyTask, error] {
+	return seq(func() ([]*MyTask, error) {
+		tasks, _, err := s.List(ctx, params, opts...)
+		return tasks, err
+	})
+}
+
+// All returns an iterator over the pending contact requests selected by
+// params, which may be nil, ordered as by ListFiltered (params.Limit is
+// ignored).
+//
+// Since the API lists at most 100 requests, the iterator lists again once
+// it has yielded every listed request, and continues with the requests not
+// yielded before. It stops when a listing has no new requests, so approving
+// or rejecting the yielded requests lets it process any number of them. If
+// listing fails, the iterator yields the error once and stops.
+func (s *IncomingRequestsService) All(ctx context.Context, params *IncomingRequestListParams, opts ...RequestOption) iter.Seq2[*IncomingRequest, error] {
+	if params != nil {
+		unlimited := *params
+		unlimited.Limit = 0
+		params = &unlimited
+	}
+	return func(yield func(*IncomingRequest, error) bool) {
+		seen := make(map[int]bool)
+		for {
+			requests, _, err := s.ListFiltered(ctx, params, opts...)
+			if err != nil {
+				yield(nil, err)
+				return
+			}
+			fresh := false
+			for _, r := range requests {
+				if seen[r.RequestID] {
+					continue
+				}
+				seen[r.RequestID] = true
+				fresh = true
+				if !yield(r, nil) {
+					return
+				}
+			}
+			if !fresh {
+				return
+			}
+		}
+	}
+}
+
+// seq returns an iterator over the items returned by list, which is called
+// when iteration starts.
+func seq[T any](list func() ([]*T, error)) iter.Seq2[*T, error] {
+	return func(yield func(*T, error) bool) {
+		items, err := list()
+		if err != nil {
+			yield(nil, err)
+			return
+		}
+		for _, item := range items {
+			if !yield(item, nil) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,88 @@
+//go:build go1.23
+
+package chatwork_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/nashirox/chatwork-go"
+	"github.com/nashirox/chatwork-go/chatworktest"
+)
+
+func TestAll(t *testing.T) {
+	srv := chatworktest.NewServer()
+	defer srv.Close()
+
+	roomID := srv.AddRoom(chatwork.Room{Name: "General"})
+	srv.AddRoom(chatwork.Room{Name: "Random"})
+	for _, body := range []string{"one", "two", "three"} {
+		srv.AddMessage(roomID, chatwork.Message{Body: body})
+	}
+	client := srv.Client()
+	ctx := context.Background()
+
+	var names []string
+	for room, err := range client.Rooms.All(ctx) {
+		if err != nil {
+			t.Fatalf("Rooms.All yielded error: %v", err)
+		}
+		names = append(names, room.Name)
+	}
+	if len(names) != 2 {
+		t.Errorf("Expected 2 rooms, got %v", names)
+	}
+
+	var bodies []string
+	for m, err := range client.Messages.All(ctx, roomID) {
+		if err != nil {
+			t.Fatalf("Messages.All yielded error: %v", err)
+		}
+		bodies = append(bodies, m.Body)
+		if len(bodies) == 2 {
+			break
+		}
+	}
+	if len(bodies) != 2 || bodies[0] != "one" || bodies[1] != "two" {
+		t.Errorf("Expected the first two messages, got %v", bodies)
+	}
+
+	srv.Fail("GET", "/my/tasks", http.StatusInternalServerError)
+	yields := 0
+	for task, err := range client.MyTasks.All(ctx, nil) {
+		yields++
+		if task != nil || !errors.Is(err, chatwork.ErrServerError) {
+			t.Errorf("Expected a server error, got %v, %v", task, err)
+		}
+	}
+	if yields != 1 {
+		t.Errorf("Expected the error to be yielded once, got %d yields", yields)
+	}
+}
+
+func TestIncomingRequestsService_All(t *testing.T) {
+	srv := chatworktest.NewServer()
+	defer srv.Close()
+
+	for i := 0; i < 3; i++ {
+		srv.AddIncomingRequest(chatwork.IncomingRequest{AccountID: chatwork.AccountID(10 + i)})
+	}
+	client := srv.Client()
+	ctx := context.Background()
+
+	var accounts []chatwork.AccountID
+	for r, err := range client.IncomingRequests.All(ctx, nil) {
+		if err != nil {
+			t.Fatalf("IncomingRequests.All yielded error: %v", err)
+		}
+		accounts = append(accounts, r.AccountID)
+		if _, err := client.IncomingRequests.Reject(ctx, r.RequestID); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if len(accounts) != 3 || accounts[0] != 10 || accounts[2] != 12 {
+		t.Errorf("Expected the requests oldest first, got %v", accounts)
+	}
+}
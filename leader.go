@@ -0,0 +1,200 @@
+package chatwork
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+// DefaultLeaseTTL is the default duration of a lease held by a LeaderElector.
+const DefaultLeaseTTL = 15 * time.Second
+
+// LeaseStore stores leases shared by the replicas of an application, such
+// as rows of a database or keys of a cache with expiry.
+type LeaseStore interface {
+	// Acquire takes the lease name for holder until ttl from now, if it is
+	// free, expired, or already held by holder, and reports whether holder
+	// now holds it. Implementations must do this atomically.
+	Acquire(ctx context.Context, name, holder string, ttl time.Duration) (bool, error)
+
+	// Release frees the lease name if holder holds it.
+	Release(ctx context.Context, name, holder string) error
+}
+
+// LeaderElector runs a job on only one of several replicas at a time, so that
+// periodic jobs such as a Hold, an UnreadMonitor, or a reminder loop do not
+// post or archive the same data twice.
+//
+// The replica that acquires the lease runs the job and renews the lease every
+// third of its TTL. The others stand by and retry at the same interval. If
+// the leader cannot renew the lease, its job's context is canceled and it
+// stands by again.
+type LeaderElector struct {
+	store  LeaseStore
+	name   string
+	holder string
+
+	// Duration of the lease. Defaults to DefaultLeaseTTL.
+	TTL time.Duration
+
+	// Called with errors from the store (optional)
+	OnError func(error)
+
+	mu     sync.Mutex
+	leader bool
+}
+
+// NewLeaderElector returns a LeaderElector competing for the lease name on
+// behalf of holder, which must be unique among the replicas, such as a host
+// name.
+func NewLeaderElector(store LeaseStore, name, holder string) *LeaderElector {
+	return &LeaderElector{
+		store:  store,
+		name:   name,
+		holder: holder,
+		TTL:    DefaultLeaseTTL,
+	}
+}
+
+// IsLeader reports whether the elector currently holds the lease.
+func (e *LeaderElector) IsLeader() bool {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return e.leader
+}
+
+// Run waits until the lease is acquired and runs job, repeating after a lost
+// lease, until ctx is done or job returns. It then releases the lease and
+// returns job's error, or ctx.Err().
+func (e *LeaderElector) Run(ctx context.Context, job func(ctx context.Context) error) error {
+	ttl := e.TTL
+	if ttl <= 0 {
+		ttl = DefaultLeaseTTL
+	}
+	interval := ttl / 3
+
+	for {
+		acquired, err := e.store.Acquire(ctx, e.name, e.holder, ttl)
+		if err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			e.reportError(err)
+		}
+		if acquired {
+			done, err := e.lead(ctx, job, ttl, interval)
+			if done {
+				return err
+			}
+		}
+		if err := sleepContext(ctx, interval); err != nil {
+			return err
+		}
+	}
+}
+
+// lead runs job while renewing the lease. It reports whether Run is done,
+// rather than the lease lost.
+func (e *LeaderElector) lead(ctx context.Context, job func(ctx context.Context) error, ttl, interval time.Duration) (bool, error) {
+	e.setLeader(true)
+	defer e.setLeader(false)
+
+	jobCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	result := make(chan error, 1)
+	go func() {
+		result <- job(jobCtx)
+	}()
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case err := <-result:
+			e.release(ttl)
+			if err == nil && ctx.Err() != nil {
+				err = ctx.Err()
+			}
+			return true, err
+		case <-ticker.C:
+			renewed, err := e.store.Acquire(ctx, e.name, e.holder, ttl)
+			if err != nil {
+				e.reportError(err)
+			}
+			if !renewed && ctx.Err() == nil {
+				cancel()
+				<-result
+				return false, nil
+			}
+		}
+	}
+}
+
+// release frees the lease, without the caller's context, which may be done.
+func (e *LeaderElector) release(timeout time.Duration) {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	if err := e.store.Release(ctx, e.name, e.holder); err != nil {
+		e.reportError(err)
+	}
+}
+
+func (e *LeaderElector) setLeader(leader bool) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.leader = leader
+}
+
+func (e *LeaderElector) reportError(err error) {
+	if e.OnError != nil && !errors.Is(err, context.Canceled) {
+		e.OnError(err)
+	}
+}
+
+// MemoryLeaseStore is a LeaseStore for replicas within a single process,
+// such as in tests.
+type MemoryLeaseStore struct {
+	mu     sync.Mutex
+	leases map[string]memoryLease
+	now    func() time.Time
+}
+
+type memoryLease struct {
+	holder  string
+	expires time.Time
+}
+
+// NewMemoryLeaseStore returns an empty MemoryLeaseStore.
+func NewMemoryLeaseStore() *MemoryLeaseStore {
+	return &MemoryLeaseStore{
+		leases: make(map[string]memoryLease),
+		now:    time.Now,
+	}
+}
+
+// Acquire implements LeaseStore.
+func (s *MemoryLeaseStore) Acquire(ctx context.Context, name, holder string, ttl time.Duration) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	if lease, ok := s.leases[name]; ok && lease.holder != holder && now.Before(lease.expires) {
+		return false, nil
+	}
+	s.leases[name] = memoryLease{holder: holder, expires: now.Add(ttl)}
+	return true, nil
+}
+
+// Release implements LeaseStore.
+func (s *MemoryLeaseStore) Release(ctx context.Context, name, holder string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if lease, ok := s.leases[name]; ok && lease.holder == holder {
+		delete(s.leases, name)
+	}
+	return nil
+}
@@ -0,0 +1,98 @@
+package chatwork
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestLeaderElector(t *testing.T) {
+	store := NewMemoryLeaseStore()
+	a := NewLeaderElector(store, "reminders", "a")
+	b := NewLeaderElector(store, "reminders", "b")
+	a.TTL = 30 * time.Millisecond
+	b.TTL = 30 * time.Millisecond
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	aStarted, stopA := make(chan struct{}), make(chan struct{})
+	aDone := make(chan error, 1)
+	go func() {
+		aDone <- a.Run(ctx, func(ctx context.Context) error {
+			close(aStarted)
+			<-stopA
+			return nil
+		})
+	}()
+	<-aStarted
+
+	bStarted := make(chan struct{})
+	bDone := make(chan error, 1)
+	go func() {
+		bDone <- b.Run(ctx, func(ctx context.Context) error {
+			close(bStarted)
+			<-ctx.Done()
+			return nil
+		})
+	}()
+
+	// b stands by for several renewals of a's lease.
+	select {
+	case <-bStarted:
+		t.Fatal("Expected only one elector to run the job")
+	case <-time.After(100 * time.Millisecond):
+	}
+	if !a.IsLeader() || b.IsLeader() {
+		t.Errorf("Expected a to lead, got a=%v b=%v", a.IsLeader(), b.IsLeader())
+	}
+
+	// When a finishes, it releases the lease and b takes over.
+	close(stopA)
+	if err := <-aDone; err != nil {
+		t.Errorf("Expected a to return nil, got %v", err)
+	}
+	select {
+	case <-bStarted:
+	case <-time.After(time.Second):
+		t.Fatal("Expected b to take over")
+	}
+
+	cancel()
+	if err := <-bDone; err != context.Canceled {
+		t.Errorf("Expected b to return context.Canceled, got %v", err)
+	}
+}
+
+func TestLeaderElector_LostLease(t *testing.T) {
+	store := NewMemoryLeaseStore()
+	e := NewLeaderElector(store, "hold", "a")
+	e.TTL = 30 * time.Millisecond
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	runs := make(chan struct{}, 2)
+	done := make(chan error, 1)
+	go func() {
+		done <- e.Run(ctx, func(ctx context.Context) error {
+			runs <- struct{}{}
+			<-ctx.Done()
+			return ctx.Err()
+		})
+	}()
+	<-runs
+
+	// Another holder takes over the lease, e.g. after a network partition.
+	store.mu.Lock()
+	store.leases["hold"] = memoryLease{holder: "b", expires: time.Now().Add(50 * time.Millisecond)}
+	store.mu.Unlock()
+
+	select {
+	case <-runs:
+	case <-time.After(time.Second):
+		t.Fatal("Expected the job to be restarted after the lease expired")
+	}
+	cancel()
+	<-done
+}
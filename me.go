@@ -2,6 +2,7 @@ package chatwork
 
 import (
 	"context"
+	"fmt"
 )
 
 // MeService handles communication with the "me" related
@@ -16,19 +17,13 @@ type MeService service
 // visible when viewing other users' profiles.
 //
 // ChatWork API docs: https://developer.chatwork.com/reference/get-me
-func (s *MeService) Get(ctx context.Context) (*Me, *Response, error) {
+func (s *MeService) Get(ctx context.Context, opts ...RequestOption) (*Me, *Response, error) {
 	req, err := s.client.NewRequest("GET", "me", nil)
 	if err != nil {
 		return nil, nil, err
 	}
 
-	me := new(Me)
-	resp, err := s.client.Do(ctx, req, me)
-	if err != nil {
-		return nil, resp, err
-	}
-
-	return me, resp, nil
+	return Do[Me](ctx, s.client, req, opts...)
 }
 
 // GetStatus returns the authenticated user's unread counts.
@@ -40,17 +35,78 @@ func (s *MeService) Get(ctx context.Context) (*Me, *Response, error) {
 // - Tasks
 //
 // ChatWork API docs: https://developer.chatwork.com/reference/get-my-status
-func (s *MeService) GetStatus(ctx context.Context) (*MyStatus, *Response, error) {
+func (s *MeService) GetStatus(ctx context.Context, opts ...RequestOption) (*MyStatus, *Response, error) {
 	req, err := s.client.NewRequest("GET", "my/status", nil)
 	if err != nil {
 		return nil, nil, err
 	}
 
-	status := new(MyStatus)
-	resp, err := s.client.Do(ctx, req, status)
+	return Do[MyStatus](ctx, s.client, req, opts...)
+}
+
+// MarkAllAsReadParams represents the parameters for MeService.MarkAllAsRead.
+type MarkAllAsReadParams struct {
+	// Number of rooms marked as read at the same time. Defaults to 4.
+	Concurrency int
+}
+
+// MarkAsReadResult is the outcome of marking a room as read.
+type MarkAsReadResult struct {
+	RoomID RoomID
+	Name   string
+
+	// Counts before the room was marked as read
+	UnreadNum  int
+	MentionNum int
+
+	// Why the room was not marked as read, if it was not
+	Err error
+}
+
+// MarkAllAsRead marks every room with unread messages as read and returns
+// the result for each of them. params may be nil.
+//
+// The rooms are marked as read with Batch: if ChatWork rate limits a request,
+// or ctx is done, the rooms not yet started are skipped, with that error as
+// their result, rather than adding to the load.
+//
+// Failures in individual rooms do not stop the others. They are joined into
+// the returned error.
+func (s *MeService) MarkAllAsRead(ctx context.Context, params *MarkAllAsReadParams, opts ...RequestOption) ([]*MarkAsReadResult, error) {
+	rooms, _, err := s.client.Rooms.List(ctx, opts...)
 	if err != nil {
-		return nil, resp, err
+		return nil, err
+	}
+	var concurrency int
+	if params != nil {
+		concurrency = params.Concurrency
+	}
+
+	var results []*MarkAsReadResult
+	var jobs []BatchJob
+	for _, room := range rooms {
+		if !room.HasUnread() {
+			continue
+		}
+		result := &MarkAsReadResult{
+			RoomID:     room.RoomID,
+			Name:       room.Name,
+			UnreadNum:  room.UnreadNum,
+			MentionNum: room.MentionNum,
+		}
+		results = append(results, result)
+		jobs = append(jobs, BatchJob{
+			Name: fmt.Sprintf("room %d", result.RoomID),
+			Run: func(ctx context.Context, client *Client) error {
+				_, _, err := client.Rooms.MarkMessagesAsRead(ctx, result.RoomID, "", opts...)
+				return err
+			},
+		})
 	}
 
-	return status, resp, nil
+	batch, err := Batch(ctx, s.client, jobs, &BatchOptions{Concurrency: concurrency})
+	for i, result := range batch {
+		results[i].Err = result.Err
+	}
+	return results, err
 }
@@ -0,0 +1,44 @@
+package chatwork_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/nashirox/chatwork-go"
+	"github.com/nashirox/chatwork-go/chatworktest"
+)
+
+func TestMeService_MarkAllAsRead(t *testing.T) {
+	srv := chatworktest.NewServer()
+	defer srv.Close()
+
+	unread := srv.AddRoom(chatwork.Room{Name: "Unread", UnreadNum: 3, MentionNum: 1})
+	failing := srv.AddRoom(chatwork.Room{Name: "Failing", UnreadNum: 1})
+	srv.AddRoom(chatwork.Room{Name: "Read"})
+	srv.Fail("PUT", "/rooms/"+failing.String()+"/messages/read", http.StatusForbidden)
+
+	results, err := srv.Client().Me.MarkAllAsRead(context.Background(), &chatwork.MarkAllAsReadParams{Concurrency: 2})
+	if !errors.Is(err, chatwork.ErrForbidden) {
+		t.Errorf("Expected the failure to be returned, got %v", err)
+	}
+	if len(results) != 2 {
+		t.Fatalf("Expected results for the 2 unread rooms, got %d", len(results))
+	}
+	for _, r := range results {
+		switch r.RoomID {
+		case unread:
+			if r.Err != nil || r.UnreadNum != 3 || r.MentionNum != 1 {
+				t.Errorf("Unexpected result: %+v", r)
+			}
+		case failing:
+			if !errors.Is(r.Err, chatwork.ErrForbidden) {
+				t.Errorf("Expected a forbidden error, got %v", r.Err)
+			}
+		}
+	}
+	if room, _ := srv.Room(unread); room.UnreadNum != 0 {
+		t.Errorf("Expected the room to be read, got %d unread", room.UnreadNum)
+	}
+}
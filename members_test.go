@@ -0,0 +1,172 @@
+package chatwork_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/nashirox/chatwork-go"
+	"github.com/nashirox/chatwork-go/chatworktest"
+)
+
+func TestRoomsService_AddRemoveMembers(t *testing.T) {
+	srv := chatworktest.NewServer()
+	defer srv.Close()
+
+	roomID := srv.AddRoom(chatwork.Room{Name: "Team", Role: "admin"})
+	srv.AddMember(roomID, chatwork.Member{AccountID: 200, Role: "member"})
+	client := srv.Client()
+	ctx := context.Background()
+
+	roles := func() map[chatwork.AccountID]chatwork.Role {
+		roles := make(map[chatwork.AccountID]chatwork.Role)
+		for _, m := range srv.Members(roomID) {
+			roles[m.AccountID] = m.Role
+		}
+		return roles
+	}
+	var me chatwork.AccountID
+	for id := range roles() {
+		if id != 200 {
+			me = id
+		}
+	}
+
+	result, _, err := client.Rooms.AddMembers(ctx, roomID, []chatwork.AccountID{300, 400}, "readonly")
+	if err != nil {
+		t.Fatalf("AddMembers returned error: %v", err)
+	}
+	if !reflect.DeepEqual(result.Readonly, []chatwork.AccountID{300, 400}) || !reflect.DeepEqual(result.Member, []chatwork.AccountID{200}) {
+		t.Errorf("Unexpected update result %+v", result)
+	}
+	want := map[chatwork.AccountID]chatwork.Role{me: "admin", 200: "member", 300: "readonly", 400: "readonly"}
+	if got := roles(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Expected members %v, got %v", want, got)
+	}
+
+	if _, _, err := client.Rooms.RemoveMembers(ctx, roomID, []chatwork.AccountID{300}); err != nil {
+		t.Fatalf("RemoveMembers returned error: %v", err)
+	}
+	delete(want, 300)
+	if got := roles(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Expected members %v, got %v", want, got)
+	}
+
+	if _, _, err := client.Rooms.RemoveMembers(ctx, roomID, []chatwork.AccountID{me}); err == nil {
+		t.Error("Expected an error when removing the last admin")
+	}
+	if _, _, err := client.Rooms.AddMembers(ctx, roomID, []chatwork.AccountID{500}, "owner"); err == nil {
+		t.Error("Expected an error for an invalid role")
+	}
+	if got := roles(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Expected failed changes to leave members %v, got %v", want, got)
+	}
+}
+
+func TestRoomsService_ChangeMemberRole(t *testing.T) {
+	srv := chatworktest.NewServer()
+	defer srv.Close()
+
+	roomID := srv.AddRoom(chatwork.Room{Name: "Team", Role: "admin"})
+	srv.AddMember(roomID, chatwork.Member{AccountID: 200, Role: "member"})
+	client := srv.Client()
+	ctx := context.Background()
+
+	if _, _, err := client.Rooms.ChangeMemberRole(ctx, roomID, 200, "admin"); err != nil {
+		t.Fatalf("ChangeMemberRole returned error: %v", err)
+	}
+	for _, m := range srv.Members(roomID) {
+		if m.Role != "admin" {
+			t.Errorf("Expected every member to be an admin, got %+v", m)
+		}
+	}
+
+	if _, _, err := client.Rooms.ChangeMemberRole(ctx, roomID, 300, "admin"); !errors.Is(err, chatwork.ErrNotFound) {
+		t.Errorf("Expected ErrNotFound for a non-member, got %v", err)
+	}
+	if _, _, err := client.Rooms.ChangeMemberRole(ctx, roomID, 200, "owner"); err == nil {
+		t.Error("Expected an error for an invalid role")
+	}
+}
+
+func TestRoomsService_SyncMembers(t *testing.T) {
+	srv := chatworktest.NewServer()
+	defer srv.Close()
+
+	team := srv.AddRoom(chatwork.Room{Name: "Team", Role: "admin"})
+	srv.AddMember(team, chatwork.Member{AccountID: 200, Role: "member"})
+	srv.AddMember(team, chatwork.Member{AccountID: 300, Role: "admin"})
+	project := srv.AddRoom(chatwork.Room{Name: "Project", Role: "admin"})
+	srv.AddMember(project, chatwork.Member{AccountID: 300, Role: "member"})
+	srv.AddMember(project, chatwork.Member{AccountID: 400, Role: "member"})
+	client := srv.Client()
+	ctx := context.Background()
+
+	want := &chatwork.MemberDiff{Added: []chatwork.AccountID{200}, Removed: []chatwork.AccountID{400}, RoleChanged: []chatwork.AccountID{300}}
+	diff, _, err := client.Rooms.DiffMembers(ctx, project, team)
+	if err != nil {
+		t.Fatalf("DiffMembers returned error: %v", err)
+	}
+	if !reflect.DeepEqual(diff, want) {
+		t.Errorf("Expected diff %+v, got %+v", want, diff)
+	}
+
+	diff, _, err = client.Rooms.SyncMembers(ctx, team, project, &chatwork.SyncMembersParams{KeepExtra: true})
+	if err != nil {
+		t.Fatalf("SyncMembers returned error: %v", err)
+	}
+	if want := (&chatwork.MemberDiff{Added: []chatwork.AccountID{200}, RoleChanged: []chatwork.AccountID{300}}); !reflect.DeepEqual(diff, want) {
+		t.Errorf("Expected diff %+v, got %+v", want, diff)
+	}
+
+	if diff, _, err = client.Rooms.SyncMembers(ctx, team, project, nil); err != nil {
+		t.Fatalf("SyncMembers returned error: %v", err)
+	}
+	if want := (&chatwork.MemberDiff{Removed: []chatwork.AccountID{400}}); !reflect.DeepEqual(diff, want) {
+		t.Errorf("Expected diff %+v, got %+v", want, diff)
+	}
+	if diff, _, err := client.Rooms.DiffMembers(ctx, project, team); err != nil || !diff.Empty() {
+		t.Errorf("Expected the rooms to have the same members, got %+v, %v", diff, err)
+	}
+}
+
+func TestOptionMemberUpdateRetries(t *testing.T) {
+	srv := chatworktest.NewServer()
+	defer srv.Close()
+
+	roomID := srv.AddRoom(chatwork.Room{Name: "Team", Role: "admin"})
+	reads := 0
+	concurrent := 0
+	client := srv.Client(chatwork.OptionMemberUpdateRetries(1), chatwork.OptionOnRequest(func(r *http.Request) {
+		if r.Method != "GET" {
+			return
+		}
+		// Another admin adds a member before the check of each of the
+		// first two attempts.
+		if reads++; reads%2 == 0 && concurrent < 2 {
+			concurrent++
+			srv.AddMember(roomID, chatwork.Member{AccountID: chatwork.AccountID(900 + concurrent), Role: "member"})
+		}
+	}))
+	ctx := context.Background()
+
+	_, _, err := client.Rooms.AddMembers(ctx, roomID, []chatwork.AccountID{200}, "member")
+	if !errors.Is(err, chatwork.ErrMembersChanged) {
+		t.Fatalf("Expected ErrMembersChanged, got %v", err)
+	}
+	if _, _, err := client.Rooms.AddMembers(ctx, roomID, []chatwork.AccountID{200}, "member"); err != nil {
+		t.Fatalf("AddMembers returned error: %v", err)
+	}
+
+	ids := make(map[chatwork.AccountID]bool)
+	for _, m := range srv.Members(roomID) {
+		ids[m.AccountID] = true
+	}
+	for _, id := range []chatwork.AccountID{200, 901, 902} {
+		if !ids[id] {
+			t.Errorf("Expected account %d to be a member, got %v", id, ids)
+		}
+	}
+}
@@ -0,0 +1,54 @@
+package chatwork
+
+// Mentions lists who a message mentions.
+type Mentions struct {
+	// Accounts mentioned with [To:], without duplicates, in order of appearance
+	AccountIDs []AccountID
+
+	// Whether the message mentions everyone with [toall]
+	ToAll bool
+}
+
+// Includes reports whether accountID is mentioned, individually or with [toall].
+func (m Mentions) Includes(accountID AccountID) bool {
+	if m.ToAll {
+		return true
+	}
+	for _, id := range m.AccountIDs {
+		if id == accountID {
+			return true
+		}
+	}
+	return false
+}
+
+// ExtractMentions returns the mentions in a message body. Tags inside quotes
+// and code blocks are not mentions and are ignored. Replies are not included;
+// use ParseMessage to find them.
+func ExtractMentions(body string) Mentions {
+	var m Mentions
+	seen := make(map[AccountID]bool)
+	collectMentions(ParseMessage(body), &m, seen)
+	return m
+}
+
+func collectMentions(nodes []*Node, m *Mentions, seen map[AccountID]bool) {
+	for _, n := range nodes {
+		switch n.Type {
+		case NodeTo:
+			if !seen[n.AccountID] {
+				seen[n.AccountID] = true
+				m.AccountIDs = append(m.AccountIDs, n.AccountID)
+			}
+		case NodeToAll:
+			m.ToAll = true
+		case NodeInfo, NodeTitle:
+			collectMentions(n.Children, m, seen)
+		}
+	}
+}
+
+// Mentions returns the mentions in the message body.
+func (m *Message) Mentions() Mentions {
+	return ExtractMentions(m.Body)
+}
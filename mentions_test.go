@@ -0,0 +1,32 @@
+package chatwork
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestExtractMentions(t *testing.T) {
+	tests := []struct {
+		body string
+		want Mentions
+	}{
+		{"hello", Mentions{}},
+		{"[To:1]Alice [To:2]Bob [To:1]", Mentions{AccountIDs: []AccountID{1, 2}}},
+		{"[toall]\n[info][To:3][/info]", Mentions{AccountIDs: []AccountID{3}, ToAll: true}},
+		{"[qt][qtmeta aid=4 time=1][To:5][/qt][code][To:6][/code][rp aid=7 to=1-2]", Mentions{}},
+	}
+
+	for _, tt := range tests {
+		if got := ExtractMentions(tt.body); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("ExtractMentions(%q) = %+v, want %+v", tt.body, got, tt.want)
+		}
+	}
+
+	message := &Message{Body: "[To:1] deploy?"}
+	if !message.Mentions().Includes(1) || message.Mentions().Includes(2) {
+		t.Errorf("Unexpected mentions: %+v", message.Mentions())
+	}
+	if !ExtractMentions("[toall]").Includes(2) {
+		t.Error("Expected [toall] to include every account")
+	}
+}
@@ -0,0 +1,135 @@
+package chatwork
+
+import (
+	"fmt"
+	"strings"
+)
+
+// MessageBuilder builds message bodies in ChatWork notation. Blocks that
+// contain other content, such as Info and Quote, take a function that adds
+// their contents, so tags are always closed in the right order:
+//
+//	body := chatwork.NewMessageBuilder().
+//		To(123456).
+//		Info(func(b *chatwork.MessageBuilder) {
+//			b.Title("Deploy finished").Text("Version 1.2.3 is live.").HR().Code(log)
+//		}).
+//		String()
+//
+// The zero value is an empty builder ready to use.
+type MessageBuilder struct {
+	b strings.Builder
+}
+
+// NewMessageBuilder returns an empty MessageBuilder.
+func NewMessageBuilder() *MessageBuilder {
+	return &MessageBuilder{}
+}
+
+// Text appends text as is.
+func (m *MessageBuilder) Text(text string) *MessageBuilder {
+	m.b.WriteString(text)
+	return m
+}
+
+// Textf appends text formatted with fmt.Sprintf.
+func (m *MessageBuilder) Textf(format string, args ...interface{}) *MessageBuilder {
+	fmt.Fprintf(&m.b, format, args...)
+	return m
+}
+
+// Line appends text followed by a line break.
+func (m *MessageBuilder) Line(text string) *MessageBuilder {
+	m.b.WriteString(text)
+	m.b.WriteByte('\n')
+	return m
+}
+
+// To mentions each of the accounts, notifying them of the message.
+func (m *MessageBuilder) To(accountIDs ...AccountID) *MessageBuilder {
+	for _, id := range accountIDs {
+		fmt.Fprintf(&m.b, "[To:%d]", id)
+	}
+	if len(accountIDs) > 0 {
+		m.b.WriteByte('\n')
+	}
+	return m
+}
+
+// ToAll mentions every member of the room.
+func (m *MessageBuilder) ToAll() *MessageBuilder {
+	m.b.WriteString("[toall]\n")
+	return m
+}
+
+// Info appends an info block with the contents added by content.
+func (m *MessageBuilder) Info(content func(b *MessageBuilder)) *MessageBuilder {
+	m.b.WriteString("[info]")
+	content(m)
+	m.b.WriteString("[/info]")
+	return m
+}
+
+// Title appends the title of an info block. It belongs at the start of the
+// contents passed to Info.
+func (m *MessageBuilder) Title(title string) *MessageBuilder {
+	fmt.Fprintf(&m.b, "[title]%s[/title]", title)
+	return m
+}
+
+// Code appends a code block with code shown as is. Tags in code, such as a
+// [/code] that would end the block early, are escaped with EscapeTags.
+func (m *MessageBuilder) Code(code string) *MessageBuilder {
+	fmt.Fprintf(&m.b, "[code]%s[/code]", EscapeTags(code))
+	return m
+}
+
+// Quote appends a quote of a message posted by accountID at sendTime (Unix
+// seconds), with the contents added by content. A zero accountID quotes
+// without attribution.
+func (m *MessageBuilder) Quote(accountID AccountID, sendTime int64, content func(b *MessageBuilder)) *MessageBuilder {
+	m.b.WriteString("[qt]")
+	if accountID != 0 {
+		fmt.Fprintf(&m.b, "[qtmeta aid=%d time=%d]", accountID, sendTime)
+	}
+	content(m)
+	m.b.WriteString("[/qt]")
+	return m
+}
+
+// HR appends a horizontal rule.
+func (m *MessageBuilder) HR() *MessageBuilder {
+	m.b.WriteString("[hr]")
+	return m
+}
+
+// String returns the message body.
+func (m *MessageBuilder) String() string {
+	return m.b.String()
+}
+
+// Params returns parameters for MessagesService.Create with the message body.
+func (m *MessageBuilder) Params() *MessageCreateParams {
+	return &MessageCreateParams{Body: m.String()}
+}
+
+// notationTags are the names of the tags that ChatWork interprets.
+var notationTags = map[string]bool{
+	"to": true, "toall": true, "rp": true, "qt": true, "qtmeta": true,
+	"info": true, "title": true, "code": true, "picon": true, "piconname": true,
+	"hr": true,
+}
+
+// EscapeTags keeps the tags of ChatWork notation in s from being interpreted
+// by inserting a zero-width space after their opening bracket, so that they
+// are displayed as written. Other bracketed text, such as [recovered] in a
+// stack trace, is left unchanged.
+func EscapeTags(s string) string {
+	return tagPattern.ReplaceAllStringFunc(s, func(tag string) string {
+		m := tagPattern.FindStringSubmatch(tag)
+		if !notationTags[strings.ToLower(m[2])] {
+			return tag
+		}
+		return "[\u200b" + tag[1:]
+	})
+}
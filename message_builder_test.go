@@ -0,0 +1,60 @@
+package chatwork
+
+import "testing"
+
+func TestMessageBuilder(t *testing.T) {
+	tests := []struct {
+		name  string
+		build func(b *MessageBuilder)
+		want  string
+	}{
+		{
+			name: "mentions and text",
+			build: func(b *MessageBuilder) {
+				b.To(1, 2).Textf("%d tasks are overdue", 3)
+			},
+			want: "[To:1][To:2]\n3 tasks are overdue",
+		},
+		{
+			name: "nested blocks",
+			build: func(b *MessageBuilder) {
+				b.ToAll().Info(func(b *MessageBuilder) {
+					b.Title("Deploy").Line("done").HR().Code("[info]not a tag[/code] []string")
+				})
+			},
+			want: "[toall]\n[info][title]Deploy[/title]done\n[hr][code][\u200binfo]not a tag[\u200b/code] []string[/code][/info]",
+		},
+		{
+			name: "quotes",
+			build: func(b *MessageBuilder) {
+				b.Quote(10, 1700000000, func(b *MessageBuilder) {
+					b.Quote(0, 0, func(b *MessageBuilder) { b.Text("inner") })
+				}).Text("agreed")
+			},
+			want: "[qt][qtmeta aid=10 time=1700000000][qt]inner[/qt][/qt]agreed",
+		},
+	}
+
+	for _, tt := range tests {
+		var b MessageBuilder
+		tt.build(&b)
+		if got := b.String(); got != tt.want {
+			t.Errorf("%s: expected %q, got %q", tt.name, tt.want, got)
+		}
+		if got := b.Params().Body; got != tt.want {
+			t.Errorf("%s: expected params body %q, got %q", tt.name, tt.want, got)
+		}
+	}
+}
+
+func TestEscapeTags(t *testing.T) {
+	in := "[To:1] panic: boom [recovered]\n[CODE][/code]"
+	want := "[\u200bTo:1] panic: boom [recovered]\n[\u200bCODE][\u200b/code]"
+	got := EscapeTags(in)
+	if got != want {
+		t.Errorf("Expected %q, got %q", want, got)
+	}
+	if nodes := ParseMessage("[code]" + got + "[/code]"); len(nodes) != 1 || nodes[0].Type != NodeCode {
+		t.Errorf("Expected a single code block, got %+v", nodes)
+	}
+}
@@ -0,0 +1,219 @@
+package chatwork
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// NodeType identifies the kind of a Node.
+type NodeType int
+
+// Node types produced by ParseMessage.
+const (
+	NodeText  NodeType = iota // plain text
+	NodeTo                    // [To:aid], a mention
+	NodeToAll                 // [toall]
+	NodeReply                 // [rp aid=... to=room-message]
+	NodeQuote                 // [qt][qtmeta aid=... time=...]...[/qt]
+	NodeInfo                  // [info]...[/info]
+	NodeTitle                 // [title]...[/title]
+	NodeCode                  // [code]...[/code]
+	NodePicon                 // [picon:aid] or [piconname:aid]
+	NodeHR                    // [hr]
+)
+
+// Node is an element of a message body parsed by ParseMessage.
+type Node struct {
+	Type NodeType
+
+	// Text of NodeText, verbatim contents of NodeCode
+	Text string
+
+	// Account mentioned (NodeTo), replied to (NodeReply), quoted (NodeQuote),
+	// or shown (NodePicon)
+	AccountID AccountID
+
+	// Message replied to (NodeReply), when the tag names it
+	RoomID    RoomID
+	MessageID MessageID
+
+	// Send time of the quoted message in Unix seconds (NodeQuote)
+	Time int64
+
+	// Contents of NodeQuote, NodeInfo, and NodeTitle
+	Children []*Node
+}
+
+// tagPattern matches a single notation tag, such as [To:1], [/info], or
+// [rp aid=1 to=2-3].
+var tagPattern = regexp.MustCompile(`\[(/?)([A-Za-z]+)(?::(\d+))?((?:\s+[a-z]+=[^\s\]]*)*)\]`)
+
+// ParseMessage parses a message body in ChatWork notation into nodes.
+//
+// Parsing never fails: unknown tags, closing tags without an opening one,
+// and blocks that are never closed are kept as text, so that no part of the
+// body is lost. The contents of [code] blocks are not parsed.
+func ParseMessage(body string) []*Node {
+	root := &Node{}
+	stack := []*Node{root}
+	raws := []string{""} // opening tags of the nodes on the stack
+
+	top := func() *Node { return stack[len(stack)-1] }
+
+	pos := 0
+	for pos < len(body) {
+		loc := tagPattern.FindStringSubmatchIndex(body[pos:])
+		if loc == nil {
+			break
+		}
+		start, end := pos+loc[0], pos+loc[1]
+		raw := body[start:end]
+		closing := loc[3] > loc[2]
+		name := strings.ToLower(body[pos+loc[4] : pos+loc[5]])
+		id := 0
+		if loc[6] >= 0 {
+			id, _ = strconv.Atoi(body[pos+loc[6] : pos+loc[7]])
+		}
+		attrs := parseTagAttributes(body[pos+loc[8] : pos+loc[9]])
+
+		appendText(top(), body[pos:start])
+		pos = end
+
+		switch {
+		case closing:
+			if typ, ok := containerTypes[name]; ok && len(stack) > 1 && top().Type == typ {
+				stack = stack[:len(stack)-1]
+				raws = raws[:len(raws)-1]
+				continue
+			}
+		case name == "to" && id != 0:
+			appendNode(top(), &Node{Type: NodeTo, AccountID: AccountID(id)})
+			continue
+		case name == "toall" && id == 0:
+			appendNode(top(), &Node{Type: NodeToAll})
+			continue
+		case (name == "picon" || name == "piconname") && id != 0:
+			appendNode(top(), &Node{Type: NodePicon, AccountID: AccountID(id)})
+			continue
+		case name == "hr" && id == 0:
+			appendNode(top(), &Node{Type: NodeHR})
+			continue
+		case name == "rp":
+			reply := &Node{Type: NodeReply}
+			aid, _ := strconv.Atoi(attrs["aid"])
+			reply.AccountID = AccountID(aid)
+			if roomID, messageID, ok := strings.Cut(attrs["to"], "-"); ok {
+				rid, _ := strconv.Atoi(roomID)
+				reply.RoomID = RoomID(rid)
+				reply.MessageID = MessageID(messageID)
+			}
+			appendNode(top(), reply)
+			continue
+		case name == "qtmeta":
+			if q := top(); q.Type == NodeQuote && len(q.Children) == 0 && q.AccountID == 0 {
+				aid, _ := strconv.Atoi(attrs["aid"])
+				q.AccountID = AccountID(aid)
+				q.Time, _ = strconv.ParseInt(attrs["time"], 10, 64)
+				raws[len(raws)-1] += raw
+				continue
+			}
+		case name == "code" && id == 0:
+			if n := strings.Index(strings.ToLower(body[pos:]), "[/code]"); n >= 0 {
+				appendNode(top(), &Node{Type: NodeCode, Text: body[pos : pos+n]})
+				pos += n + len("[/code]")
+				continue
+			}
+		default:
+			if typ, ok := containerTypes[name]; ok && id == 0 {
+				node := &Node{Type: typ}
+				appendNode(top(), node)
+				stack = append(stack, node)
+				raws = append(raws, raw)
+				continue
+			}
+		}
+
+		appendText(top(), raw)
+	}
+	appendText(top(), body[pos:])
+
+	// Blocks that were never closed are turned back into text.
+	for len(stack) > 1 {
+		node, raw := top(), raws[len(raws)-1]
+		stack = stack[:len(stack)-1]
+		raws = raws[:len(raws)-1]
+
+		parent := top()
+		parent.Children = parent.Children[:len(parent.Children)-1]
+		appendText(parent, raw)
+		for _, child := range node.Children {
+			if child.Type == NodeText {
+				appendText(parent, child.Text)
+			} else {
+				appendNode(parent, child)
+			}
+		}
+	}
+
+	return root.Children
+}
+
+// containerTypes maps the names of tags that enclose other nodes to their types.
+var containerTypes = map[string]NodeType{
+	"qt":    NodeQuote,
+	"info":  NodeInfo,
+	"title": NodeTitle,
+}
+
+// parseTagAttributes parses the key=value pairs of a tag.
+func parseTagAttributes(s string) map[string]string {
+	attrs := make(map[string]string)
+	for _, field := range strings.Fields(s) {
+		if key, value, ok := strings.Cut(field, "="); ok {
+			attrs[key] = value
+		}
+	}
+	return attrs
+}
+
+func appendNode(parent, node *Node) {
+	parent.Children = append(parent.Children, node)
+}
+
+// appendText appends text to parent, merging it with a preceding text node.
+func appendText(parent *Node, text string) {
+	if text == "" {
+		return
+	}
+	if n := len(parent.Children); n > 0 && parent.Children[n-1].Type == NodeText {
+		parent.Children[n-1].Text += text
+		return
+	}
+	parent.Children = append(parent.Children, &Node{Type: NodeText, Text: text})
+}
+
+// PlainText returns the text of nodes without markup: the text of quotes,
+// info blocks, and code blocks is kept, titles and rules end a line, and
+// mentions, replies, and icons are dropped.
+func PlainText(nodes []*Node) string {
+	var b strings.Builder
+	writePlainText(&b, nodes)
+	return b.String()
+}
+
+func writePlainText(b *strings.Builder, nodes []*Node) {
+	for _, n := range nodes {
+		switch n.Type {
+		case NodeText, NodeCode:
+			b.WriteString(n.Text)
+		case NodeTitle:
+			writePlainText(b, n.Children)
+			b.WriteByte('\n')
+		case NodeHR:
+			b.WriteByte('\n')
+		case NodeQuote, NodeInfo:
+			writePlainText(b, n.Children)
+		}
+	}
+}
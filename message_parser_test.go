@@ -0,0 +1,60 @@
+package chatwork
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseMessage(t *testing.T) {
+	body := "[rp aid=10 to=100-2000] [To:11][To:12]Bob\n" +
+		"[qt][qtmeta aid=13 time=1700000000]quoted [b]text[/qt]" +
+		"[info][title]Build [picon:14][/title]failed[hr][code][To:99] [info][/code][/info]" +
+		"[toall] [/info] [title]open"
+
+	want := []*Node{
+		{Type: NodeReply, AccountID: 10, RoomID: 100, MessageID: "2000"},
+		{Type: NodeText, Text: " "},
+		{Type: NodeTo, AccountID: 11},
+		{Type: NodeTo, AccountID: 12},
+		{Type: NodeText, Text: "Bob\n"},
+		{Type: NodeQuote, AccountID: 13, Time: 1700000000, Children: []*Node{
+			{Type: NodeText, Text: "quoted [b]text"},
+		}},
+		{Type: NodeInfo, Children: []*Node{
+			{Type: NodeTitle, Children: []*Node{
+				{Type: NodeText, Text: "Build "},
+				{Type: NodePicon, AccountID: 14},
+			}},
+			{Type: NodeText, Text: "failed"},
+			{Type: NodeHR},
+			{Type: NodeCode, Text: "[To:99] [info]"},
+		}},
+		{Type: NodeToAll},
+		{Type: NodeText, Text: " [/info] [title]open"},
+	}
+
+	got := ParseMessage(body)
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Unexpected nodes:")
+		for i, n := range got {
+			t.Logf("%d: %+v", i, *n)
+		}
+	}
+
+	if text := PlainText(got); text != " Bob\nquoted [b]textBuild \nfailed\n[To:99] [info] [/info] [title]open" {
+		t.Errorf("Unexpected plain text: %q", text)
+	}
+}
+
+func TestParseMessage_Unclosed(t *testing.T) {
+	got := ParseMessage("[info][title]Title[/title][To:1]body")
+	want := []*Node{
+		{Type: NodeText, Text: "[info]"},
+		{Type: NodeTitle, Children: []*Node{{Type: NodeText, Text: "Title"}}},
+		{Type: NodeTo, AccountID: 1},
+		{Type: NodeText, Text: "body"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Unexpected nodes: %+v", got)
+	}
+}
@@ -0,0 +1,214 @@
+package chatwork
+
+import (
+	"context"
+	"strings"
+	"unicode/utf8"
+)
+
+// MaxMessageLength is the number of characters that SendLong puts in each
+// message, the longest body ChatWork accepts.
+const MaxMessageLength = 65535
+
+// SendLong sends body to a room, split with SplitMessage into as many
+// messages of up to MaxMessageLength characters as needed, in order. It
+// returns the IDs of the created messages. If sending fails, the IDs of the
+// messages sent before the failure are returned with the error.
+func (s *MessagesService) SendLong(ctx context.Context, roomID RoomID, body string, opts ...RequestOption) ([]MessageID, *Response, error) {
+	var (
+		ids  []MessageID
+		resp *Response
+	)
+	parts := SplitMessage(body, MaxMessageLength)
+	budget := NewBudget(ctx, len(parts))
+	for _, part := range parts {
+		params := &MessageCreateParams{
+			Body: part,
+		}
+		partCtx, cancel := budget.Next()
+		created, r, err := s.Create(partCtx, roomID, params, opts...)
+		cancel()
+		resp = r
+		if err != nil {
+			return ids, resp, err
+		}
+		ids = append(ids, created.MessageID)
+	}
+	return ids, resp, nil
+}
+
+// SplitMessage splits body into parts of at most limit characters.
+//
+// Parts end at line breaks where possible, and [code], [info], [qt], and
+// [title] blocks are kept whole, so that each part renders on its own. A
+// block longer than limit is split at line breaks too: a code block is
+// closed and reopened in each part, while other blocks are split as text.
+// Lines longer than limit are split within the line.
+func SplitMessage(body string, limit int) []string {
+	if limit <= 0 || utf8.RuneCountInString(body) <= limit {
+		return []string{body}
+	}
+
+	var (
+		parts []string
+		cur   strings.Builder
+		n     int // characters in cur
+	)
+	flush := func() {
+		if n > 0 {
+			parts = append(parts, strings.TrimSuffix(cur.String(), "\n"))
+			cur.Reset()
+			n = 0
+		}
+	}
+	// addText appends text, splitting it at line breaks where it does not fit.
+	var addText func(text string)
+	addText = func(text string) {
+		for text != "" {
+			size := utf8.RuneCountInString(text)
+			if n+size <= limit {
+				cur.WriteString(text)
+				n += size
+				return
+			}
+			head := truncateRunes(text, limit-n)
+			if i := strings.LastIndexByte(head, '\n'); i >= 0 {
+				head = head[:i+1]
+			} else if n > 0 {
+				// Start a new part rather than breaking the line.
+				flush()
+				continue
+			}
+			cur.WriteString(head)
+			n += utf8.RuneCountInString(head)
+			flush()
+			text = text[len(head):]
+		}
+	}
+
+	for _, seg := range splitSegments(body) {
+		size := utf8.RuneCountInString(seg.text)
+		switch {
+		case !seg.block:
+			addText(seg.text)
+		case n+size <= limit:
+			cur.WriteString(seg.text)
+			n += size
+		case size <= limit:
+			flush()
+			cur.WriteString(seg.text)
+			n = size
+		case seg.code && limit > len("[code][/code]"):
+			flush()
+			for _, chunk := range SplitMessage(seg.inner, limit-len("[code][/code]")) {
+				parts = append(parts, "[code]"+chunk+"[/code]")
+			}
+		default:
+			addText(seg.text)
+		}
+	}
+	flush()
+	return parts
+}
+
+// messageSegment is a top-level block of a message body, or the text
+// between blocks.
+type messageSegment struct {
+	text  string
+	block bool
+	code  bool
+	inner string // contents of a code block
+}
+
+// splitSegments divides body into top-level blocks and the text between them.
+// Blocks that are never closed are part of the text.
+func splitSegments(body string) []messageSegment {
+	var segs []messageSegment
+	text := 0 // start of the pending text
+	pos := 0
+	for pos < len(body) {
+		loc := tagPattern.FindStringSubmatchIndex(body[pos:])
+		if loc == nil {
+			break
+		}
+		start, end := pos+loc[0], pos+loc[1]
+		closing := loc[3] > loc[2]
+		name := strings.ToLower(body[pos+loc[4] : pos+loc[5]])
+		pos = end
+		if closing || loc[6] >= 0 {
+			continue
+		}
+
+		var blockEnd int
+		seg := messageSegment{block: true}
+		if name == "code" {
+			i := strings.Index(strings.ToLower(body[end:]), "[/code]")
+			if i < 0 {
+				continue
+			}
+			blockEnd = end + i + len("[/code]")
+			seg.code = true
+			seg.inner = body[end : end+i]
+		} else if _, ok := containerTypes[name]; ok {
+			if blockEnd = closeOfBlock(body, end); blockEnd < 0 {
+				continue
+			}
+		} else {
+			continue
+		}
+
+		if start > text {
+			segs = append(segs, messageSegment{text: body[text:start]})
+		}
+		seg.text = body[start:blockEnd]
+		segs = append(segs, seg)
+		text, pos = blockEnd, blockEnd
+	}
+	if text < len(body) {
+		segs = append(segs, messageSegment{text: body[text:]})
+	}
+	return segs
+}
+
+// closeOfBlock returns the end of the closing tag of the block whose contents
+// start at pos, or -1 if it is never closed.
+func closeOfBlock(body string, pos int) int {
+	depth := 1
+	for pos < len(body) {
+		loc := tagPattern.FindStringSubmatchIndex(body[pos:])
+		if loc == nil {
+			return -1
+		}
+		end := pos + loc[1]
+		closing := loc[3] > loc[2]
+		name := strings.ToLower(body[pos+loc[4] : pos+loc[5]])
+		_, container := containerTypes[name]
+		pos = end
+
+		switch {
+		case name == "code" && !closing && loc[6] < 0:
+			if i := strings.Index(strings.ToLower(body[pos:]), "[/code]"); i >= 0 {
+				pos += i + len("[/code]")
+			}
+		case !container:
+		case closing:
+			if depth--; depth == 0 {
+				return end
+			}
+		case loc[6] < 0:
+			depth++
+		}
+	}
+	return -1
+}
+
+// truncateRunes returns the prefix of s with at most n characters.
+func truncateRunes(s string, n int) string {
+	for i := range s {
+		if n == 0 {
+			return s[:i]
+		}
+		n--
+	}
+	return s
+}
@@ -0,0 +1,121 @@
+package chatwork_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/nashirox/chatwork-go"
+	"github.com/nashirox/chatwork-go/chatworktest"
+)
+
+func TestSplitMessage(t *testing.T) {
+	tests := []struct {
+		name  string
+		body  string
+		limit int
+		want  []string
+	}{
+		{
+			name:  "short",
+			body:  "hello",
+			limit: 10,
+			want:  []string{"hello"},
+		},
+		{
+			name:  "line breaks",
+			body:  "one\ntwo\nthree",
+			limit: 9,
+			want:  []string{"one\ntwo", "three"},
+		},
+		{
+			name:  "blocks kept whole",
+			body:  "intro\n[info][title]T[/title]body[/info]tail",
+			limit: 37,
+			want:  []string{"intro", "[info][title]T[/title]body[/info]tail"},
+		},
+		{
+			name:  "long code block",
+			body:  "[code]aaaa\nbbbb\ncccc[/code]",
+			limit: 23,
+			want:  []string{"[code]aaaa\nbbbb[/code]", "[code]cccc[/code]"},
+		},
+		{
+			name:  "long line",
+			body:  "あいうえおかきく",
+			limit: 3,
+			want:  []string{"あいう", "えおか", "きく"},
+		},
+	}
+
+	for _, tt := range tests {
+		got := chatwork.SplitMessage(tt.body, tt.limit)
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s: expected %q, got %q", tt.name, tt.want, got)
+		}
+		for _, part := range got {
+			if utf8.RuneCountInString(part) > tt.limit {
+				t.Errorf("%s: part %q is longer than %d", tt.name, part, tt.limit)
+			}
+		}
+	}
+
+	long := strings.Repeat("line\n", 30000)
+	for _, part := range chatwork.SplitMessage(long, chatwork.MaxMessageLength) {
+		if utf8.RuneCountInString(part) > chatwork.MaxMessageLength {
+			t.Fatalf("Part of %d characters exceeds MaxMessageLength", utf8.RuneCountInString(part))
+		}
+	}
+}
+
+func TestMessagesService_SendLong(t *testing.T) {
+	srv := chatworktest.NewServer()
+	defer srv.Close()
+
+	roomID := srv.AddRoom(chatwork.Room{Name: "Logs"})
+	var posts int
+	failAt := 0
+	client := srv.Client(chatwork.OptionOnRequest(func(r *http.Request) {
+		if r.Method != http.MethodPost {
+			return
+		}
+		if posts++; posts == failAt {
+			srv.Fail("POST", "rooms/"+roomID.String()+"/messages", http.StatusInternalServerError)
+		}
+	}))
+	ctx := context.Background()
+
+	body := strings.Repeat("line\n", 30000)
+	parts := chatwork.SplitMessage(body, chatwork.MaxMessageLength)
+	if len(parts) != 3 {
+		t.Fatalf("Expected the body to be split into 3 parts, got %d", len(parts))
+	}
+
+	ids, _, err := client.Messages.SendLong(ctx, roomID, body)
+	if err != nil {
+		t.Fatalf("SendLong returned error: %v", err)
+	}
+	messages := srv.Messages(roomID)
+	if len(ids) != len(parts) || len(messages) != len(parts) {
+		t.Fatalf("Expected %d messages, got %d IDs and %d messages", len(parts), len(ids), len(messages))
+	}
+	for i, m := range messages {
+		if m.MessageID != ids[i] || m.Body != parts[i] {
+			t.Errorf("Expected part %d to be sent in order as %s, got message %s", i, ids[i], m.MessageID)
+		}
+	}
+
+	posts, failAt = 0, 2
+	ids, _, err = client.Messages.SendLong(ctx, roomID, body)
+	if !errors.Is(err, chatwork.ErrServerError) {
+		t.Errorf("Expected the failed part to be reported, got %v", err)
+	}
+	messages = srv.Messages(roomID)
+	if len(ids) != 1 || len(messages) != len(parts)+1 || messages[len(parts)].MessageID != ids[0] {
+		t.Errorf("Expected the ID of the part sent before the failure, got %v", ids)
+	}
+}
@@ -0,0 +1,125 @@
+package chatwork
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"text/template"
+	"time"
+)
+
+// MessageTemplates is a set of named message templates, in text/template
+// syntax, for sending repetitive messages such as alerts consistently.
+//
+// Besides the standard functions, templates can use these functions for
+// ChatWork notation:
+//
+//	mention ID...      [To:ID] for each account ID
+//	toall              [toall]
+//	info TITLE BODY    an info block; an empty TITLE omits the title
+//	code TEXT          a code block with TEXT shown as is
+//	hr                 a horizontal rule
+//	escape TEXT        TEXT with its tags escaped (see EscapeTags)
+//
+// For example:
+//
+//	templates := chatwork.NewMessageTemplates()
+//	err := templates.Register("deploy-failed",
+//		`{{mention .Owner}}{{info (printf "Deploy of %s failed" .Service) (code .Log)}}`)
+//
+// Missing fields are reported as errors rather than rendered as "<no value>".
+// A MessageTemplates is safe for concurrent use.
+type MessageTemplates struct {
+	// Called after SendTemplate sends a message (optional), such as to log
+	// the variant sent or to record it in a TemplateExperiment
+	OnSend func(*TemplateSend)
+
+	mu        sync.RWMutex
+	templates map[string][]*templateVariant
+}
+
+// NewMessageTemplates returns an empty MessageTemplates.
+func NewMessageTemplates() *MessageTemplates {
+	return &MessageTemplates{templates: make(map[string][]*templateVariant)}
+}
+
+// templateFuncs are the notation functions available to message templates.
+var templateFuncs = template.FuncMap{
+	// mention takes interface{} so that both AccountID fields and plain
+	// integers in template data can be mentioned.
+	"mention": func(accountIDs ...interface{}) string {
+		var b strings.Builder
+		for _, id := range accountIDs {
+			fmt.Fprintf(&b, "[To:%d]", id)
+		}
+		return b.String()
+	},
+	"toall": func() string {
+		return "[toall]"
+	},
+	"info": func(title, body string) string {
+		return NewMessageBuilder().Info(func(b *MessageBuilder) {
+			if title != "" {
+				b.Title(title)
+			}
+			b.Text(body)
+		}).String()
+	},
+	"code": func(text string) string {
+		return NewMessageBuilder().Code(text).String()
+	},
+	"hr": func() string {
+		return "[hr]"
+	},
+	"escape": EscapeTags,
+}
+
+// Register parses text and adds it as the template name, replacing any
+// template of the same name.
+func (t *MessageTemplates) Register(name, text string) error {
+	return t.RegisterVariants(name, TemplateVariant{Weight: 1, Text: text})
+}
+
+// Render executes the template name with data and returns the message body.
+// For templates with variants, a variant is picked at random by weight.
+func (t *MessageTemplates) Render(name string, data interface{}) (string, error) {
+	body, _, err := t.RenderVariant(name, data)
+	return body, err
+}
+
+// OptionMessageTemplates sets the templates used by MessagesService.SendTemplate.
+func OptionMessageTemplates(templates *MessageTemplates) ClientOption {
+	return func(c *Client) {
+		c.templates = templates
+	}
+}
+
+// SendTemplate renders the template name, registered with
+// OptionMessageTemplates, with data and sends the result to a room. The
+// sent message is passed to the OnSend hook of the templates.
+func (s *MessagesService) SendTemplate(ctx context.Context, roomID RoomID, name string, data interface{}, opts ...RequestOption) (*MessageCreatedResponse, *Response, error) {
+	templates := s.client.templates
+	if templates == nil {
+		return nil, nil, fmt.Errorf("chatwork: no message templates set; use OptionMessageTemplates")
+	}
+	body, variant, err := templates.RenderVariant(name, data)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	params := &MessageCreateParams{
+		Body: body,
+	}
+	created, resp, err := s.Create(ctx, roomID, params, opts...)
+	if err == nil && templates.OnSend != nil {
+		templates.OnSend(&TemplateSend{
+			Template:  name,
+			Variant:   variant,
+			RoomID:    roomID,
+			MessageID: created.MessageID,
+			Time:      time.Now(),
+		})
+	}
+	return created, resp, err
+}
@@ -0,0 +1,50 @@
+package chatwork
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+)
+
+func TestMessageTemplates(t *testing.T) {
+	templates := NewMessageTemplates()
+	err := templates.Register("deploy-failed",
+		`{{mention .Owner}}{{info (printf "Deploy of %s failed" .Service) (code .Log)}}{{hr}}{{escape .Note}}`)
+	if err != nil {
+		t.Fatalf("Register returned error: %v", err)
+	}
+
+	var body string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		r.ParseForm()
+		body = r.PostForm.Get("body")
+		w.Write([]byte(`{"message_id":"1"}`))
+	}))
+	defer server.Close()
+
+	client := New(testToken, OptionMessageTemplates(templates))
+	client.BaseURL, _ = url.Parse(server.URL)
+
+	data := map[string]interface{}{
+		"Owner":   1,
+		"Service": "api",
+		"Log":     "exit 1 [/code]",
+		"Note":    "see [info]",
+	}
+	if _, _, err := client.Messages.SendTemplate(context.Background(), 1, "deploy-failed", data); err != nil {
+		t.Fatalf("SendTemplate returned error: %v", err)
+	}
+	want := "[To:1][info][title]Deploy of api failed[/title][code]exit 1 [\u200b/code][/code][/info][hr]see [\u200binfo]"
+	if body != want {
+		t.Errorf("Expected body %q, got %q", want, body)
+	}
+
+	if _, err := templates.Render("deploy-failed", map[string]interface{}{}); err == nil {
+		t.Error("Expected an error for missing fields")
+	}
+	if _, _, err := client.Messages.SendTemplate(context.Background(), 1, "unknown", nil); err == nil {
+		t.Error("Expected an error for an unknown template")
+	}
+}
@@ -2,7 +2,11 @@ package chatwork
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"net/url"
+	"sort"
+	"strings"
 )
 
 // MessagesService handles communication with the message related
@@ -17,6 +21,15 @@ type MessageCreateParams struct {
 	SelfUnread bool   `url:"self_unread,omitempty"`
 }
 
+// ToAll prefixes the body with a [toall] mention, unless it already starts
+// with one, and returns p.
+func (p *MessageCreateParams) ToAll() *MessageCreateParams {
+	if !strings.HasPrefix(p.Body, "[toall]") {
+		p.Body = "[toall]\n" + p.Body
+	}
+	return p
+}
+
 // MessageUpdateParams represents the parameters for updating a message.
 type MessageUpdateParams struct {
 	Body string `url:"body"`
@@ -25,24 +38,39 @@ type MessageUpdateParams struct {
 // MessageListParams represents the parameters for listing messages.
 type MessageListParams struct {
 	// Force retrieval of messages
-	// 0: Get up to 100 most recent messages (default)
-	// 1: Get messages older than the most recent 100
+	// 0: Get only the messages not listed before with the same token (default)
+	// 1: Get the 100 most recent messages, whether listed before or not
 	Force int
 }
 
+// MessageListAllParams represents the parameters for MessagesService.ListAll.
+type MessageListAllParams struct {
+	// Only messages sent at or after this time (optional)
+	Since Timestamp
+
+	// Only the newest Limit messages (optional)
+	Limit int
+}
+
 // MessageCreatedResponse represents the response when a message is created.
 type MessageCreatedResponse struct {
 	// The ID of the created message
-	MessageID string `json:"message_id"`
+	MessageID MessageID `json:"message_id"`
 }
 
 // List returns messages in the specified room.
 //
-// By default, returns up to 100 most recent messages.
-// Use params.Force = 1 to retrieve older messages.
+// By default, returns only the messages that were not listed before with the
+// same token, up to 100. Use params.Force = 1 to retrieve the 100 most recent
+// messages regardless, or ListAll.
 //
 // ChatWork API docs: https://developer.chatwork.com/reference/get-rooms-room_id-messages
-func (s *MessagesService) List(ctx context.Context, roomID int, params *MessageListParams) ([]*Message, *Response, error) {
+func (s *MessagesService) List(ctx context.Context, roomID RoomID, params *MessageListParams, opts ...RequestOption) ([]*Message, *Response, error) {
+	if params != nil {
+		if err := params.Validate(); err != nil {
+			return nil, nil, err
+		}
+	}
 	u := fmt.Sprintf("rooms/%d/messages", roomID)
 	req, err := s.client.NewRequest("GET", u, nil)
 	if err != nil {
@@ -56,75 +84,97 @@ func (s *MessagesService) List(ctx context.Context, roomID int, params *MessageL
 		req.URL.RawQuery = q.Encode()
 	}
 
-	var messages []*Message
-	resp, err := s.client.Do(ctx, req, &messages)
+	return doValue[[]*Message](ctx, s.client, req, opts...)
+}
+
+// ListAll returns the messages of a room, oldest first, without the
+// surprises of List: it always lists with Force, so the result does not
+// depend on earlier listings with the same token, and params filters it by
+// send time and count. params may be nil.
+//
+// The API has no way to page back through history: only the 100 most recent
+// messages of a room can be retrieved, and older ones are not returned.
+func (s *MessagesService) ListAll(ctx context.Context, roomID RoomID, params *MessageListAllParams, opts ...RequestOption) ([]*Message, *Response, error) {
+	messages, resp, err := s.List(ctx, roomID, &MessageListParams{Force: 1}, opts...)
 	if err != nil {
 		return nil, resp, err
 	}
+	if params == nil {
+		params = &MessageListAllParams{}
+	}
 
-	return messages, resp, nil
+	sort.SliceStable(messages, func(i, j int) bool {
+		return compareMessageIDs(messages[i].MessageID, messages[j].MessageID) < 0
+	})
+	filtered := messages[:0]
+	for _, m := range messages {
+		if m.SendTime >= params.Since {
+			filtered = append(filtered, m)
+		}
+	}
+	if params.Limit > 0 && len(filtered) > params.Limit {
+		filtered = filtered[len(filtered)-params.Limit:]
+	}
+	return filtered, resp, nil
 }
 
 // Create posts a new message to the specified room.
 //
 // The message body supports ChatWork message notation for mentions, quotes, etc.
+// Clients with OptionStagingRoom post it to their staging room instead.
 //
 // ChatWork API docs: https://developer.chatwork.com/reference/post-rooms-room_id-messages
-func (s *MessagesService) Create(ctx context.Context, roomID int, params *MessageCreateParams) (*MessageCreatedResponse, *Response, error) {
-	u := fmt.Sprintf("rooms/%d/messages", roomID)
-	req, err := s.client.NewFormRequest("POST", u, params)
+func (s *MessagesService) Create(ctx context.Context, roomID RoomID, params *MessageCreateParams, opts ...RequestOption) (*MessageCreatedResponse, *Response, error) {
+	if params == nil {
+		return nil, nil, errNilParams
+	}
+	roomID, params, err := s.client.stage(roomID, params)
 	if err != nil {
 		return nil, nil, err
 	}
-
-	result := new(MessageCreatedResponse)
-	resp, err := s.client.Do(ctx, req, result)
+	u := fmt.Sprintf("rooms/%d/messages", roomID)
+	req, err := s.client.NewFormRequest("POST", u, params)
 	if err != nil {
-		return nil, resp, err
+		return nil, nil, err
 	}
 
-	return result, resp, nil
+	return Do[MessageCreatedResponse](ctx, s.client, req, opts...)
 }
 
 // Get returns information about a specific message.
 //
 // ChatWork API docs: https://developer.chatwork.com/reference/get-rooms-room_id-messages-message_id
-func (s *MessagesService) Get(ctx context.Context, roomID int, messageID string) (*Message, *Response, error) {
-	u := fmt.Sprintf("rooms/%d/messages/%s", roomID, messageID)
+func (s *MessagesService) Get(ctx context.Context, roomID RoomID, messageID MessageID, opts ...RequestOption) (*Message, *Response, error) {
+	u := fmt.Sprintf("rooms/%d/messages/%s", roomID, url.PathEscape(string(messageID)))
 	req, err := s.client.NewRequest("GET", u, nil)
 	if err != nil {
 		return nil, nil, err
 	}
 
-	message := new(Message)
-	resp, err := s.client.Do(ctx, req, message)
-	if err != nil {
-		return nil, resp, err
-	}
-
-	return message, resp, nil
+	return Do[Message](ctx, s.client, req, opts...)
 }
 
 // Update updates the specified message.
 //
 // Only the message creator can update their own messages.
-// Messages can only be updated for a limited time after creation.
+// Messages can only be updated for a limited time after creation. With
+// OptionStagingRoom, only messages in the staging room can be updated.
 //
 // ChatWork API docs: https://developer.chatwork.com/reference/put-rooms-room_id-messages-message_id
-func (s *MessagesService) Update(ctx context.Context, roomID int, messageID string, params *MessageUpdateParams) (*Message, *Response, error) {
-	u := fmt.Sprintf("rooms/%d/messages/%s", roomID, messageID)
-	req, err := s.client.NewFormRequest("PUT", u, params)
-	if err != nil {
+func (s *MessagesService) Update(ctx context.Context, roomID RoomID, messageID MessageID, params *MessageUpdateParams, opts ...RequestOption) (*Message, *Response, error) {
+	if params == nil {
+		return nil, nil, errNilParams
+	}
+	if err := s.client.checkStagedUpdate(roomID); err != nil {
 		return nil, nil, err
 	}
-
-	message := new(Message)
-	resp, err := s.client.Do(ctx, req, message)
+	u := fmt.Sprintf("rooms/%d/messages/%s", roomID, url.PathEscape(string(messageID)))
+	req, err := s.client.NewFormRequest("PUT", u, params)
 	if err != nil {
-		return nil, resp, err
+		return nil, nil, err
 	}
 
-	return message, resp, nil
+	return Do[Message](ctx, s.client, req, opts...)
 }
 
 // Delete deletes the specified message.
@@ -133,37 +183,31 @@ func (s *MessagesService) Update(ctx context.Context, roomID int, messageID stri
 // Messages can only be deleted for a limited time after creation.
 //
 // ChatWork API docs: https://developer.chatwork.com/reference/delete-rooms-room_id-messages-message_id
-func (s *MessagesService) Delete(ctx context.Context, roomID int, messageID string) (*Message, *Response, error) {
-	u := fmt.Sprintf("rooms/%d/messages/%s", roomID, messageID)
+func (s *MessagesService) Delete(ctx context.Context, roomID RoomID, messageID MessageID, opts ...RequestOption) (*Message, *Response, error) {
+	u := fmt.Sprintf("rooms/%d/messages/%s", roomID, url.PathEscape(string(messageID)))
 	req, err := s.client.NewRequest("DELETE", u, nil)
 	if err != nil {
 		return nil, nil, err
 	}
 
-	message := new(Message)
-	resp, err := s.client.Do(ctx, req, message)
-	if err != nil {
-		return nil, resp, err
-	}
-
-	return message, resp, nil
+	return Do[Message](ctx, s.client, req, opts...)
 }
 
 // SendMessage is a convenience method for sending a simple text message.
 //
 // This is equivalent to calling Create with a MessageCreateParams containing only the body.
-func (s *MessagesService) SendMessage(ctx context.Context, roomID int, body string) (*MessageCreatedResponse, *Response, error) {
+func (s *MessagesService) SendMessage(ctx context.Context, roomID RoomID, body string, opts ...RequestOption) (*MessageCreatedResponse, *Response, error) {
 	params := &MessageCreateParams{
 		Body: body,
 	}
-	return s.Create(ctx, roomID, params)
+	return s.Create(ctx, roomID, params, opts...)
 }
 
 // SendTo sends a message with mentions to specified users.
 //
 // The message will include [To:accountID] tags for each specified user,
 // which will trigger notifications for those users.
-func (s *MessagesService) SendTo(ctx context.Context, roomID int, accountIDs []int, body string) (*MessageCreatedResponse, *Response, error) {
+func (s *MessagesService) SendTo(ctx context.Context, roomID RoomID, accountIDs []AccountID, body string, opts ...RequestOption) (*MessageCreatedResponse, *Response, error) {
 	// Build mention tags
 	mentions := ""
 	for _, id := range accountIDs {
@@ -173,26 +217,51 @@ func (s *MessagesService) SendTo(ctx context.Context, roomID int, accountIDs []i
 	params := &MessageCreateParams{
 		Body: mentions + body,
 	}
-	return s.Create(ctx, roomID, params)
+	return s.Create(ctx, roomID, params, opts...)
+}
+
+// SendToAll sends a message that mentions every member of the room with [toall].
+//
+// Depending on the room's settings, mentioning everyone may be restricted to
+// its admins. The authenticated account then needs the admin role for the
+// members to be notified.
+func (s *MessagesService) SendToAll(ctx context.Context, roomID RoomID, body string, opts ...RequestOption) (*MessageCreatedResponse, *Response, error) {
+	params := &MessageCreateParams{
+		Body: body,
+	}
+	return s.Create(ctx, roomID, params.ToAll(), opts...)
+}
+
+// SendCode sends code, such as a stack trace or a diff, in a code block.
+// Tags in code are escaped, so it is shown as is.
+func (s *MessagesService) SendCode(ctx context.Context, roomID RoomID, code string, opts ...RequestOption) (*MessageCreatedResponse, *Response, error) {
+	params := &MessageCreateParams{
+		Body: NewMessageBuilder().Code(code).String(),
+	}
+	return s.Create(ctx, roomID, params, opts...)
 }
 
 // Reply sends a reply to a specific message.
 //
 // This creates a threaded conversation by linking the new message to the original.
-func (s *MessagesService) Reply(ctx context.Context, roomID int, messageID, body string) (*MessageCreatedResponse, *Response, error) {
+func (s *MessagesService) Reply(ctx context.Context, roomID RoomID, messageID MessageID, body string, opts ...RequestOption) (*MessageCreatedResponse, *Response, error) {
 	params := &MessageCreateParams{
 		Body: fmt.Sprintf("[rp aid=%s] %s", messageID, body),
 	}
-	return s.Create(ctx, roomID, params)
+	return s.Create(ctx, roomID, params, opts...)
 }
 
 // Quote sends a message quoting another message.
 //
 // This fetches the original message and includes it in a quote block
 // before the new message body.
-func (s *MessagesService) Quote(ctx context.Context, roomID int, messageID, body string) (*MessageCreatedResponse, *Response, error) {
+func (s *MessagesService) Quote(ctx context.Context, roomID RoomID, messageID MessageID, body string, opts ...RequestOption) (*MessageCreatedResponse, *Response, error) {
+	budget := NewBudget(ctx, 2)
+
 	// First, fetch the message to quote
-	message, _, err := s.Get(ctx, roomID, messageID)
+	getCtx, cancel := budget.Next()
+	message, _, err := s.Get(getCtx, roomID, messageID, opts...)
+	cancel()
 	if err != nil {
 		return nil, nil, err
 	}
@@ -208,45 +277,97 @@ func (s *MessagesService) Quote(ctx context.Context, roomID int, messageID, body
 	params := &MessageCreateParams{
 		Body: quotedBody,
 	}
-	return s.Create(ctx, roomID, params)
+	createCtx, cancel := budget.Next()
+	defer cancel()
+	return s.Create(createCtx, roomID, params, opts...)
 }
 
 // SendInfo sends an information message with a title.
 //
 // Information messages are displayed with special formatting to highlight
 // important information.
-func (s *MessagesService) SendInfo(ctx context.Context, roomID int, title, body string) (*MessageCreatedResponse, *Response, error) {
+func (s *MessagesService) SendInfo(ctx context.Context, roomID RoomID, title, body string, opts ...RequestOption) (*MessageCreatedResponse, *Response, error) {
 	infoBody := fmt.Sprintf("[info][title]%s[/title]%s[/info]", title, body)
 	params := &MessageCreateParams{
 		Body: infoBody,
 	}
-	return s.Create(ctx, roomID, params)
+	return s.Create(ctx, roomID, params, opts...)
 }
 
 // GetUnreadCount returns the number of unread messages in a room.
 //
 // This is a convenience method that uses the Rooms service's GetMessagesUnreadCount.
-func (s *MessagesService) GetUnreadCount(ctx context.Context, roomID int) (int, *Response, error) {
+func (s *MessagesService) GetUnreadCount(ctx context.Context, roomID RoomID, opts ...RequestOption) (int, *Response, error) {
 	// Use RoomsService's GetMessagesUnreadCount
 	roomsService := (*RoomsService)(&s.client.common)
-	result, resp, err := roomsService.GetMessagesUnreadCount(ctx, roomID)
+	result, resp, err := roomsService.GetMessagesUnreadCount(ctx, roomID, opts...)
 	if err != nil {
 		return 0, resp, err
 	}
 
-	if count, ok := result["unread_num"]; ok {
-		return count, resp, nil
-	}
-
-	return 0, resp, nil
+	return result.UnreadNum, resp, nil
 }
 
 // MarkAsRead marks all messages up to the specified message as read.
 //
 // This is a convenience method that uses the Rooms service's MarkMessagesAsRead.
-func (s *MessagesService) MarkAsRead(ctx context.Context, roomID int, messageID string) (*Response, error) {
+func (s *MessagesService) MarkAsRead(ctx context.Context, roomID RoomID, messageID MessageID, opts ...RequestOption) (*Response, error) {
 	// Use RoomsService's MarkMessagesAsRead
 	roomsService := (*RoomsService)(&s.client.common)
-	_, resp, err := roomsService.MarkMessagesAsRead(ctx, roomID, messageID)
+	_, resp, err := roomsService.MarkMessagesAsRead(ctx, roomID, messageID, opts...)
 	return resp, err
 }
+
+// DeleteMine deletes the authenticated user's recent messages in a room.
+//
+// The 100 most recent messages are listed, narrowed down to those posted by
+// the authenticated account, and each one for which filter returns true is
+// deleted. A nil filter selects all of the account's messages. This is meant
+// for cleaning up after a misbehaving bot.
+//
+// Deletions go through the client's rate limiting (see OptionRateLimit). The
+// API does not publish how long messages can be deleted, so the deletion
+// window is left to it: messages that it refuses to delete, for example
+// because they are too old, do not stop the run, and their errors are joined
+// into the returned error alongside the IDs that were deleted. If ChatWork
+// rate limits a deletion, the run stops there rather than sending the
+// remaining deletions, and the IDs deleted so far are returned.
+func (s *MessagesService) DeleteMine(ctx context.Context, roomID RoomID, filter func(*Message) bool, opts ...RequestOption) ([]MessageID, *Response, error) {
+	meService := (*MeService)(&s.client.common)
+	me, resp, err := meService.Get(ctx, opts...)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	messages, resp, err := s.List(ctx, roomID, &MessageListParams{Force: 1}, opts...)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	var deleted []MessageID
+	var errs []error
+	for _, message := range messages {
+		if message.Account.AccountID != me.AccountID {
+			continue
+		}
+		if filter != nil && !filter(message) {
+			continue
+		}
+
+		_, resp, err = s.Delete(ctx, roomID, message.MessageID, opts...)
+		if err != nil {
+			var apiErr *APIError
+			if !errors.As(err, &apiErr) {
+				return deleted, resp, err
+			}
+			errs = append(errs, fmt.Errorf("message %s: %w", message.MessageID, err))
+			if errors.Is(err, ErrRateLimited) {
+				return deleted, resp, errors.Join(errs...)
+			}
+			continue
+		}
+		deleted = append(deleted, message.MessageID)
+	}
+
+	return deleted, resp, errors.Join(errs...)
+}
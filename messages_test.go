@@ -0,0 +1,67 @@
+package chatwork_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/nashirox/chatwork-go"
+	"github.com/nashirox/chatwork-go/chatworktest"
+)
+
+func TestMessagesService_DeleteMine(t *testing.T) {
+	srv := chatworktest.NewServer()
+	defer srv.Close()
+
+	roomID := srv.AddRoom(chatwork.Room{Name: "Alerts"})
+	spam1 := srv.AddMessage(roomID, chatwork.Message{Body: "spam 1"})
+	other := srv.AddMessage(roomID, chatwork.Message{Account: chatwork.User{AccountID: 200}, Body: "spam from someone else"})
+	kept := srv.AddMessage(roomID, chatwork.Message{Body: "keep me"})
+	spam2 := srv.AddMessage(roomID, chatwork.Message{Body: "spam 2"})
+	spam3 := srv.AddMessage(roomID, chatwork.Message{Body: "spam 3"})
+	srv.Fail("DELETE", "rooms/"+roomID.String()+"/messages/"+spam2.String(), http.StatusForbidden, "Message is too old")
+	client := srv.Client()
+
+	deleted, _, err := client.Messages.DeleteMine(context.Background(), roomID, func(m *chatwork.Message) bool {
+		return strings.HasPrefix(m.Body, "spam")
+	})
+	if !errors.Is(err, chatwork.ErrForbidden) || !strings.Contains(err.Error(), spam2.String()) {
+		t.Errorf("Expected the refused deletion to be reported, got %v", err)
+	}
+	if want := []chatwork.MessageID{spam1, spam3}; !reflect.DeepEqual(deleted, want) {
+		t.Errorf("Expected deleted %v, got %v", want, deleted)
+	}
+
+	var remaining []chatwork.MessageID
+	for _, m := range srv.Messages(roomID) {
+		remaining = append(remaining, m.MessageID)
+	}
+	if want := []chatwork.MessageID{other, kept, spam2}; !reflect.DeepEqual(remaining, want) {
+		t.Errorf("Expected remaining messages %v, got %v", want, remaining)
+	}
+}
+
+func TestMessagesService_DeleteMine_RateLimited(t *testing.T) {
+	srv := chatworktest.NewServer()
+	defer srv.Close()
+
+	roomID := srv.AddRoom(chatwork.Room{Name: "Alerts"})
+	spam1 := srv.AddMessage(roomID, chatwork.Message{Body: "spam 1"})
+	spam2 := srv.AddMessage(roomID, chatwork.Message{Body: "spam 2"})
+	spam3 := srv.AddMessage(roomID, chatwork.Message{Body: "spam 3"})
+	srv.Fail("DELETE", "rooms/"+roomID.String()+"/messages/"+spam2.String(), http.StatusTooManyRequests)
+
+	deleted, _, err := srv.Client().Messages.DeleteMine(context.Background(), roomID, nil)
+	if !errors.Is(err, chatwork.ErrRateLimited) {
+		t.Errorf("Expected the rate limit to be returned, got %v", err)
+	}
+	if want := []chatwork.MessageID{spam1}; !reflect.DeepEqual(deleted, want) {
+		t.Errorf("Expected deleted %v, got %v", want, deleted)
+	}
+	if messages := srv.Messages(roomID); len(messages) != 2 || messages[1].MessageID != spam3 {
+		t.Errorf("Expected no deletion to be sent after the rate limit, got %+v", messages)
+	}
+}
@@ -0,0 +1,56 @@
+package chatwork
+
+import "net/http"
+
+// Middleware wraps the http.RoundTripper used to send API requests.
+type Middleware func(http.RoundTripper) http.RoundTripper
+
+// RoundTripperFunc adapts an ordinary function to the http.RoundTripper interface.
+type RoundTripperFunc func(*http.Request) (*http.Response, error)
+
+// RoundTrip calls f(req).
+func (f RoundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+// OptionMiddleware adds a middleware around the client's HTTP transport.
+//
+// The option can be given several times. Middlewares are composed in
+// registration order, so the first one registered sees each request first
+// and each response last. They wrap the transport of the client set with
+// OptionHTTPClient (or http.DefaultTransport) without modifying it.
+//
+// Example:
+//
+//	logging := func(next http.RoundTripper) http.RoundTripper {
+//		return chatwork.RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+//			log.Println(req.Method, req.URL)
+//			return next.RoundTrip(req)
+//		})
+//	}
+//	client := chatwork.New("token", chatwork.OptionMiddleware(logging))
+func OptionMiddleware(middleware Middleware) ClientOption {
+	return func(c *Client) {
+		c.middlewares = append(c.middlewares, middleware)
+	}
+}
+
+// applyMiddlewares replaces c.client with a copy whose transport is wrapped
+// by the registered middlewares.
+func (c *Client) applyMiddlewares() {
+	if len(c.middlewares) == 0 {
+		return
+	}
+
+	transport := c.client.Transport
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+	for i := len(c.middlewares) - 1; i >= 0; i-- {
+		transport = c.middlewares[i](transport)
+	}
+
+	httpClient := *c.client
+	httpClient.Transport = transport
+	c.client = &httpClient
+}
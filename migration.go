@@ -0,0 +1,408 @@
+package chatwork
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"time"
+)
+
+// Steps of a room migration, in the order they are applied.
+const (
+	MigrationStepExport = "export"
+	MigrationStepCreate = "create"
+	MigrationStepImport = "import"
+)
+
+// Migration moves rooms from one ChatWork organization to another, for
+// example when a company changes organizations or consolidates after a
+// merger.
+//
+// A migration is run in two phases. Plan reads the source rooms and their
+// members and returns a MigrationPlan, which can be reviewed (and edited, as
+// JSON) before anything is written. Apply then, for each room, exports its
+// messages to an archive, creates the room in the destination organization
+// with its members mapped to their new accounts, and imports the archived
+// messages with an Importer.
+//
+// Progress is recorded in a checkpoint file in Dir after every step, so that
+// an interrupted Apply can be run again with the same plan and continues
+// where it stopped.
+type Migration struct {
+	source *Client
+	dest   *Client
+
+	// Maps source account IDs, formatted in decimal, to destination account
+	// IDs. Members without a mapping are listed in MigrationPlan.Unmapped and
+	// resolved according to the map's UnknownIDPolicy: by default, they are
+	// left out of the destination rooms.
+	Accounts *IDMap
+
+	// Directory for the message archives and the checkpoint file (required)
+	Dir string
+
+	// Renders the description of the destination rooms (optional). It is
+	// executed with a map holding the source room's "RoomID", "Name", and
+	// "Description". Defaults to the source room's description.
+	Description *DescriptionTemplate
+
+	// Whether to re-post the archived messages into the destination rooms.
+	// When false, messages are only exported.
+	ImportMessages bool
+
+	// Configures the Importer used for the messages (optional)
+	Importer func(*Importer)
+
+	// Called after every completed step (optional)
+	OnProgress func(MigrationProgress)
+}
+
+// MigrationPlan lists the rooms a Migration will create.
+type MigrationPlan struct {
+	CreatedAt time.Time            `json:"created_at"`
+	Rooms     []*MigrationRoomPlan `json:"rooms"`
+
+	// Source account IDs that have no mapping, with the rooms they belong to
+	Unmapped map[AccountID][]RoomID `json:"unmapped,omitempty"`
+}
+
+// MigrationRoomPlan describes how a single room is migrated. Account IDs
+// are those of the destination organization.
+type MigrationRoomPlan struct {
+	SourceRoomID RoomID      `json:"source_room_id"`
+	Name         string      `json:"name"`
+	Description  string      `json:"description"`
+	AdminIDs     []AccountID `json:"admin_ids"`
+	MemberIDs    []AccountID `json:"member_ids,omitempty"`
+	ReadonlyIDs  []AccountID `json:"readonly_ids,omitempty"`
+}
+
+// MigrationProgress reports a completed step of a migration.
+type MigrationProgress struct {
+	SourceRoomID RoomID
+	Step         string
+
+	// Number of rooms completed and planned in total
+	Done  int
+	Total int
+}
+
+// MigrationCheckpoint records the progress of a migration. It is stored as
+// "checkpoint.json" in the migration's Dir.
+type MigrationCheckpoint struct {
+	Rooms map[RoomID]*MigrationRoomState `json:"rooms"`
+}
+
+// MigrationRoomState is the progress of a single room, keyed by its source
+// room ID in MigrationCheckpoint.
+type MigrationRoomState struct {
+	// Path of the archive manifest, once the messages are exported
+	Archive string `json:"archive,omitempty"`
+
+	// ID of the destination room, once it is created
+	DestRoomID RoomID `json:"dest_room_id,omitempty"`
+
+	// IDs of the imported messages, keyed by source message ID
+	Imported map[MessageID]MessageID `json:"imported,omitempty"`
+
+	// Whether all steps are completed
+	Done bool `json:"done,omitempty"`
+}
+
+// NewMigration returns a Migration that reads from source and writes to dest.
+func NewMigration(source, dest *Client, accounts *IDMap, dir string) *Migration {
+	return &Migration{
+		source:   source,
+		dest:     dest,
+		Accounts: accounts,
+		Dir:      dir,
+	}
+}
+
+// Plan reads the given source rooms and their members and returns the plan
+// for migrating them. Nothing is written.
+//
+// The authenticated account of the destination client becomes an admin of
+// every planned room, since ChatWork requires the creator of a room to be one.
+func (m *Migration) Plan(ctx context.Context, roomIDs []RoomID, opts ...RequestOption) (*MigrationPlan, error) {
+	me, _, err := m.dest.Me.Get(ctx, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("destination account: %w", err)
+	}
+
+	plan := &MigrationPlan{CreatedAt: time.Now()}
+	for _, roomID := range roomIDs {
+		room, _, err := m.source.Rooms.Get(ctx, roomID, opts...)
+		if err != nil {
+			return nil, fmt.Errorf("room %d: %w", roomID, err)
+		}
+		members, _, err := m.source.Rooms.GetMembers(ctx, roomID, opts...)
+		if err != nil {
+			return nil, fmt.Errorf("room %d: %w", roomID, err)
+		}
+
+		roomPlan := &MigrationRoomPlan{
+			SourceRoomID: roomID,
+			Name:         room.Name,
+			Description:  room.Description,
+			AdminIDs:     []AccountID{me.AccountID},
+		}
+		if m.Description != nil {
+			roomPlan.Description, err = m.Description.Render(map[string]interface{}{
+				"RoomID":      room.RoomID,
+				"Name":        room.Name,
+				"Description": room.Description,
+			})
+			if err != nil {
+				return nil, fmt.Errorf("room %d: %w", roomID, err)
+			}
+		}
+
+		for _, member := range members {
+			key := member.AccountID.String()
+			if _, ok := m.Accounts.Lookup(key); !ok {
+				if plan.Unmapped == nil {
+					plan.Unmapped = make(map[AccountID][]RoomID)
+				}
+				plan.Unmapped[member.AccountID] = append(plan.Unmapped[member.AccountID], roomID)
+			}
+			accountID, err := m.Accounts.AccountID(key)
+			if err != nil {
+				if m.Accounts.Unknown == UnknownIDSkip {
+					continue
+				}
+				return nil, fmt.Errorf("room %d: %w", roomID, err)
+			}
+			if accountID == me.AccountID {
+				continue
+			}
+			switch member.Role {
+			case RoleAdmin:
+				roomPlan.AdminIDs = append(roomPlan.AdminIDs, accountID)
+			case RoleReadonly:
+				roomPlan.ReadonlyIDs = append(roomPlan.ReadonlyIDs, accountID)
+			default:
+				roomPlan.MemberIDs = append(roomPlan.MemberIDs, accountID)
+			}
+		}
+
+		plan.Rooms = append(plan.Rooms, roomPlan)
+	}
+
+	return plan, nil
+}
+
+// Apply carries out plan, resuming from the checkpoint in Dir if there is one.
+// It stops at the first error; the checkpoint then holds everything completed
+// so far, and Apply can be called again with the same plan to continue.
+func (m *Migration) Apply(ctx context.Context, plan *MigrationPlan, opts ...RequestOption) (*MigrationCheckpoint, error) {
+	if m.Dir == "" {
+		return nil, errors.New("chatwork: migration directory is required")
+	}
+	if err := os.MkdirAll(m.Dir, 0o750); err != nil {
+		return nil, err
+	}
+
+	checkpoint, err := ReadMigrationCheckpoint(m.checkpointPath())
+	if err != nil {
+		return nil, err
+	}
+
+	done := 0
+	for _, roomPlan := range plan.Rooms {
+		state := checkpoint.Rooms[roomPlan.SourceRoomID]
+		if state == nil {
+			state = new(MigrationRoomState)
+			checkpoint.Rooms[roomPlan.SourceRoomID] = state
+		}
+		if !state.Done {
+			if err := m.applyRoom(ctx, roomPlan, state, checkpoint, done, len(plan.Rooms), opts); err != nil {
+				return checkpoint, fmt.Errorf("room %d: %w", roomPlan.SourceRoomID, err)
+			}
+		}
+		done++
+	}
+
+	return checkpoint, nil
+}
+
+func (m *Migration) applyRoom(ctx context.Context, plan *MigrationRoomPlan, state *MigrationRoomState, checkpoint *MigrationCheckpoint, done, total int, opts []RequestOption) error {
+	// step saves the checkpoint and reports a completed step. The last step
+	// of a room marks it as done.
+	step := func(name string, last bool) error {
+		if last {
+			state.Done = true
+			done++
+		}
+		if err := m.saveCheckpoint(checkpoint); err != nil {
+			return err
+		}
+		if m.OnProgress != nil {
+			m.OnProgress(MigrationProgress{SourceRoomID: plan.SourceRoomID, Step: name, Done: done, Total: total})
+		}
+		return nil
+	}
+
+	if state.Archive == "" {
+		archive, err := m.export(ctx, plan.SourceRoomID, opts)
+		if err != nil {
+			return err
+		}
+		state.Archive = archive
+		if err := step(MigrationStepExport, false); err != nil {
+			return err
+		}
+	}
+
+	if state.DestRoomID == 0 {
+		created, _, err := m.dest.Rooms.Create(ctx, &RoomCreateParams{
+			Name:               plan.Name,
+			Description:        plan.Description,
+			MembersAdminIDs:    plan.AdminIDs,
+			MembersMemberIDs:   plan.MemberIDs,
+			MembersReadonlyIDs: plan.ReadonlyIDs,
+		}, opts...)
+		if err != nil {
+			return err
+		}
+		state.DestRoomID = created.RoomID
+		if err := step(MigrationStepCreate, !m.ImportMessages); err != nil {
+			return err
+		}
+	}
+
+	if !m.ImportMessages {
+		if !state.Done {
+			// Created by an earlier Apply that also imported messages.
+			return step(MigrationStepCreate, true)
+		}
+		return nil
+	}
+
+	importer := NewImporter(m.dest)
+	if m.Importer != nil {
+		m.Importer(importer)
+	}
+	filter := importer.Filter
+	importer.Filter = func(message *Message) bool {
+		if _, ok := state.Imported[message.MessageID]; ok {
+			return false
+		}
+		return filter == nil || filter(message)
+	}
+
+	result, err := importer.ImportArchive(ctx, state.Archive, state.DestRoomID, opts...)
+	if result != nil && len(result.Imported) > 0 {
+		if state.Imported == nil {
+			state.Imported = make(map[MessageID]MessageID)
+		}
+		for sourceID, destID := range result.Imported {
+			state.Imported[sourceID] = destID
+		}
+	}
+	if err != nil {
+		// Record the messages posted so far so that they are not posted twice.
+		if saveErr := m.saveCheckpoint(checkpoint); saveErr != nil {
+			return errors.Join(err, saveErr)
+		}
+		return err
+	}
+	return step(MigrationStepImport, true)
+}
+
+// export writes the messages of a source room to an archive and returns the
+// path of its manifest.
+func (m *Migration) export(ctx context.Context, roomID RoomID, opts []RequestOption) (string, error) {
+	messages, _, err := m.source.Messages.List(ctx, roomID, &MessageListParams{Force: 1}, opts...)
+	if err != nil {
+		return "", err
+	}
+
+	// Start a fresh archive if an earlier run exported the room without
+	// recording it, rather than adding the messages to it a second time.
+	// Its files are removed along with its manifest, so that none are left
+	// behind unlisted.
+	prefix := "room-" + roomID.String()
+	earlier, err := filepath.Glob(filepath.Join(m.Dir, prefix+"-*"))
+	if err != nil {
+		return "", err
+	}
+	for _, path := range earlier {
+		if err := os.Remove(path); err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return "", err
+		}
+	}
+	w, err := NewExportWriter(ExportWriterOptions{
+		Dir:         m.Dir,
+		Prefix:      prefix,
+		Compression: ExportCompressionGzip,
+	})
+	if err != nil {
+		return "", err
+	}
+	for _, message := range messages {
+		if err := w.WriteRecord(message, message.SendTime.Time()); err != nil {
+			w.Close()
+			return "", err
+		}
+	}
+	if err := w.Close(); err != nil {
+		return "", err
+	}
+	return w.manifestPath(), nil
+}
+
+func (m *Migration) checkpointPath() string {
+	return filepath.Join(m.Dir, "checkpoint.json")
+}
+
+// saveCheckpoint replaces the checkpoint file atomically.
+func (m *Migration) saveCheckpoint(checkpoint *MigrationCheckpoint) error {
+	data, err := json.MarshalIndent(checkpoint, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	path := m.checkpointPath()
+	if err := os.WriteFile(path+".tmp", data, 0o640); err != nil {
+		return err
+	}
+	return os.Rename(path+".tmp", path)
+}
+
+// ReadMigrationCheckpoint reads the checkpoint at path. A missing file is
+// returned as an empty checkpoint.
+func ReadMigrationCheckpoint(path string) (*MigrationCheckpoint, error) {
+	checkpoint := &MigrationCheckpoint{Rooms: make(map[RoomID]*MigrationRoomState)}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return checkpoint, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(data, checkpoint); err != nil {
+		return nil, fmt.Errorf("chatwork: invalid migration checkpoint: %w", err)
+	}
+	if checkpoint.Rooms == nil {
+		checkpoint.Rooms = make(map[RoomID]*MigrationRoomState)
+	}
+	return checkpoint, nil
+}
+
+// UnmappedAccounts returns the source account IDs without a mapping, in
+// ascending order.
+func (p *MigrationPlan) UnmappedAccounts() []AccountID {
+	ids := make([]AccountID, 0, len(p.Unmapped))
+	for id := range p.Unmapped {
+		ids = append(ids, id)
+	}
+	slices.Sort(ids)
+	return ids
+}
@@ -0,0 +1,149 @@
+package chatwork_test
+
+import (
+	"context"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/nashirox/chatwork-go"
+	"github.com/nashirox/chatwork-go/chatworktest"
+)
+
+func TestMigration(t *testing.T) {
+	source := chatworktest.NewServer()
+	defer source.Close()
+	dest := chatworktest.NewServer()
+	defer dest.Close()
+	dest.SetMe(chatwork.Me{AccountID: 100, Name: "Migrator"})
+
+	roomID := source.AddRoom(chatwork.Room{Name: "Project", Description: "Old description"})
+	source.AddMember(roomID, chatwork.Member{AccountID: 2, Role: "member"})
+	source.AddMember(roomID, chatwork.Member{AccountID: 3, Role: "readonly"})
+	source.AddMember(roomID, chatwork.Member{AccountID: 4, Role: "admin"})
+	source.AddMessage(roomID, chatwork.Message{Account: chatwork.User{AccountID: 2, Name: "Bob"}, Body: "hello", SendTime: 1704164640})
+	source.AddMessage(roomID, chatwork.Message{Account: chatwork.User{AccountID: 3, Name: "Carol"}, Body: "hi", SendTime: 1704164700})
+
+	tmpl, err := chatwork.NewDescriptionTemplate("{{.Description}} (migrated from {{.RoomID}})")
+	if err != nil {
+		t.Fatal(err)
+	}
+	accounts, err := chatwork.LoadIDMapCSV(strings.NewReader("1,100\n2,200\n3,300\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	migration := chatwork.NewMigration(source.Client(), dest.Client(), accounts, t.TempDir())
+	migration.Description = tmpl
+	migration.ImportMessages = true
+	migration.Importer = func(im *chatwork.Importer) { im.Interval = -1 }
+	var steps []string
+	migration.OnProgress = func(p chatwork.MigrationProgress) {
+		steps = append(steps, p.Step)
+	}
+
+	ctx := context.Background()
+	plan, err := migration.Plan(ctx, []chatwork.RoomID{roomID})
+	if err != nil {
+		t.Fatalf("Plan returned error: %v", err)
+	}
+	want := &chatwork.MigrationRoomPlan{
+		SourceRoomID: roomID,
+		Name:         "Project",
+		Description:  "Old description (migrated from " + roomID.String() + ")",
+		AdminIDs:     []chatwork.AccountID{100},
+		MemberIDs:    []chatwork.AccountID{200},
+		ReadonlyIDs:  []chatwork.AccountID{300},
+	}
+	if len(plan.Rooms) != 1 || !reflect.DeepEqual(plan.Rooms[0], want) {
+		t.Errorf("Unexpected room plan: %+v", plan.Rooms[0])
+	}
+	if got := plan.UnmappedAccounts(); !reflect.DeepEqual(got, []chatwork.AccountID{4}) {
+		t.Errorf("Expected account 4 to be unmapped, got %v", got)
+	}
+
+	// Interrupt the migration after the export.
+	dest.Fail("POST", "rooms", 500)
+	if _, err := migration.Apply(ctx, plan); !errors.Is(err, chatwork.ErrServerError) {
+		t.Fatalf("Expected the room creation to fail, got %v", err)
+	}
+	if !reflect.DeepEqual(steps, []string{chatwork.MigrationStepExport}) {
+		t.Errorf("Unexpected steps: %v", steps)
+	}
+
+	// Resuming must not export again.
+	dest.Fail("POST", "rooms", 0)
+	source.Fail("GET", "rooms/"+roomID.String()+"/messages", 500)
+	checkpoint, err := migration.Apply(ctx, plan)
+	if err != nil {
+		t.Fatalf("Apply returned error: %v", err)
+	}
+	if !reflect.DeepEqual(steps, []string{chatwork.MigrationStepExport, chatwork.MigrationStepCreate, chatwork.MigrationStepImport}) {
+		t.Errorf("Unexpected steps: %v", steps)
+	}
+
+	state := checkpoint.Rooms[roomID]
+	if !state.Done || len(state.Imported) != 2 {
+		t.Errorf("Unexpected room state: %+v", state)
+	}
+	room, ok := dest.Room(state.DestRoomID)
+	if !ok || room.Description != want.Description {
+		t.Errorf("Unexpected destination room: %+v", room)
+	}
+	messages := dest.Messages(state.DestRoomID)
+	if len(messages) != 2 || !strings.Contains(messages[0].Body, "Bob (2)") || !strings.Contains(messages[1].Body, "hi") {
+		t.Errorf("Unexpected imported messages: %+v", messages)
+	}
+
+	// A completed migration is not applied twice.
+	if _, err := migration.Apply(ctx, plan); err != nil {
+		t.Fatalf("Apply returned error: %v", err)
+	}
+	if got := len(dest.Rooms()); got != 1 {
+		t.Errorf("Expected one destination room, got %d", got)
+	}
+}
+
+func TestMigration_ReplacesUnrecordedExport(t *testing.T) {
+	source := chatworktest.NewServer()
+	defer source.Close()
+	dest := chatworktest.NewServer()
+	defer dest.Close()
+
+	roomID := source.AddRoom(chatwork.Room{Name: "Project"})
+	source.AddMessage(roomID, chatwork.Message{Account: chatwork.User{AccountID: 1, Name: "Test User"}, Body: "hello", SendTime: 1704164640})
+
+	// An earlier run exported the room but stopped before recording it.
+	dir := t.TempDir()
+	orphan := filepath.Join(dir, "room-"+roomID.String()+"-20240101-1.jsonl.gz")
+	for _, path := range []string{orphan, filepath.Join(dir, "room-"+roomID.String()+"-manifest.json")} {
+		if err := os.WriteFile(path, []byte("partial"), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	migration := chatwork.NewMigration(source.Client(), dest.Client(), chatwork.NewIDMap(), dir)
+	ctx := context.Background()
+	plan, err := migration.Plan(ctx, []chatwork.RoomID{roomID})
+	if err != nil {
+		t.Fatalf("Plan returned error: %v", err)
+	}
+	checkpoint, err := migration.Apply(ctx, plan)
+	if err != nil {
+		t.Fatalf("Apply returned error: %v", err)
+	}
+
+	if _, err := os.Stat(orphan); !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("Expected the earlier export's file to be removed, got %v", err)
+	}
+	manifest, err := chatwork.VerifyArchive(checkpoint.Rooms[roomID].Archive)
+	if err != nil {
+		t.Fatalf("VerifyArchive returned error: %v", err)
+	}
+	if len(manifest.Files) != 1 || manifest.Records() != 1 {
+		t.Errorf("Expected a fresh archive of one file, got %+v", manifest.Files)
+	}
+}
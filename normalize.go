@@ -0,0 +1,94 @@
+package chatwork
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"regexp"
+	"strings"
+)
+
+var (
+	// codeBlockPattern matches [code]...[/code] blocks, whose contents are kept verbatim.
+	codeBlockPattern = regexp.MustCompile(`(?is)\[code\].*?\[/code\]`)
+
+	// tagNamePattern matches the opening of a ChatWork notation tag and captures its name.
+	tagNamePattern = regexp.MustCompile(`\[(/?)([A-Za-z]+)([:\s\]])`)
+
+	// blankLinesPattern matches runs of two or more empty lines.
+	blankLinesPattern = regexp.MustCompile(`\n{3,}`)
+)
+
+// canonicalTags maps lower-cased ChatWork tag names to their canonical spelling.
+var canonicalTags = map[string]string{
+	"to":        "To",
+	"toall":     "toall",
+	"rp":        "rp",
+	"qt":        "qt",
+	"qtmeta":    "qtmeta",
+	"info":      "info",
+	"title":     "title",
+	"code":      "code",
+	"hr":        "hr",
+	"picon":     "picon",
+	"piconname": "piconname",
+	"preview":   "preview",
+	"download":  "download",
+	"task":      "task",
+}
+
+// Normalize returns the canonical form of a message body.
+//
+// Line endings are converted to "\n", trailing whitespace is removed from each
+// line, runs of blank lines are collapsed into one, and known ChatWork tags are
+// rewritten with their canonical casing (for example "[to:1]" becomes "[To:1]").
+// The contents of [code] blocks are left untouched apart from line endings.
+//
+// Two bodies that render the same in ChatWork normalize to the same string,
+// which makes Normalize suitable as the basis for deduplication and hashing.
+func Normalize(body string) string {
+	body = strings.ReplaceAll(body, "\r\n", "\n")
+	body = strings.ReplaceAll(body, "\r", "\n")
+
+	var b strings.Builder
+	last := 0
+	for _, loc := range codeBlockPattern.FindAllStringIndex(body, -1) {
+		b.WriteString(normalizeText(body[last:loc[0]]))
+		b.WriteString(normalizeTags(body[loc[0] : loc[0]+len("[code]")]))
+		b.WriteString(body[loc[0]+len("[code]") : loc[1]-len("[/code]")])
+		b.WriteString(normalizeTags(body[loc[1]-len("[/code]") : loc[1]]))
+		last = loc[1]
+	}
+	b.WriteString(normalizeText(body[last:]))
+
+	return strings.TrimSpace(b.String())
+}
+
+// Fingerprint returns a hex-encoded SHA-256 hash of the normalized body.
+// Bodies that differ only in formatting noise produce the same fingerprint.
+func Fingerprint(body string) string {
+	sum := sha256.Sum256([]byte(Normalize(body)))
+	return hex.EncodeToString(sum[:])
+}
+
+// normalizeText canonicalizes a segment of a body that is outside code blocks.
+func normalizeText(text string) string {
+	lines := strings.Split(text, "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimRight(line, " \t\u00a0\u3000")
+	}
+	text = strings.Join(lines, "\n")
+	text = blankLinesPattern.ReplaceAllString(text, "\n\n")
+	return normalizeTags(text)
+}
+
+// normalizeTags rewrites known tag names with their canonical casing.
+func normalizeTags(text string) string {
+	return tagNamePattern.ReplaceAllStringFunc(text, func(tag string) string {
+		m := tagNamePattern.FindStringSubmatch(tag)
+		name, ok := canonicalTags[strings.ToLower(m[2])]
+		if !ok {
+			return tag
+		}
+		return "[" + m[1] + name + m[3]
+	})
+}
@@ -0,0 +1,57 @@
+package chatwork
+
+import "testing"
+
+func TestNormalize(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+		want string
+	}{
+		{
+			name: "line endings and trailing whitespace",
+			body: "hello  \r\nworld\t\r\n",
+			want: "hello\nworld",
+		},
+		{
+			name: "blank line runs",
+			body: "a\n\n\n\nb",
+			want: "a\n\nb",
+		},
+		{
+			name: "tag casing",
+			body: "[to:123] [INFO][Title]t[/TITLE]b[/Info] [TOALL]",
+			want: "[To:123] [info][title]t[/title]b[/info] [toall]",
+		},
+		{
+			name: "unknown tags untouched",
+			body: "[IMPORTANT] read this",
+			want: "[IMPORTANT] read this",
+		},
+		{
+			name: "code block contents preserved",
+			body: "[CODE]x  \n\n\n\n[INFO]  [/Code]\r\nafter  ",
+			want: "[code]x  \n\n\n\n[INFO]  [/code]\nafter",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Normalize(tt.body); got != tt.want {
+				t.Errorf("Normalize(%q) = %q, want %q", tt.body, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFingerprint(t *testing.T) {
+	a := Fingerprint("[to:1] Deploy finished  \r\n")
+	b := Fingerprint("[To:1] Deploy finished")
+	if a != b {
+		t.Errorf("Expected equivalent bodies to share a fingerprint, got %s and %s", a, b)
+	}
+
+	if a == Fingerprint("[To:2] Deploy finished") {
+		t.Error("Expected different bodies to have different fingerprints")
+	}
+}
@@ -0,0 +1,282 @@
+package chatwork
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	defaultOAuthAuthURL  = "https://www.chatwork.com/packages/oauth2/login.php"
+	defaultOAuthTokenURL = "https://oauth.chatwork.com/token"
+
+	// tokenExpiryDelta is how long before its expiry an access token is refreshed.
+	tokenExpiryDelta = 10 * time.Second
+)
+
+// NewOAuth creates a new ChatWork API client that authenticates with OAuth 2.0.
+//
+// The access token is obtained from tokens on every request and sent in an
+// "Authorization: Bearer" header. Use OAuthConfig.TokenProvider to get a
+// provider that refreshes expired tokens automatically.
+//
+// Example:
+//
+//	config := &chatwork.OAuthConfig{
+//		ClientID:     "CLIENT_ID",
+//		ClientSecret: "CLIENT_SECRET",
+//		RedirectURL:  "https://example.com/callback",
+//	}
+//	token, err := config.Exchange(ctx, code, "")
+//	client := chatwork.NewOAuth(config.TokenProvider(token))
+func NewOAuth(tokens TokenProvider, options ...ClientOption) *Client {
+	options = append([]ClientOption{OptionTokenProvider(tokens), optionBearerAuth()}, options...)
+	return New("", options...)
+}
+
+// optionBearerAuth makes the client send tokens in an Authorization header.
+func optionBearerAuth() ClientOption {
+	return func(c *Client) {
+		c.bearerAuth = true
+	}
+}
+
+// OAuthToken is a set of OAuth 2.0 credentials issued by ChatWork.
+type OAuthToken struct {
+	AccessToken  string    `json:"access_token"`
+	TokenType    string    `json:"token_type"`
+	RefreshToken string    `json:"refresh_token,omitempty"`
+	Scope        string    `json:"scope,omitempty"`
+	Expiry       time.Time `json:"expiry,omitempty"`
+}
+
+// Valid reports whether the access token is present and not about to expire.
+func (t *OAuthToken) Valid() bool {
+	if t == nil || t.AccessToken == "" {
+		return false
+	}
+	return t.Expiry.IsZero() || time.Now().Add(tokenExpiryDelta).Before(t.Expiry)
+}
+
+// OAuthError is returned when the ChatWork token endpoint rejects a request.
+type OAuthError struct {
+	// The HTTP status code returned by the token endpoint
+	StatusCode int
+
+	// Error code and human-readable description as defined by RFC 6749
+	Code        string `json:"error"`
+	Description string `json:"error_description"`
+}
+
+// Error returns a human-readable description of the OAuth error.
+func (e *OAuthError) Error() string {
+	if e.Description == "" {
+		return fmt.Sprintf("oauth: %d %s", e.StatusCode, e.Code)
+	}
+	return fmt.Sprintf("oauth: %d %s: %s", e.StatusCode, e.Code, e.Description)
+}
+
+// OAuthConfig describes a ChatWork OAuth 2.0 client application.
+//
+// It implements the authorization code flow: send the user to AuthCodeURL,
+// then trade the code ChatWork redirects back with for a token via Exchange.
+type OAuthConfig struct {
+	// Credentials of the client registered with ChatWork
+	ClientID     string
+	ClientSecret string
+
+	// Redirect URI registered for the client
+	RedirectURL string
+
+	// Requested scopes, such as "rooms.all:read_write" or "users.profile.me:read"
+	Scopes []string
+
+	// Endpoint overrides. The public ChatWork endpoints are used when empty.
+	AuthURL  string
+	TokenURL string
+
+	// HTTP client used for token requests. Defaults to http.DefaultClient.
+	HTTPClient *http.Client
+}
+
+// AuthCodeURL returns the consent page URL to which the user should be redirected.
+//
+// state is echoed back to the redirect URL and should be verified by the caller.
+// If codeChallenge is non-empty, it is sent as an S256 PKCE challenge and the
+// matching verifier must be passed to Exchange.
+func (c *OAuthConfig) AuthCodeURL(state, codeChallenge string) string {
+	authURL := c.AuthURL
+	if authURL == "" {
+		authURL = defaultOAuthAuthURL
+	}
+
+	v := url.Values{}
+	v.Set("response_type", "code")
+	v.Set("client_id", c.ClientID)
+	if c.RedirectURL != "" {
+		v.Set("redirect_uri", c.RedirectURL)
+	}
+	if len(c.Scopes) > 0 {
+		v.Set("scope", strings.Join(c.Scopes, " "))
+	}
+	if state != "" {
+		v.Set("state", state)
+	}
+	if codeChallenge != "" {
+		v.Set("code_challenge", codeChallenge)
+		v.Set("code_challenge_method", "S256")
+	}
+
+	if strings.Contains(authURL, "?") {
+		return authURL + "&" + v.Encode()
+	}
+	return authURL + "?" + v.Encode()
+}
+
+// Exchange converts an authorization code into a token.
+// codeVerifier is the PKCE verifier, or empty if no challenge was sent.
+func (c *OAuthConfig) Exchange(ctx context.Context, code, codeVerifier string) (*OAuthToken, error) {
+	v := url.Values{}
+	v.Set("grant_type", "authorization_code")
+	v.Set("code", code)
+	if c.RedirectURL != "" {
+		v.Set("redirect_uri", c.RedirectURL)
+	}
+	if codeVerifier != "" {
+		v.Set("code_verifier", codeVerifier)
+	}
+	return c.retrieveToken(ctx, v)
+}
+
+// Refresh obtains a new token using a refresh token.
+func (c *OAuthConfig) Refresh(ctx context.Context, refreshToken string) (*OAuthToken, error) {
+	v := url.Values{}
+	v.Set("grant_type", "refresh_token")
+	v.Set("refresh_token", refreshToken)
+	if len(c.Scopes) > 0 {
+		v.Set("scope", strings.Join(c.Scopes, " "))
+	}
+	return c.retrieveToken(ctx, v)
+}
+
+// TokenProvider returns a TokenProvider that serves token and refreshes it when it expires.
+func (c *OAuthConfig) TokenProvider(token *OAuthToken) *OAuthTokenProvider {
+	return &OAuthTokenProvider{config: c, token: token}
+}
+
+// retrieveToken posts v to the token endpoint.
+func (c *OAuthConfig) retrieveToken(ctx context.Context, v url.Values) (*OAuthToken, error) {
+	tokenURL := c.TokenURL
+	if tokenURL == "" {
+		tokenURL = defaultOAuthTokenURL
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", tokenURL, strings.NewReader(v.Encode()))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+	req.SetBasicAuth(url.QueryEscape(c.ClientID), url.QueryEscape(c.ClientSecret))
+
+	httpClient := c.HTTPClient
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		oauthErr := &OAuthError{StatusCode: resp.StatusCode}
+		_ = json.Unmarshal(data, oauthErr)
+		return nil, oauthErr
+	}
+
+	var body struct {
+		AccessToken  string `json:"access_token"`
+		TokenType    string `json:"token_type"`
+		RefreshToken string `json:"refresh_token"`
+		Scope        string `json:"scope"`
+		ExpiresIn    int64  `json:"expires_in"`
+	}
+	if err := json.Unmarshal(data, &body); err != nil {
+		return nil, fmt.Errorf("oauth: failed to parse token response: %w", err)
+	}
+	if body.AccessToken == "" {
+		return nil, &OAuthError{StatusCode: resp.StatusCode, Code: "invalid_response", Description: "token response has no access_token"}
+	}
+
+	token := &OAuthToken{
+		AccessToken:  body.AccessToken,
+		TokenType:    body.TokenType,
+		RefreshToken: body.RefreshToken,
+		Scope:        body.Scope,
+	}
+	if body.ExpiresIn > 0 {
+		token.Expiry = time.Now().Add(time.Duration(body.ExpiresIn) * time.Second)
+	}
+	if token.RefreshToken == "" {
+		token.RefreshToken = v.Get("refresh_token")
+	}
+
+	return token, nil
+}
+
+// OAuthTokenProvider is a TokenProvider backed by an OAuth 2.0 token.
+// It is safe for concurrent use.
+type OAuthTokenProvider struct {
+	config *OAuthConfig
+
+	mu    sync.Mutex
+	token *OAuthToken
+
+	// OnRefresh, if set, is called with every newly issued token so that it
+	// can be persisted. ChatWork rotates refresh tokens on use.
+	OnRefresh func(*OAuthToken)
+}
+
+// Token returns a valid access token, refreshing it first if it has expired.
+func (p *OAuthTokenProvider) Token(ctx context.Context) (string, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.token.Valid() {
+		return p.token.AccessToken, nil
+	}
+	if p.token == nil || p.token.RefreshToken == "" {
+		return "", &OAuthError{Code: "invalid_grant", Description: "access token expired and no refresh token is available"}
+	}
+
+	token, err := p.config.Refresh(ctx, p.token.RefreshToken)
+	if err != nil {
+		return "", err
+	}
+	p.token = token
+	if p.OnRefresh != nil {
+		p.OnRefresh(token)
+	}
+
+	return token.AccessToken, nil
+}
+
+// CurrentToken returns the token most recently issued to the provider.
+func (p *OAuthTokenProvider) CurrentToken() *OAuthToken {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.token
+}
@@ -0,0 +1,116 @@
+package chatwork
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestOAuthConfig_AuthCodeURL(t *testing.T) {
+	config := &OAuthConfig{
+		ClientID:    "client",
+		RedirectURL: "https://example.com/callback",
+		Scopes:      []string{"rooms.all:read_write", "users.profile.me:read"},
+	}
+
+	u, err := url.Parse(config.AuthCodeURL("xyz", "challenge"))
+	if err != nil {
+		t.Fatalf("AuthCodeURL returned an invalid URL: %v", err)
+	}
+
+	q := u.Query()
+	expected := map[string]string{
+		"response_type":         "code",
+		"client_id":             "client",
+		"redirect_uri":          "https://example.com/callback",
+		"scope":                 "rooms.all:read_write users.profile.me:read",
+		"state":                 "xyz",
+		"code_challenge":        "challenge",
+		"code_challenge_method": "S256",
+	}
+	for key, want := range expected {
+		if got := q.Get(key); got != want {
+			t.Errorf("Expected %s=%q, got %q", key, want, got)
+		}
+	}
+}
+
+func TestNewOAuth_RefreshesExpiredToken(t *testing.T) {
+	var grants []string
+	tokenServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if user, pass, ok := r.BasicAuth(); !ok || user != "client" || pass != "secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			w.Write([]byte(`{"error": "invalid_client"}`))
+			return
+		}
+		r.ParseForm()
+		grants = append(grants, r.PostForm.Get("grant_type")+":"+r.PostForm.Get("refresh_token"))
+		w.Write([]byte(`{"access_token": "fresh", "token_type": "Bearer", "expires_in": 1800, "refresh_token": "next"}`))
+	}))
+	defer tokenServer.Close()
+
+	var gotAuth string
+	apiServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotAuth = r.Header.Get("Authorization")
+		w.Write([]byte(`{}`))
+	}))
+	defer apiServer.Close()
+
+	config := &OAuthConfig{ClientID: "client", ClientSecret: "secret", TokenURL: tokenServer.URL}
+	provider := config.TokenProvider(&OAuthToken{
+		AccessToken:  "stale",
+		RefreshToken: "old",
+		Expiry:       time.Now().Add(-time.Minute),
+	})
+
+	var refreshed *OAuthToken
+	provider.OnRefresh = func(token *OAuthToken) { refreshed = token }
+
+	client := NewOAuth(provider)
+	client.BaseURL, _ = url.Parse(apiServer.URL)
+
+	if _, _, err := client.Me.Get(context.Background()); err != nil {
+		t.Fatalf("Me.Get returned error: %v", err)
+	}
+
+	if gotAuth != "Bearer fresh" {
+		t.Errorf("Expected Authorization header %q, got %q", "Bearer fresh", gotAuth)
+	}
+	if len(grants) != 1 || grants[0] != "refresh_token:old" {
+		t.Errorf("Expected a single refresh_token grant, got %v", grants)
+	}
+	if refreshed == nil || refreshed.RefreshToken != "next" {
+		t.Errorf("Expected OnRefresh to receive the rotated token, got %+v", refreshed)
+	}
+
+	// A valid token must not trigger another refresh.
+	if _, _, err := client.Me.Get(context.Background()); err != nil {
+		t.Fatalf("Me.Get returned error: %v", err)
+	}
+	if len(grants) != 1 {
+		t.Errorf("Expected the cached token to be reused, got %d grants", len(grants))
+	}
+}
+
+func TestOAuthConfig_ExchangeError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`{"error": "invalid_grant", "error_description": "code expired"}`))
+	}))
+	defer server.Close()
+
+	config := &OAuthConfig{ClientID: "client", ClientSecret: "secret", TokenURL: server.URL}
+	_, err := config.Exchange(context.Background(), "code", "")
+
+	oauthErr, ok := err.(*OAuthError)
+	if !ok {
+		t.Fatalf("Expected *OAuthError, got %T", err)
+	}
+	if oauthErr.Code != "invalid_grant" || !strings.Contains(oauthErr.Error(), "code expired") {
+		t.Errorf("Unexpected error: %v", oauthErr)
+	}
+}
@@ -0,0 +1,125 @@
+package chatwork
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrInvalidParams is returned for parameters that are rejected before a
+// request is sent, such as nil parameters of a method that requires them.
+//
+// Methods whose parameters are all optional, such as MessagesService.List,
+// accept nil parameters and use the defaults.
+var ErrInvalidParams = errors.New("chatwork: invalid parameters")
+
+// errNilParams is returned by methods that require parameters.
+var errNilParams = fmt.Errorf("%w: params are required", ErrInvalidParams)
+
+// NewMessageListParams returns the default parameters for listing messages:
+// only the messages not listed before with the same token.
+func NewMessageListParams() *MessageListParams {
+	return &MessageListParams{}
+}
+
+// Forced makes List return the 100 most recent messages, whether listed
+// before or not, and returns p.
+func (p *MessageListParams) Forced() *MessageListParams {
+	p.Force = 1
+	return p
+}
+
+// Validate returns an error wrapping ErrInvalidParams if p is invalid.
+func (p *MessageListParams) Validate() error {
+	if p.Force != 0 && p.Force != 1 {
+		return fmt.Errorf("%w: force must be 0 or 1, got %d", ErrInvalidParams, p.Force)
+	}
+	return nil
+}
+
+// NewTaskListParams returns the default parameters for listing the tasks of
+// a room: all tasks.
+func NewTaskListParams() *TaskListParams {
+	return &TaskListParams{}
+}
+
+// Assignee selects the tasks assigned to an account and returns p.
+func (p *TaskListParams) Assignee(accountID AccountID) *TaskListParams {
+	p.AccountID = accountID
+	return p
+}
+
+// AssignedBy selects the tasks assigned by an account and returns p.
+func (p *TaskListParams) AssignedBy(accountID AccountID) *TaskListParams {
+	p.AssignedByAccountID = accountID
+	return p
+}
+
+// WithStatus selects the tasks with a status and returns p.
+func (p *TaskListParams) WithStatus(status TaskStatus) *TaskListParams {
+	p.Status = status
+	return p
+}
+
+// Validate returns an error wrapping ErrInvalidParams if p is invalid.
+func (p *TaskListParams) Validate() error {
+	if p.AccountID < 0 || p.AssignedByAccountID < 0 {
+		return fmt.Errorf("%w: negative account ID", ErrInvalidParams)
+	}
+	if p.Status != "" {
+		return p.Status.Validate()
+	}
+	return nil
+}
+
+// NewMyTaskListParams returns the default parameters for listing the tasks
+// of the authenticated user: all tasks.
+func NewMyTaskListParams() *MyTaskListParams {
+	return &MyTaskListParams{}
+}
+
+// AssignedBy selects the tasks assigned by an account and returns p.
+func (p *MyTaskListParams) AssignedBy(accountID AccountID) *MyTaskListParams {
+	p.AssignedByAccountID = accountID
+	return p
+}
+
+// WithStatus selects the tasks with a status and returns p.
+func (p *MyTaskListParams) WithStatus(status TaskStatus) *MyTaskListParams {
+	p.Status = status
+	return p
+}
+
+// Validate returns an error wrapping ErrInvalidParams if p is invalid.
+func (p *MyTaskListParams) Validate() error {
+	if p.AssignedByAccountID < 0 {
+		return fmt.Errorf("%w: negative account ID", ErrInvalidParams)
+	}
+	if p.Status != "" {
+		return p.Status.Validate()
+	}
+	return nil
+}
+
+// Validate returns an error wrapping ErrInvalidParams if p is invalid.
+func (p *TaskCreateParams) Validate() error {
+	if p.LimitType != "" {
+		return p.LimitType.Validate()
+	}
+	return nil
+}
+
+// Validate returns an error wrapping ErrInvalidParams if p is invalid.
+func (p *RoomCreateParams) Validate() error {
+	if p.IconPreset != "" {
+		return p.IconPreset.Validate()
+	}
+	return nil
+}
+
+// Validate returns an error wrapping ErrInvalidParams if p is invalid.
+func (p *RoomUpdateParams) Validate() error {
+	if p.IconPreset != "" {
+		return p.IconPreset.Validate()
+	}
+	return nil
+}
@@ -0,0 +1,175 @@
+package chatwork_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/nashirox/chatwork-go"
+	"github.com/nashirox/chatwork-go/chatworktest"
+)
+
+func TestNilParams(t *testing.T) {
+	srv := chatworktest.NewServer()
+	defer srv.Close()
+
+	roomID := srv.AddRoom(chatwork.Room{Name: "Team", Role: "admin"})
+	other := srv.AddRoom(chatwork.Room{Name: "Other", Role: "admin"})
+	messageID := srv.AddMessage(roomID, chatwork.Message{Body: "hello"})
+	requests := 0
+	client := srv.Client(chatwork.OptionOnRequest(func(*http.Request) { requests++ }))
+	ctx := context.Background()
+
+	// Methods whose parameters are optional use the defaults.
+	optional := map[string]func() error{
+		"Messages.List": func() error {
+			_, _, err := client.Messages.List(ctx, roomID, nil)
+			return err
+		},
+		"Messages.ListAll": func() error {
+			_, _, err := client.Messages.ListAll(ctx, roomID, nil)
+			return err
+		},
+		"Rooms.GetTasks": func() error {
+			_, _, err := client.Rooms.GetTasks(ctx, roomID, nil)
+			return err
+		},
+		"Rooms.IterFiles": func() error {
+			it := client.Rooms.IterFiles(ctx, roomID, nil)
+			for it.Next() {
+			}
+			return it.Err()
+		},
+		"Rooms.SyncMembers": func() error {
+			_, _, err := client.Rooms.SyncMembers(ctx, roomID, other, nil)
+			return err
+		},
+		"MyTasks.List": func() error {
+			_, _, err := client.MyTasks.List(ctx, nil)
+			return err
+		},
+		"IncomingRequests.ListFiltered": func() error {
+			_, _, err := client.IncomingRequests.ListFiltered(ctx, nil)
+			return err
+		},
+		"Me.MarkAllAsRead": func() error {
+			_, err := client.Me.MarkAllAsRead(ctx, nil)
+			return err
+		},
+	}
+	for name, call := range optional {
+		if err := call(); err != nil {
+			t.Errorf("%s with nil params returned error: %v", name, err)
+		}
+	}
+
+	// Methods that require parameters fail without sending a request.
+	required := map[string]func() error{
+		"Messages.Create": func() error {
+			_, _, err := client.Messages.Create(ctx, roomID, nil)
+			return err
+		},
+		"Messages.Update": func() error {
+			_, _, err := client.Messages.Update(ctx, roomID, messageID, nil)
+			return err
+		},
+		"Rooms.Create": func() error {
+			_, _, err := client.Rooms.Create(ctx, nil)
+			return err
+		},
+		"Rooms.Update": func() error {
+			_, _, err := client.Rooms.Update(ctx, roomID, nil)
+			return err
+		},
+		"Rooms.ReplaceMembers": func() error {
+			_, _, err := client.Rooms.ReplaceMembers(ctx, roomID, nil)
+			return err
+		},
+		"Rooms.UpdateMembers": func() error {
+			_, _, err := client.Rooms.UpdateMembers(ctx, roomID, nil)
+			return err
+		},
+		"Tasks.Create": func() error {
+			_, _, err := client.Tasks.Create(ctx, roomID, nil)
+			return err
+		},
+	}
+	for name, call := range required {
+		before := requests
+		if err := call(); !errors.Is(err, chatwork.ErrInvalidParams) {
+			t.Errorf("%s with nil params: expected ErrInvalidParams, got %v", name, err)
+		}
+		if requests != before {
+			t.Errorf("%s with nil params sent a request", name)
+		}
+	}
+}
+
+func TestParamConstructors(t *testing.T) {
+	if p := chatwork.NewMessageListParams().Forced(); p.Force != 1 || p.Validate() != nil {
+		t.Errorf("Unexpected message list params: %+v", p)
+	}
+	if err := (&chatwork.MessageListParams{Force: 2}).Validate(); !errors.Is(err, chatwork.ErrInvalidParams) {
+		t.Errorf("Expected ErrInvalidParams for force 2, got %v", err)
+	}
+
+	p := chatwork.NewTaskListParams().Assignee(1).AssignedBy(2).WithStatus(chatwork.TaskStatusOpen)
+	if p.AccountID != 1 || p.AssignedByAccountID != 2 || p.Status != chatwork.TaskStatusOpen || p.Validate() != nil {
+		t.Errorf("Unexpected task list params: %+v", p)
+	}
+	if err := chatwork.NewMyTaskListParams().WithStatus("closed").Validate(); !errors.Is(err, chatwork.ErrInvalidParams) {
+		t.Errorf("Expected ErrInvalidParams for status closed, got %v", err)
+	}
+
+	srv := chatworktest.NewServer()
+	defer srv.Close()
+	_, _, err := srv.Client().MyTasks.List(context.Background(), chatwork.NewMyTaskListParams().WithStatus("closed"))
+	if !errors.Is(err, chatwork.ErrInvalidParams) {
+		t.Errorf("Expected List to validate its params, got %v", err)
+	}
+	_, _, err = srv.Client().Tasks.Create(context.Background(), 1, &chatwork.TaskCreateParams{Body: "Report", ToIDs: []chatwork.AccountID{1}, LimitType: "week"})
+	if !errors.Is(err, chatwork.ErrInvalidParams) {
+		t.Errorf("Expected Create to validate its params, got %v", err)
+	}
+}
+
+func TestEnums(t *testing.T) {
+	valid := []interface{ Validate() error }{
+		chatwork.RoleAdmin, chatwork.RoleMember, chatwork.RoleReadonly,
+		chatwork.RoomTypeGroup, chatwork.RoomTypeDirect, chatwork.RoomTypeMy,
+		chatwork.IconPresetMeeting, chatwork.IconPresetTravel,
+		chatwork.TaskStatusOpen, chatwork.TaskStatusDone,
+		chatwork.LimitTypeNone, chatwork.LimitTypeDate, chatwork.LimitTypeTime,
+	}
+	for _, v := range valid {
+		if err := v.Validate(); err != nil {
+			t.Errorf("Expected %v to be valid, got %v", v, err)
+		}
+	}
+	invalid := []interface{ Validate() error }{
+		chatwork.Role("owner"), chatwork.RoomType(""), chatwork.IconPreset("rocket"), chatwork.TaskStatus("closed"), chatwork.LimitType("week"),
+	}
+	for _, v := range invalid {
+		if err := v.Validate(); !errors.Is(err, chatwork.ErrInvalidParams) {
+			t.Errorf("Expected ErrInvalidParams for %q, got %v", v, err)
+		}
+	}
+	if s := chatwork.RoleReadonly.String(); s != "readonly" {
+		t.Errorf("Expected readonly, got %s", s)
+	}
+
+	srv := chatworktest.NewServer()
+	defer srv.Close()
+	client := srv.Client()
+	ctx := context.Background()
+	if _, _, err := client.Rooms.Create(ctx, &chatwork.RoomCreateParams{Name: "Room", IconPreset: "rocket"}); !errors.Is(err, chatwork.ErrInvalidParams) {
+		t.Errorf("Expected Create to validate the icon preset, got %v", err)
+	}
+	if _, _, err := client.Tasks.UpdateStatus(ctx, 1, 1, "closed"); !errors.Is(err, chatwork.ErrInvalidParams) {
+		t.Errorf("Expected UpdateStatus to validate the status, got %v", err)
+	}
+	if _, _, err := client.Rooms.AddMembers(ctx, 1, []chatwork.AccountID{2}, "owner"); !errors.Is(err, chatwork.ErrInvalidParams) {
+		t.Errorf("Expected AddMembers to validate the role, got %v", err)
+	}
+}
@@ -0,0 +1,147 @@
+package chatwork
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// ErrPermissionDenied is returned by clients derived with WithPermissions for
+// calls that their permissions do not allow. No request is sent for them.
+var ErrPermissionDenied = errors.New("chatwork: permission denied")
+
+// Permissions restricts the calls a client can make, so that code such as
+// plugins or third-party handlers can be given a client with only the
+// privileges it needs.
+//
+// Calls are identified by the endpoint they use and named after the service
+// method that wraps it, such as "Rooms.Delete". Convenience methods are
+// covered by the method they build on: SendMessage by "Messages.Create",
+// and Leave and DeleteRoom by "Rooms.Delete". Endpoints this package does not
+// wrap, called through Do, are only restricted by ReadOnly.
+type Permissions struct {
+	// Services whose calls are all denied, named as the Client fields, such
+	// as "Rooms" or "IncomingRequests"
+	DisabledServices []string
+
+	// Individual calls that are denied, such as "Rooms.UpdateMembers"
+	Denied []string
+
+	// Deny every call that is not a GET request
+	ReadOnly bool
+}
+
+// operations maps endpoints to the names used by Permissions. More specific
+// patterns come first; "*" matches a single path segment.
+var operations = []struct {
+	method  string
+	pattern string
+	name    string
+}{
+	{"GET", "me", "Me.Get"},
+	{"GET", "my/status", "Me.GetStatus"},
+	{"GET", "my/tasks", "MyTasks.List"},
+	{"GET", "contacts", "Contacts.List"},
+	{"GET", "incoming_requests", "IncomingRequests.List"},
+	{"PUT", "incoming_requests/*", "IncomingRequests.Approve"},
+	{"DELETE", "incoming_requests/*", "IncomingRequests.Reject"},
+	{"GET", "rooms", "Rooms.List"},
+	{"POST", "rooms", "Rooms.Create"},
+	{"GET", "rooms/*", "Rooms.Get"},
+	{"PUT", "rooms/*", "Rooms.Update"},
+	{"DELETE", "rooms/*", "Rooms.Delete"},
+	{"GET", "rooms/*/members", "Rooms.GetMembers"},
+	{"PUT", "rooms/*/members", "Rooms.UpdateMembers"},
+	{"GET", "rooms/*/messages", "Messages.List"},
+	{"POST", "rooms/*/messages", "Messages.Create"},
+	{"GET", "rooms/*/messages/read", "Rooms.GetMessagesReadStatus"},
+	{"PUT", "rooms/*/messages/read", "Rooms.MarkMessagesAsRead"},
+	{"GET", "rooms/*/messages/unread", "Rooms.GetMessagesUnreadCount"},
+	{"GET", "rooms/*/messages/*", "Messages.Get"},
+	{"PUT", "rooms/*/messages/*", "Messages.Update"},
+	{"DELETE", "rooms/*/messages/*", "Messages.Delete"},
+	{"GET", "rooms/*/tasks", "Rooms.GetTasks"},
+	{"POST", "rooms/*/tasks", "Tasks.Create"},
+	{"GET", "rooms/*/tasks/*", "Tasks.Get"},
+	{"PUT", "rooms/*/tasks/*/status", "Tasks.UpdateStatus"},
+	{"GET", "rooms/*/files", "Rooms.GetFiles"},
+	{"POST", "rooms/*/files", "Rooms.UploadFile"},
+	{"GET", "rooms/*/files/*", "Rooms.GetFile"},
+}
+
+// WithPermissions returns a copy of the client whose calls are restricted by
+// p, in addition to any restrictions of c itself. The copy shares the
+// configuration of c, including its HTTP client and rate limiter.
+func (c *Client) WithPermissions(p Permissions) *Client {
+	derived := new(Client)
+	*derived = *c
+	derived.permissions = append(append([]Permissions(nil), c.permissions...), p)
+	derived.extensions = &extensionSet{}
+
+	derived.common.client = derived
+	derived.Rooms = (*RoomsService)(&derived.common)
+	derived.Messages = (*MessagesService)(&derived.common)
+	derived.Me = (*MeService)(&derived.common)
+	derived.MyTasks = (*MyTasksService)(&derived.common)
+	derived.Contacts = (*ContactsService)(&derived.common)
+	derived.Tasks = (*TasksService)(&derived.common)
+	derived.IncomingRequests = (*IncomingRequestsService)(&derived.common)
+	return derived
+}
+
+// checkPermissions returns ErrPermissionDenied if req is not allowed by the
+// client's permissions.
+func (c *Client) checkPermissions(req *http.Request) error {
+	if len(c.permissions) == 0 {
+		return nil
+	}
+
+	operation := c.operation(req)
+	for _, p := range c.permissions {
+		if p.ReadOnly && req.Method != http.MethodGet {
+			return fmt.Errorf("%w: %s %s", ErrPermissionDenied, req.Method, req.URL.Path)
+		}
+		if operation == "" {
+			continue
+		}
+		service, _, _ := strings.Cut(operation, ".")
+		for _, disabled := range p.DisabledServices {
+			if disabled == service {
+				return fmt.Errorf("%w: %s", ErrPermissionDenied, operation)
+			}
+		}
+		for _, denied := range p.Denied {
+			if denied == operation {
+				return fmt.Errorf("%w: %s", ErrPermissionDenied, operation)
+			}
+		}
+	}
+	return nil
+}
+
+// operation returns the name of the call req makes, or "" for endpoints this
+// package does not wrap.
+func (c *Client) operation(req *http.Request) string {
+	path := strings.TrimPrefix(req.URL.Path, strings.TrimRight(c.BaseURL.Path, "/"))
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+
+	for _, op := range operations {
+		if op.method == req.Method && matchSegments(strings.Split(op.pattern, "/"), segments) {
+			return op.name
+		}
+	}
+	return ""
+}
+
+func matchSegments(pattern, segments []string) bool {
+	if len(pattern) != len(segments) {
+		return false
+	}
+	for i := range pattern {
+		if pattern[i] != "*" && pattern[i] != segments[i] {
+			return false
+		}
+	}
+	return true
+}
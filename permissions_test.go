@@ -0,0 +1,56 @@
+package chatwork
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+)
+
+func TestClient_WithPermissions(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	client := New(testToken)
+	client.BaseURL, _ = url.Parse(server.URL + "/v2")
+	plugin := client.WithPermissions(Permissions{
+		DisabledServices: []string{"IncomingRequests"},
+		Denied:           []string{"Rooms.Delete", "Rooms.UpdateMembers"},
+	})
+	readOnly := plugin.WithPermissions(Permissions{ReadOnly: true})
+	ctx := context.Background()
+
+	tests := []struct {
+		name    string
+		call    func() error
+		allowed bool
+	}{
+		{"send message", func() error { _, _, err := plugin.Messages.SendMessage(ctx, 1, "hi"); return err }, true},
+		{"leave room", func() error { _, err := plugin.Rooms.Leave(ctx, 1); return err }, false},
+		{"update members", func() error {
+			_, _, err := plugin.Rooms.UpdateMembers(ctx, 1, &RoomMembersUpdateParams{MembersAdminIDs: []AccountID{1}})
+			return err
+		}, false},
+		{"approve request", func() error { _, _, err := plugin.IncomingRequests.Approve(ctx, 1); return err }, false},
+		{"read-only get", func() error { _, _, err := readOnly.Rooms.Get(ctx, 1); return err }, true},
+		{"read-only send", func() error { _, _, err := readOnly.Messages.SendMessage(ctx, 1, "hi"); return err }, false},
+		{"read-only inherits", func() error { _, _, err := readOnly.IncomingRequests.List(ctx); return err }, false},
+		{"parent unrestricted", func() error { _, err := client.Rooms.Leave(ctx, 1); return err }, true},
+	}
+	for _, tt := range tests {
+		before := requests
+		err := tt.call()
+		if tt.allowed && err != nil {
+			t.Errorf("%s: expected the call to be allowed, got %v", tt.name, err)
+		}
+		if !tt.allowed && (!errors.Is(err, ErrPermissionDenied) || requests != before) {
+			t.Errorf("%s: expected the call to be denied without a request, got %v", tt.name, err)
+		}
+	}
+}
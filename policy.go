@@ -0,0 +1,242 @@
+package chatwork
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ErrPolicyDenied is returned by clients with a Policy for calls that it
+// denies. No request is sent for them.
+var ErrPolicyDenied = errors.New("chatwork: denied by policy")
+
+// Directions of the actions evaluated by a Policy.
+const (
+	PolicyOutgoing = "outgoing"
+	PolicyIncoming = "incoming"
+)
+
+// PolicyInput describes an action evaluated by a Policy: a call made by a
+// client, or an event received from a webhook or a watcher.
+type PolicyInput struct {
+	Direction string
+
+	// Name of the call, as used by Permissions, such as "Messages.Create",
+	// or the type of an incoming event, such as "message_created"
+	Operation string
+
+	// Room of the action, if any
+	RoomID RoomID
+
+	// Sender of an incoming message. Zero for outgoing calls.
+	AccountID AccountID
+
+	// Message body, if any
+	Body string
+
+	// When the action takes place. Set by Evaluate if zero.
+	Time time.Time
+}
+
+// IncomingMessage returns the input for a message received in a room, such
+// as from Client.Watch.
+func IncomingMessage(roomID RoomID, m *Message) *PolicyInput {
+	return &PolicyInput{
+		Direction: PolicyIncoming,
+		Operation: "message_created",
+		RoomID:    roomID,
+		AccountID: m.Account.AccountID,
+		Body:      m.Body,
+		Time:      m.SendTime.Time(),
+	}
+}
+
+// PolicyRule is a named rule of a Policy.
+type PolicyRule struct {
+	Name string
+
+	// Check returns why in is denied, or "" if it is allowed.
+	Check func(ctx context.Context, in *PolicyInput) string
+}
+
+// PolicyDecision is the result of evaluating an action.
+type PolicyDecision struct {
+	Input   *PolicyInput
+	Allowed bool
+
+	// Rule that denied the action, and why
+	Rule   string
+	Reason string
+}
+
+// Policy governs the messages an organization sends and receives, such as
+// who may post to which rooms, banned content, and posting hours.
+//
+// Set on a client with OptionPolicy, it evaluates every call other than GET
+// requests before it is sent. Incoming events are evaluated by passing them
+// to Evaluate, for example from a webhook handler. Every decision is passed
+// to OnDecision, for audit logs.
+type Policy struct {
+	// Rules in the order they are checked. The first rule that denies an
+	// action decides; actions no rule denies are allowed.
+	Rules []PolicyRule
+
+	// Called with every decision (optional)
+	OnDecision func(PolicyDecision)
+}
+
+// Evaluate checks in against the rules and returns the decision.
+func (p *Policy) Evaluate(ctx context.Context, in *PolicyInput) PolicyDecision {
+	if in.Time.IsZero() {
+		in.Time = time.Now()
+	}
+
+	decision := PolicyDecision{Input: in, Allowed: true}
+	for _, rule := range p.Rules {
+		if reason := rule.Check(ctx, in); reason != "" {
+			decision = PolicyDecision{Input: in, Rule: rule.Name, Reason: reason}
+			break
+		}
+	}
+	if p.OnDecision != nil {
+		p.OnDecision(decision)
+	}
+	return decision
+}
+
+// Err returns an error wrapping ErrPolicyDenied if d denies its action, or nil.
+func (d PolicyDecision) Err() error {
+	if d.Allowed {
+		return nil
+	}
+	return fmt.Errorf("%w: %s: %s", ErrPolicyDenied, d.Rule, d.Reason)
+}
+
+// OptionPolicy evaluates every call of the client other than GET requests
+// with p, and fails denied calls with ErrPolicyDenied.
+func OptionPolicy(p *Policy) ClientOption {
+	return func(c *Client) {
+		c.policy = p
+	}
+}
+
+// checkPolicy returns an error if req is denied by the client's policy.
+func (c *Client) checkPolicy(ctx context.Context, req *http.Request) error {
+	if c.policy == nil || req.Method == http.MethodGet {
+		return nil
+	}
+
+	in := &PolicyInput{
+		Direction: PolicyOutgoing,
+		Operation: c.operation(req),
+	}
+	if in.Operation == "" {
+		in.Operation = req.Method + " " + req.URL.Path
+	}
+	path := strings.TrimPrefix(req.URL.Path, strings.TrimRight(c.BaseURL.Path, "/"))
+	if segments := strings.Split(strings.Trim(path, "/"), "/"); len(segments) > 1 && segments[0] == "rooms" {
+		id, _ := strconv.Atoi(segments[1])
+		in.RoomID = RoomID(id)
+	}
+	if req.GetBody != nil && strings.HasPrefix(req.Header.Get("Content-Type"), "application/x-www-form-urlencoded") {
+		body, err := req.GetBody()
+		if err != nil {
+			return err
+		}
+		data, err := io.ReadAll(body)
+		body.Close()
+		if err != nil {
+			return err
+		}
+		if form, err := url.ParseQuery(string(data)); err == nil {
+			in.Body = form.Get("body")
+		}
+	}
+
+	return c.policy.Evaluate(ctx, in).Err()
+}
+
+// AllowRooms returns a rule that denies outgoing calls that change rooms
+// other than roomIDs, such as posting messages. Creating rooms is allowed.
+func AllowRooms(roomIDs ...RoomID) PolicyRule {
+	allowed := make(map[RoomID]bool, len(roomIDs))
+	for _, id := range roomIDs {
+		allowed[id] = true
+	}
+	return PolicyRule{
+		Name: "allow-rooms",
+		Check: func(ctx context.Context, in *PolicyInput) string {
+			if in.Direction == PolicyOutgoing && in.RoomID != 0 && !allowed[in.RoomID] {
+				return fmt.Sprintf("room %d is not allowed", in.RoomID)
+			}
+			return ""
+		},
+	}
+}
+
+// AllowPosters returns a rule that denies incoming messages in roomID from
+// accounts other than accountIDs.
+func AllowPosters(roomID RoomID, accountIDs ...AccountID) PolicyRule {
+	allowed := make(map[AccountID]bool, len(accountIDs))
+	for _, id := range accountIDs {
+		allowed[id] = true
+	}
+	return PolicyRule{
+		Name: "allow-posters",
+		Check: func(ctx context.Context, in *PolicyInput) string {
+			if in.Direction == PolicyIncoming && in.RoomID == roomID && !allowed[in.AccountID] {
+				return fmt.Sprintf("account %d may not post to room %d", in.AccountID, roomID)
+			}
+			return ""
+		},
+	}
+}
+
+// DenyContent returns a rule that denies messages, in both directions, whose
+// body matches pattern. category names the kind of content, such as
+// "credit-card-number", in the reason.
+func DenyContent(category string, pattern *regexp.Regexp) PolicyRule {
+	return PolicyRule{
+		Name: "deny-content",
+		Check: func(ctx context.Context, in *PolicyInput) string {
+			if in.Body != "" && pattern.MatchString(in.Body) {
+				return "message contains " + category
+			}
+			return ""
+		},
+	}
+}
+
+// BusinessHours returns a rule that denies outgoing messages outside of
+// start to end, as times of day in loc, on the given weekdays (Monday to
+// Friday if none are given).
+func BusinessHours(loc *time.Location, start, end time.Duration, days ...time.Weekday) PolicyRule {
+	if len(days) == 0 {
+		days = []time.Weekday{time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday}
+	}
+	workdays := make(map[time.Weekday]bool, len(days))
+	for _, d := range days {
+		workdays[d] = true
+	}
+	return PolicyRule{
+		Name: "business-hours",
+		Check: func(ctx context.Context, in *PolicyInput) string {
+			if in.Direction != PolicyOutgoing || !strings.HasPrefix(in.Operation, "Messages.") {
+				return ""
+			}
+			t := in.Time.In(loc)
+			midnight := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, loc)
+			if offset := t.Sub(midnight); !workdays[t.Weekday()] || offset < start || offset >= end {
+				return "outside of business hours"
+			}
+			return ""
+		},
+	}
+}
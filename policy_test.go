@@ -0,0 +1,93 @@
+package chatwork_test
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/nashirox/chatwork-go"
+	"github.com/nashirox/chatwork-go/chatworktest"
+)
+
+func TestOptionPolicy(t *testing.T) {
+	srv := chatworktest.NewServer()
+	defer srv.Close()
+
+	allowed := srv.AddRoom(chatwork.Room{Name: "Allowed"})
+	other := srv.AddRoom(chatwork.Room{Name: "Other"})
+
+	var decisions []chatwork.PolicyDecision
+	client := srv.Client(chatwork.OptionPolicy(&chatwork.Policy{
+		Rules: []chatwork.PolicyRule{
+			chatwork.AllowRooms(allowed),
+			chatwork.DenyContent("card number", regexp.MustCompile(`\d{4}-\d{4}-\d{4}-\d{4}`)),
+		},
+		OnDecision: func(d chatwork.PolicyDecision) { decisions = append(decisions, d) },
+	}))
+	ctx := context.Background()
+
+	if _, _, err := client.Messages.Create(ctx, allowed, &chatwork.MessageCreateParams{Body: "hello"}); err != nil {
+		t.Fatalf("Expected the message to be allowed, got %v", err)
+	}
+	_, _, err := client.Messages.Create(ctx, allowed, &chatwork.MessageCreateParams{Body: "card 1234-5678-9012-3456"})
+	if !errors.Is(err, chatwork.ErrPolicyDenied) {
+		t.Errorf("Expected the content to be denied, got %v", err)
+	}
+	_, _, err = client.Messages.Create(ctx, other, &chatwork.MessageCreateParams{Body: "hello"})
+	if !errors.Is(err, chatwork.ErrPolicyDenied) {
+		t.Errorf("Expected the room to be denied, got %v", err)
+	}
+	if _, _, err := client.Rooms.Get(ctx, other); err != nil {
+		t.Errorf("Expected reads to be allowed, got %v", err)
+	}
+
+	if len(decisions) != 3 {
+		t.Fatalf("Expected 3 decisions, got %d", len(decisions))
+	}
+	if d := decisions[0]; !d.Allowed || d.Input.Operation != "Messages.Create" || d.Input.RoomID != allowed || d.Input.Body != "hello" {
+		t.Errorf("Unexpected decision: %+v %+v", d, d.Input)
+	}
+	if d := decisions[1]; d.Allowed || d.Rule != "deny-content" {
+		t.Errorf("Unexpected decision: %+v", d)
+	}
+	if d := decisions[2]; d.Allowed || d.Rule != "allow-rooms" {
+		t.Errorf("Unexpected decision: %+v", d)
+	}
+	if messages := srv.Messages(allowed); len(messages) != 1 {
+		t.Errorf("Expected 1 message to be sent, got %d", len(messages))
+	}
+}
+
+func TestPolicy_Evaluate(t *testing.T) {
+	tokyo := time.FixedZone("JST", 9*60*60)
+	policy := &chatwork.Policy{Rules: []chatwork.PolicyRule{
+		chatwork.AllowPosters(1, 100),
+		chatwork.BusinessHours(tokyo, 9*time.Hour, 18*time.Hour),
+	}}
+	ctx := context.Background()
+
+	tests := []struct {
+		name  string
+		input *chatwork.PolicyInput
+		rule  string
+	}{
+		{"allowed poster", chatwork.IncomingMessage(1, &chatwork.Message{Account: chatwork.User{AccountID: 100}}), ""},
+		{"other poster", chatwork.IncomingMessage(1, &chatwork.Message{Account: chatwork.User{AccountID: 200}}), "allow-posters"},
+		{"other room", chatwork.IncomingMessage(2, &chatwork.Message{Account: chatwork.User{AccountID: 200}}), ""},
+		{"business hours", &chatwork.PolicyInput{Direction: chatwork.PolicyOutgoing, Operation: "Messages.Create", Time: time.Date(2024, 1, 10, 10, 0, 0, 0, tokyo)}, ""},
+		{"evening", &chatwork.PolicyInput{Direction: chatwork.PolicyOutgoing, Operation: "Messages.Create", Time: time.Date(2024, 1, 10, 18, 0, 0, 0, tokyo)}, "business-hours"},
+		{"weekend", &chatwork.PolicyInput{Direction: chatwork.PolicyOutgoing, Operation: "Messages.Create", Time: time.Date(2024, 1, 13, 10, 0, 0, 0, tokyo)}, "business-hours"},
+		{"other operation", &chatwork.PolicyInput{Direction: chatwork.PolicyOutgoing, Operation: "Rooms.Update", Time: time.Date(2024, 1, 13, 10, 0, 0, 0, tokyo)}, ""},
+	}
+	for _, tt := range tests {
+		d := policy.Evaluate(ctx, tt.input)
+		if d.Rule != tt.rule || d.Allowed != (tt.rule == "") {
+			t.Errorf("%s: unexpected decision %+v", tt.name, d)
+		}
+		if (d.Err() == nil) != d.Allowed {
+			t.Errorf("%s: unexpected error %v", tt.name, d.Err())
+		}
+	}
+}
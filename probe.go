@@ -0,0 +1,176 @@
+package chatwork
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// defaultProbeInterval is the default time between checks of a Probe.
+const defaultProbeInterval = time.Minute
+
+// ProbeResult is the outcome of a single check of a Probe.
+type ProbeResult struct {
+	Time time.Time
+
+	// Latency of the authenticated call (GET /me)
+	MeLatency time.Duration
+
+	// Latency of the send, read, and delete round trip in the canary room,
+	// or zero if it was not run
+	RoundTripLatency time.Duration
+
+	// The first failure of the check, or nil if it succeeded
+	Err error
+}
+
+// ProbeStats holds the metrics of a Probe, as returned by Probe.Stats.
+type ProbeStats struct {
+	// Number of checks, and how many of them failed
+	Checks   int64
+	Failures int64
+
+	// Consecutive failures since the last success
+	ConsecutiveFailures int
+
+	// Time of the last successful check
+	LastSuccess time.Time
+
+	// Result of the last check
+	Last *ProbeResult
+}
+
+// Probe periodically checks that ChatWork is available and the client's
+// token is accepted, so that operators notice outages and revoked tokens
+// before users do.
+//
+// Every check calls GET /me. With a CanaryRoomID, it also sends a message
+// to that room, reads it back, and deletes it, exercising the write path.
+// Use a dedicated room, since its members are notified of every check.
+// Results are passed to OnResult, for exporting to a metrics system, and
+// aggregated in Stats.
+type Probe struct {
+	client *Client
+
+	// Time between checks in Run. Defaults to one minute.
+	Interval time.Duration
+
+	// Room for the round trip check; zero skips it.
+	CanaryRoomID RoomID
+
+	// Called with the result of every check (optional)
+	OnResult func(*ProbeResult)
+
+	mu    sync.Mutex
+	stats ProbeStats
+}
+
+// NewProbe returns a Probe checking with client that calls onResult with
+// every result.
+func NewProbe(client *Client, onResult func(*ProbeResult)) *Probe {
+	return &Probe{
+		client:   client,
+		Interval: defaultProbeInterval,
+		OnResult: onResult,
+	}
+}
+
+// Run checks every Interval until ctx is done. Failed checks are reported
+// in their results rather than ending Run.
+func (p *Probe) Run(ctx context.Context, opts ...RequestOption) error {
+	interval := p.Interval
+	if interval <= 0 {
+		interval = defaultProbeInterval
+	}
+
+	for {
+		result := p.Check(ctx, opts...)
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if p.OnResult != nil {
+			p.OnResult(result)
+		}
+		if err := sleepContext(ctx, interval); err != nil {
+			return err
+		}
+	}
+}
+
+// Check runs a single check and records its result in the stats.
+func (p *Probe) Check(ctx context.Context, opts ...RequestOption) *ProbeResult {
+	result := &ProbeResult{Time: time.Now()}
+	result.Err = p.check(ctx, result, opts)
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.stats.Checks++
+	if result.Err != nil {
+		p.stats.Failures++
+		p.stats.ConsecutiveFailures++
+	} else {
+		p.stats.ConsecutiveFailures = 0
+		p.stats.LastSuccess = result.Time
+	}
+	p.stats.Last = result
+	return result
+}
+
+func (p *Probe) check(ctx context.Context, result *ProbeResult, opts []RequestOption) error {
+	calls := 1
+	if p.CanaryRoomID != 0 {
+		calls = 4
+	}
+	budget := NewBudget(ctx, calls)
+
+	started := time.Now()
+	callCtx, cancel := budget.Next()
+	_, _, err := p.client.Me.Get(callCtx, opts...)
+	cancel()
+	if err != nil {
+		return fmt.Errorf("me: %w", err)
+	}
+	result.MeLatency = time.Since(started)
+
+	if p.CanaryRoomID == 0 {
+		return nil
+	}
+	started = time.Now()
+	body := fmt.Sprintf("chatwork probe %s", started.UTC().Format(time.RFC3339))
+	callCtx, cancel = budget.Next()
+	created, _, err := p.client.Messages.SendMessage(callCtx, p.CanaryRoomID, body, opts...)
+	cancel()
+	if err != nil {
+		return fmt.Errorf("send to room %d: %w", p.CanaryRoomID, err)
+	}
+	callCtx, cancel = budget.Next()
+	message, _, err := p.client.Messages.Get(callCtx, p.CanaryRoomID, created.MessageID, opts...)
+	cancel()
+	if err == nil && message.Body != body {
+		err = fmt.Errorf("chatwork: probe message %s read back as %q", created.MessageID, message.Body)
+	}
+	callCtx, cancel = budget.Next()
+	defer cancel()
+	if err != nil {
+		// Clean up regardless, reporting a failed cleanup with the read error.
+		err = fmt.Errorf("read from room %d: %w", p.CanaryRoomID, err)
+		if _, _, deleteErr := p.client.Messages.Delete(callCtx, p.CanaryRoomID, created.MessageID, opts...); deleteErr != nil {
+			err = errors.Join(err, fmt.Errorf("delete from room %d: %w", p.CanaryRoomID, deleteErr))
+		}
+		return err
+	}
+	if _, _, err := p.client.Messages.Delete(callCtx, p.CanaryRoomID, created.MessageID, opts...); err != nil {
+		return fmt.Errorf("delete from room %d: %w", p.CanaryRoomID, err)
+	}
+	result.RoundTripLatency = time.Since(started)
+	return nil
+}
+
+// Stats returns a snapshot of the metrics.
+func (p *Probe) Stats() ProbeStats {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.stats
+}
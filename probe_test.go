@@ -0,0 +1,66 @@
+package chatwork_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+	"testing"
+
+	"github.com/nashirox/chatwork-go"
+	"github.com/nashirox/chatwork-go/chatworktest"
+)
+
+func TestProbe_Check(t *testing.T) {
+	srv := chatworktest.NewServer()
+	defer srv.Close()
+
+	canary := srv.AddRoom(chatwork.Room{Name: "Canary"})
+	probe := chatwork.NewProbe(srv.Client(), nil)
+	probe.CanaryRoomID = canary
+	ctx := context.Background()
+
+	result := probe.Check(ctx)
+	if result.Err != nil {
+		t.Fatalf("Expected the check to succeed, got %v", result.Err)
+	}
+	if result.MeLatency <= 0 || result.RoundTripLatency <= 0 {
+		t.Errorf("Expected latencies to be measured, got %+v", result)
+	}
+	if messages := srv.Messages(canary); len(messages) != 0 {
+		t.Errorf("Expected the probe message to be deleted, got %d messages", len(messages))
+	}
+
+	srv.Fail("GET", "/me", http.StatusUnauthorized)
+	if result := probe.Check(ctx); !errors.Is(result.Err, chatwork.ErrUnauthorized) {
+		t.Errorf("Expected an unauthorized error, got %v", result.Err)
+	}
+
+	stats := probe.Stats()
+	if stats.Checks != 2 || stats.Failures != 1 || stats.ConsecutiveFailures != 1 {
+		t.Errorf("Unexpected stats: %+v", stats)
+	}
+	if stats.LastSuccess.IsZero() || stats.Last.Err == nil {
+		t.Errorf("Expected the last success and last failure to be recorded, got %+v", stats)
+	}
+}
+
+func TestProbe_CheckFailedCleanup(t *testing.T) {
+	srv := chatworktest.NewServer()
+	defer srv.Close()
+
+	canary := srv.AddRoom(chatwork.Room{Name: "Canary"})
+	probe := chatwork.NewProbe(srv.Client(), nil)
+	probe.CanaryRoomID = canary
+
+	// Message IDs are sequential, so the probe message gets the next one.
+	last, _ := strconv.Atoi(string(srv.AddMessage(srv.AddRoom(chatwork.Room{Name: "Other"}), chatwork.Message{Body: "hi"})))
+	path := "rooms/" + canary.String() + "/messages/" + strconv.Itoa(last+1)
+	srv.Fail("GET", path, http.StatusInternalServerError)
+	srv.Fail("DELETE", path, http.StatusForbidden)
+
+	result := probe.Check(context.Background())
+	if !errors.Is(result.Err, chatwork.ErrServerError) || !errors.Is(result.Err, chatwork.ErrForbidden) {
+		t.Errorf("Expected both the read and the cleanup error, got %v", result.Err)
+	}
+}
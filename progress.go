@@ -0,0 +1,113 @@
+package chatwork
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+// RenderProgress returns a text progress bar such as
+//
+//	[##########..........]  50% (5/10)
+//
+// width is the number of cells inside the brackets. The bar uses only ASCII
+// characters so that it lines up when posted inside a [code] block.
+// current is clamped to the range [0, total].
+func RenderProgress(current, total, width int) string {
+	if width < 1 {
+		width = 1
+	}
+	if total < 0 {
+		total = 0
+	}
+	if current < 0 {
+		current = 0
+	}
+	if current > total {
+		current = total
+	}
+
+	ratio := 1.0
+	if total > 0 {
+		ratio = float64(current) / float64(total)
+	}
+	filled := int(ratio * float64(width))
+
+	return fmt.Sprintf("[%s%s] %3d%% (%d/%d)",
+		strings.Repeat("#", filled),
+		strings.Repeat(".", width-filled),
+		int(ratio*100),
+		current,
+		total,
+	)
+}
+
+// EstimateRemaining extrapolates the time left for a job that has completed
+// current of total units in elapsed. It returns -1 when no estimate is possible.
+func EstimateRemaining(elapsed time.Duration, current, total int) time.Duration {
+	if current <= 0 || total <= 0 || elapsed <= 0 {
+		return -1
+	}
+	if current >= total {
+		return 0
+	}
+	return time.Duration(float64(elapsed) / float64(current) * float64(total-current))
+}
+
+// FormatETA returns a short human-readable estimate such as "ETA 3m05s",
+// or "ETA --" when the remaining time cannot be estimated yet.
+func FormatETA(elapsed time.Duration, current, total int) string {
+	remaining := EstimateRemaining(elapsed, current, total)
+	if remaining < 0 {
+		return "ETA --"
+	}
+	return "ETA " + formatDuration(remaining)
+}
+
+// formatDuration renders d with second precision as "42s", "3m05s", or "1h02m".
+func formatDuration(d time.Duration) string {
+	d = d.Round(time.Second)
+	switch {
+	case d >= time.Hour:
+		return fmt.Sprintf("%dh%02dm", int(d.Hours()), int(d.Minutes())%60)
+	case d >= time.Minute:
+		return fmt.Sprintf("%dm%02ds", int(d.Minutes()), int(d.Seconds())%60)
+	default:
+		return fmt.Sprintf("%ds", int(d.Seconds()))
+	}
+}
+
+// UpdateProgress rewrites the status message as a titled progress bar with an ETA.
+// The estimate is based on the time elapsed since the status message was created.
+func (m *StatusMessage) UpdateProgress(ctx context.Context, title string, current, total int, opts ...RequestOption) (*Response, error) {
+	elapsed := time.Since(m.created)
+	body := fmt.Sprintf("[info][title]%s[/title][code]%s %s[/code][/info]",
+		title,
+		RenderProgress(current, total, 20),
+		FormatETA(elapsed, current, total),
+	)
+	return m.Update(ctx, body, opts...)
+}
+
+// progressReader reports the bytes read through it, for WithProgress.
+type progressReader struct {
+	r           io.ReadCloser
+	transferred int64
+	total       int64
+	report      func(transferred, total int64)
+}
+
+func (p *progressReader) Read(b []byte) (int, error) {
+	n, err := p.r.Read(b)
+	if n > 0 {
+		p.transferred += int64(n)
+		p.report(p.transferred, p.total)
+	}
+	return n, err
+}
+
+func (p *progressReader) Close() error {
+	return p.r.Close()
+}
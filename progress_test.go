@@ -0,0 +1,45 @@
+package chatwork
+
+import (
+	"testing"
+	"time"
+)
+
+func TestRenderProgress(t *testing.T) {
+	tests := []struct {
+		current, total, width int
+		want                  string
+	}{
+		{0, 10, 10, "[..........]   0% (0/10)"},
+		{5, 10, 10, "[#####.....]  50% (5/10)"},
+		{10, 10, 4, "[####] 100% (10/10)"},
+		{15, 10, 4, "[####] 100% (10/10)"},
+		{0, 0, 4, "[####] 100% (0/0)"},
+	}
+
+	for _, tt := range tests {
+		if got := RenderProgress(tt.current, tt.total, tt.width); got != tt.want {
+			t.Errorf("RenderProgress(%d, %d, %d) = %q, want %q", tt.current, tt.total, tt.width, got, tt.want)
+		}
+	}
+}
+
+func TestFormatETA(t *testing.T) {
+	tests := []struct {
+		elapsed        time.Duration
+		current, total int
+		want           string
+	}{
+		{time.Minute, 0, 10, "ETA --"},
+		{10 * time.Second, 1, 4, "ETA 30s"},
+		{time.Minute, 1, 4, "ETA 3m00s"},
+		{time.Hour, 1, 3, "ETA 2h00m"},
+		{time.Minute, 4, 4, "ETA 0s"},
+	}
+
+	for _, tt := range tests {
+		if got := FormatETA(tt.elapsed, tt.current, tt.total); got != tt.want {
+			t.Errorf("FormatETA(%v, %d, %d) = %q, want %q", tt.elapsed, tt.current, tt.total, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,208 @@
+package chatwork
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+const (
+	// DefaultRateLimitRequests is the number of requests ChatWork allows per token
+	// within DefaultRateLimitWindow.
+	DefaultRateLimitRequests = 300
+
+	// DefaultRateLimitWindow is the window in which ChatWork counts requests per token.
+	DefaultRateLimitWindow = 5 * time.Minute
+)
+
+// OptionRateLimit enables client-side throttling of outgoing requests.
+//
+// At most requests calls are sent within any window of length per, counting
+// from the first call, so that the client stays within ChatWork's limits even
+// at start-up. Additional calls block in Do until a slot becomes available or
+// their context is done.
+// The limiter is shared by all goroutines using the same Client.
+// A non-positive requests or per disables throttling.
+//
+// Example:
+//
+//	client := chatwork.New("token", chatwork.OptionRateLimit(
+//		chatwork.DefaultRateLimitRequests,
+//		chatwork.DefaultRateLimitWindow,
+//	))
+func OptionRateLimit(requests int, per time.Duration) ClientOption {
+	return func(c *Client) {
+		if requests <= 0 || per <= 0 {
+			c.limiter = nil
+			return
+		}
+		c.limiter = newRateLimiter(requests, per)
+	}
+}
+
+// rateLimiter is a sliding window limiter. It remembers the send times of
+// the last requests calls, including reserved ones, and lets a call go once
+// the oldest of them lies a full window in the past.
+type rateLimiter struct {
+	mu    sync.Mutex
+	per   time.Duration
+	times []time.Time // ring of send times, oldest at next
+	next  int
+}
+
+func newRateLimiter(requests int, per time.Duration) *rateLimiter {
+	return &rateLimiter{
+		per:   per,
+		times: make([]time.Time, requests),
+	}
+}
+
+// interval returns the average time between calls allowed by the limit.
+func (l *rateLimiter) interval() time.Duration {
+	return l.per / time.Duration(len(l.times))
+}
+
+// reservation is a send time handed out by reserve.
+type reservation struct {
+	slot int
+	at   time.Time
+	prev time.Time // the send time the slot held before
+}
+
+// reserve takes the next send time, which is now at the earliest.
+func (l *rateLimiter) reserve() reservation {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	r := reservation{slot: l.next, at: time.Now(), prev: l.times[l.next]}
+	if free := r.prev.Add(l.per); !r.prev.IsZero() && free.After(r.at) {
+		r.at = free
+	}
+	l.times[r.slot] = r.at
+	l.next = (l.next + 1) % len(l.times)
+	return r
+}
+
+// release returns a reservation that was never used. Only the latest
+// reservation can be returned; earlier ones keep their slot, which delays
+// later calls but never lets more through.
+func (l *rateLimiter) release(r reservation) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	latest := (l.next + len(l.times) - 1) % len(l.times)
+	if r.slot == latest && l.times[r.slot].Equal(r.at) {
+		l.times[r.slot] = r.prev
+		l.next = r.slot
+	}
+}
+
+// Wait blocks until a request may be sent or ctx is done.
+func (l *rateLimiter) Wait(ctx context.Context) error {
+	r := l.reserve()
+	delay := time.Until(r.at)
+	if delay <= 0 {
+		return nil
+	}
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		l.release(r)
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// maxRateLimitRetries bounds how often a single call waits on a 429 response.
+const maxRateLimitRetries = 3
+
+// OptionWaitOnRateLimit makes the client wait and retry when ChatWork responds
+// with 429 Too Many Requests.
+//
+// The client sleeps until the time given by the X-RateLimit-Reset header and
+// sends the request again. It gives up immediately when the reset lies beyond
+// the context deadline, in which case the returned *APIError carries the
+// computed wait in RetryAfter. Use WithNoRetry to opt out for a single call.
+func OptionWaitOnRateLimit(wait bool) ClientOption {
+	return func(c *Client) {
+		c.waitOnRateLimit = wait
+	}
+}
+
+// rateLimitWait reports whether Do should wait out a rate limit and resend
+// req, and if so for how long.
+func (c *Client) rateLimitWait(ctx context.Context, req *http.Request, cfg *requestConfig, attempt int, err error) (time.Duration, bool) {
+	if !c.waitOnRateLimit || cfg.noRetry || attempt >= maxRateLimitRetries {
+		return 0, false
+	}
+
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || !errors.Is(err, ErrRateLimited) {
+		return 0, false
+	}
+	if req.Body != nil && req.Body != http.NoBody && req.GetBody == nil {
+		return 0, false
+	}
+	if deadline, ok := ctx.Deadline(); ok && time.Now().Add(apiErr.RetryAfter).After(deadline) {
+		return 0, false
+	}
+
+	return apiErr.RetryAfter, true
+}
+
+// sleepContext pauses for d or until ctx is done.
+func sleepContext(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return ctx.Err()
+	}
+
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// parseRateLimit reads the X-RateLimit-* headers sent by ChatWork.
+func parseRateLimit(r *http.Response) RateLimit {
+	var rate RateLimit
+	if limit := r.Header.Get("X-RateLimit-Limit"); limit != "" {
+		rate.Limit, _ = strconv.Atoi(limit)
+	}
+	if remaining := r.Header.Get("X-RateLimit-Remaining"); remaining != "" {
+		rate.Remaining, _ = strconv.Atoi(remaining)
+	}
+	if reset := r.Header.Get("X-RateLimit-Reset"); reset != "" {
+		rate.Reset, _ = strconv.ParseInt(reset, 10, 64)
+	}
+	return rate
+}
+
+// retryAfter computes how long to wait before retrying a 429 response.
+// It prefers X-RateLimit-Reset and falls back to Retry-After, then to one second.
+func retryAfter(r *http.Response, now time.Time) time.Duration {
+	if reset := parseRateLimit(r).Reset; reset > 0 {
+		wait := time.Unix(reset, 0).Sub(now)
+		if wait < 0 {
+			wait = 0
+		}
+		return wait
+	}
+
+	if seconds, err := strconv.Atoi(r.Header.Get("Retry-After")); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second
+	}
+
+	return time.Second
+}
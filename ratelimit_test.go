@@ -0,0 +1,133 @@
+package chatwork
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestOptionRateLimit(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	client := New(testToken, OptionRateLimit(2, 200*time.Millisecond))
+	client.BaseURL, _ = url.Parse(server.URL)
+
+	start := time.Now()
+	for i := 0; i < 3; i++ {
+		if _, _, err := client.Me.Get(context.Background()); err != nil {
+			t.Fatalf("Me.Get returned error: %v", err)
+		}
+	}
+
+	if elapsed := time.Since(start); elapsed < 80*time.Millisecond {
+		t.Errorf("Expected third request to be throttled, all requests finished in %v", elapsed)
+	}
+}
+
+func TestOptionRateLimit_ContextCanceled(t *testing.T) {
+	client := New(testToken, OptionRateLimit(1, time.Hour))
+
+	if err := client.limiter.Wait(context.Background()); err != nil {
+		t.Fatalf("First Wait returned error: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	if err := client.limiter.Wait(ctx); err == nil {
+		t.Error("Expected Wait to fail once the context is done")
+	}
+}
+
+func TestRateLimiter_Window(t *testing.T) {
+	const requests, per = 5, time.Hour
+	l := newRateLimiter(requests, per)
+
+	var sends []time.Time
+	for i := 0; i < 3*requests; i++ {
+		sends = append(sends, l.reserve().at)
+	}
+	for i := 0; i+requests < len(sends); i++ {
+		if gap := sends[i+requests].Sub(sends[i]); gap < per {
+			t.Fatalf("Calls %d to %d were sent within %v, more than %d per %v", i, i+requests, gap, requests, per)
+		}
+	}
+	if wait := time.Until(sends[requests-1]); wait > 0 {
+		t.Errorf("Expected the first %d calls not to wait, got %v", requests, wait)
+	}
+
+	latest := l.reserve()
+	l.release(latest)
+	if again := l.reserve(); !again.at.Equal(latest.at) {
+		t.Errorf("Expected a released slot to be reused at %v, got %v", latest.at, again.at)
+	}
+}
+
+func TestOptionWaitOnRateLimit(t *testing.T) {
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if calls == 1 {
+			w.Header().Set("X-RateLimit-Limit", "300")
+			w.Header().Set("X-RateLimit-Remaining", "0")
+			w.Header().Set("X-RateLimit-Reset", strconv.FormatInt(time.Now().Unix(), 10))
+			w.WriteHeader(http.StatusTooManyRequests)
+			w.Write([]byte(`{"errors": ["Rate limit exceeded"]}`))
+			return
+		}
+		body, _ := io.ReadAll(r.Body)
+		w.Write([]byte(`{"message_id": "` + string(body) + `"}`))
+	}))
+	defer server.Close()
+
+	client := New(testToken, OptionWaitOnRateLimit(true))
+	client.BaseURL, _ = url.Parse(server.URL)
+
+	created, _, err := client.Messages.SendMessage(context.Background(), 1, "hi")
+	if err != nil {
+		t.Fatalf("SendMessage returned error: %v", err)
+	}
+	if calls != 2 {
+		t.Errorf("Expected the request to be retried once, got %d calls", calls)
+	}
+	if created.MessageID != "body=hi" {
+		t.Errorf("Expected the retried request to resend its body, got %q", created.MessageID)
+	}
+}
+
+func TestRateLimitError_RetryAfter(t *testing.T) {
+	reset := time.Now().Add(time.Hour).Unix()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-RateLimit-Reset", strconv.FormatInt(reset, 10))
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer server.Close()
+
+	client := New(testToken, OptionWaitOnRateLimit(true))
+	client.BaseURL, _ = url.Parse(server.URL)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	_, resp, err := client.Me.Get(ctx)
+
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || !errors.Is(err, ErrRateLimited) {
+		t.Fatalf("Expected a rate limit error, got %v", err)
+	}
+	if apiErr.RetryAfter < 50*time.Minute {
+		t.Errorf("Expected RetryAfter close to an hour, got %v", apiErr.RetryAfter)
+	}
+	if resp.RateLimit.Reset != reset {
+		t.Errorf("Expected RateLimit.Reset %d, got %d", reset, resp.RateLimit.Reset)
+	}
+}
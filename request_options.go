@@ -0,0 +1,89 @@
+package chatwork
+
+import (
+	"net/http"
+	"time"
+)
+
+// RequestOption customizes a single API call.
+//
+// Every service method accepts a trailing list of RequestOptions, and they
+// can also be passed to Client.Do when sending custom requests.
+//
+// Example:
+//
+//	client.Messages.SendMessage(ctx, roomID, "deploying",
+//		chatwork.WithHeader("X-Correlation-ID", id),
+//		chatwork.WithTimeout(5*time.Second),
+//	)
+type RequestOption func(*requestConfig)
+
+// requestConfig collects the effect of the RequestOptions of a call.
+type requestConfig struct {
+	header     http.Header
+	timeout    time.Duration
+	hasTimeout bool
+	noRetry    bool
+	rawBody    bool
+	progress   func(transferred, total int64)
+}
+
+func newRequestConfig(opts []RequestOption) *requestConfig {
+	cfg := &requestConfig{}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+	return cfg
+}
+
+// WithHeader sets an additional HTTP header on the request.
+// It replaces any value the client would otherwise send for the same key.
+func WithHeader(key, value string) RequestOption {
+	return func(cfg *requestConfig) {
+		if cfg.header == nil {
+			cfg.header = make(http.Header)
+		}
+		cfg.header.Set(key, value)
+	}
+}
+
+// WithTimeout bounds the call, including reading the response, by d.
+// The deadline is applied on top of the context passed to the method and
+// replaces the client's default timeout. A non-positive d disables the
+// default timeout for the call.
+func WithTimeout(d time.Duration) RequestOption {
+	return func(cfg *requestConfig) {
+		cfg.timeout = d
+		cfg.hasTimeout = true
+	}
+}
+
+// WithNoRetry disables automatic retries for the call, so that the first
+// response or error is returned as is.
+func WithNoRetry() RequestOption {
+	return func(cfg *requestConfig) {
+		cfg.noRetry = true
+	}
+}
+
+// WithRawBody keeps the body of the response in Response.RawBody, in
+// addition to decoding it, as OptionCaptureBody does for every call.
+func WithRawBody() RequestOption {
+	return func(cfg *requestConfig) {
+		cfg.rawBody = true
+	}
+}
+
+// WithProgress calls fn as the body of the request is sent, such as for
+// uploads, and as DownloadManager writes file contents, so that CLIs and
+// UIs can render progress bars. transferred counts the bytes so far and
+// total is the size, or -1 if it is unknown. For downloads resumed after
+// an interruption, transferred includes the bytes written before.
+// DownloadManager.DownloadAll calls fn for each of its files, possibly from
+// several goroutines at once; use DownloadManager.OnProgress to tell the
+// files apart.
+func WithProgress(fn func(transferred, total int64)) RequestOption {
+	return func(cfg *requestConfig) {
+		cfg.progress = fn
+	}
+}
@@ -0,0 +1,102 @@
+package chatwork
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"time"
+)
+
+// RetentionPolicy makes RoomsService.DeleteRoom keep a recoverable archive
+// of every room it deletes. See OptionRetentionPolicy.
+type RetentionPolicy struct {
+	// Directory under which each deleted room is archived (required)
+	Dir string
+
+	// Compression of the archive files
+	Compression ExportCompression
+
+	// Message posted to the room before it is deleted, if not empty
+	Notice string
+
+	// Clock used for archive names. Defaults to time.Now.
+	Now func() time.Time
+}
+
+// OptionRetentionPolicy makes RoomsService.DeleteRoom, and Delete with
+// action "delete", archive a room before deleting it, since deletion
+// cannot be undone.
+//
+// The archive is written to a directory "room-<ID>-<time>" under p.Dir
+// with an ExportWriter: a record of the room, one for each member, and
+// the room's history as written by ExportHistory, followed by a manifest
+// for VerifyArchive. Then p.Notice is posted. If any of this fails, the
+// room is not deleted. Leaving a room is not affected.
+func OptionRetentionPolicy(p *RetentionPolicy) ClientOption {
+	return func(c *Client) {
+		c.retention = p
+	}
+}
+
+// retain archives a room according to the client's retention policy.
+func (s *RoomsService) retain(ctx context.Context, roomID RoomID, opts []RequestOption) error {
+	p := s.client.retention
+	if p.Dir == "" {
+		return errors.New("chatwork: retention directory is required")
+	}
+	now := time.Now
+	if p.Now != nil {
+		now = p.Now
+	}
+	started := now().UTC()
+	dir := filepath.Join(p.Dir, fmt.Sprintf("room-%d-%s", roomID, started.Format("20060102T150405Z")))
+
+	w, err := NewExportWriter(ExportWriterOptions{
+		Dir:         dir,
+		Prefix:      "room",
+		Compression: p.Compression,
+		Now:         now,
+	})
+	if err != nil {
+		return err
+	}
+	if err := s.archive(ctx, roomID, w, started, opts); err != nil {
+		w.Close()
+		return fmt.Errorf("room %d: archive before deletion: %w", roomID, err)
+	}
+	if err := w.Close(); err != nil {
+		return fmt.Errorf("room %d: archive before deletion: %w", roomID, err)
+	}
+
+	if p.Notice != "" {
+		if _, _, err := (*MessagesService)(s).SendMessage(ctx, roomID, p.Notice, opts...); err != nil {
+			return fmt.Errorf("room %d: notice before deletion: %w", roomID, err)
+		}
+	}
+	return nil
+}
+
+// archive writes the room, its members, and its history to w.
+func (s *RoomsService) archive(ctx context.Context, roomID RoomID, w *ExportWriter, at time.Time, opts []RequestOption) error {
+	room, _, err := s.Get(ctx, roomID, opts...)
+	if err != nil {
+		return err
+	}
+	if err := w.WriteRecord(&ExportRecord{Kind: ExportRecordRoom, RoomID: roomID, Room: room}, at); err != nil {
+		return err
+	}
+
+	members, _, err := s.GetMembers(ctx, roomID, opts...)
+	if err != nil {
+		return err
+	}
+	for _, m := range members {
+		if err := w.WriteRecord(&ExportRecord{Kind: ExportRecordMember, RoomID: roomID, Member: m}, at); err != nil {
+			return err
+		}
+	}
+
+	_, _, err = s.ExportHistory(ctx, roomID, w, opts...)
+	return err
+}
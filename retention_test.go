@@ -0,0 +1,70 @@
+package chatwork_test
+
+import (
+	"context"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/nashirox/chatwork-go"
+	"github.com/nashirox/chatwork-go/chatworktest"
+)
+
+func TestOptionRetentionPolicy(t *testing.T) {
+	srv := chatworktest.NewServer()
+	defer srv.Close()
+
+	roomID := srv.AddRoom(chatwork.Room{Name: "Old project", Role: "admin"})
+	srv.AddMember(roomID, chatwork.Member{AccountID: 200, Role: "member"})
+	srv.AddMessage(roomID, chatwork.Message{Body: "hello"})
+
+	dir := t.TempDir()
+	var calls []string
+	client := srv.Client(
+		chatwork.OptionRetentionPolicy(&chatwork.RetentionPolicy{
+			Dir:    dir,
+			Notice: "This room is being deleted.",
+			Now:    func() time.Time { return time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC) },
+		}),
+		chatwork.OptionOnRequest(func(r *http.Request) { calls = append(calls, r.Method+" "+r.URL.Path) }),
+	)
+
+	if _, err := client.Rooms.DeleteRoom(context.Background(), roomID); err != nil {
+		t.Fatalf("DeleteRoom returned error: %v", err)
+	}
+	if _, ok := srv.Room(roomID); ok {
+		t.Error("Expected the room to be deleted")
+	}
+	room := "/rooms/" + roomID.String()
+	if n := len(calls); n < 2 || !strings.HasSuffix(calls[n-2], room+"/messages") || !strings.HasPrefix(calls[n-2], "POST") ||
+		!strings.HasSuffix(calls[n-1], room) || !strings.HasPrefix(calls[n-1], "DELETE") {
+		t.Errorf("Expected the notice to be posted before deletion, got %v", calls)
+	}
+
+	manifest, err := chatwork.VerifyArchive(filepath.Join(dir, "room-"+roomID.String()+"-20240501T120000Z", "room-manifest.json"))
+	if err != nil {
+		t.Fatalf("VerifyArchive returned error: %v", err)
+	}
+	// The room, two members, and one message
+	if n := manifest.Records(); n != 4 {
+		t.Errorf("Expected 4 archived records, got %d", n)
+	}
+}
+
+func TestOptionRetentionPolicy_failure(t *testing.T) {
+	srv := chatworktest.NewServer()
+	defer srv.Close()
+
+	roomID := srv.AddRoom(chatwork.Room{Name: "Old project", Role: "admin"})
+	srv.Fail("GET", "/rooms/"+roomID.String()+"/members", http.StatusInternalServerError)
+	client := srv.Client(chatwork.OptionRetentionPolicy(&chatwork.RetentionPolicy{Dir: t.TempDir()}))
+
+	if _, err := client.Rooms.DeleteRoom(context.Background(), roomID); err == nil {
+		t.Fatal("Expected the failed archive to be returned")
+	}
+	if _, ok := srv.Room(roomID); !ok {
+		t.Error("Expected the room to be kept when archiving fails")
+	}
+}
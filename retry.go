@@ -0,0 +1,136 @@
+package chatwork
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"slices"
+	"time"
+)
+
+// IdempotencyKeyHeader is the header that marks a request as safe to retry
+// under RetryPolicy.RetryIdempotencyKey, such as one set with
+// WithHeader(chatwork.IdempotencyKeyHeader, key).
+const IdempotencyKeyHeader = "Idempotency-Key"
+
+// RetryPolicy controls which failed calls the client sends again, as set
+// with OptionRetryPolicy. Zero fields take the documented defaults.
+//
+// A call is retried when its response has one of Statuses, or when no
+// response was received at all, such as after a connection reset, but only
+// if its method is one of Methods. 429 responses are retried once the rate
+// limit resets; other failures are retried after an exponential backoff.
+type RetryPolicy struct {
+	// Attempts per call, including the first one. Defaults to 3.
+	MaxAttempts int
+
+	// Total time a call may spend, including waits between attempts, beyond
+	// which it is not retried. Zero means no limit besides the context.
+	MaxElapsedTime time.Duration
+
+	// Methods that are retried. Defaults to the idempotent methods GET,
+	// HEAD, PUT, and DELETE.
+	Methods []string
+
+	// Status codes that are retried. Defaults to 429, 500, 502, 503, and 504.
+	Statuses []int
+
+	// Whether requests of other methods, such as POST, are retried when they
+	// carry an IdempotencyKeyHeader.
+	RetryIdempotencyKey bool
+
+	// Wait before the first retry, doubled for each further retry. Defaults
+	// to 500 milliseconds.
+	Backoff time.Duration
+
+	// Upper bound of the wait between retries. Defaults to 10 seconds.
+	MaxBackoff time.Duration
+}
+
+// OptionRetryPolicy makes the client retry failed calls according to policy.
+// A nil policy disables retries, except for OptionWaitOnRateLimit. Use
+// WithNoRetry to opt out for a single call.
+//
+// Example:
+//
+//	client := chatwork.New("token", chatwork.OptionRetryPolicy(&chatwork.RetryPolicy{
+//		MaxAttempts:    5,
+//		MaxElapsedTime: time.Minute,
+//	}))
+func OptionRetryPolicy(policy *RetryPolicy) ClientOption {
+	return func(c *Client) {
+		c.retryPolicy = policy
+	}
+}
+
+// retryWait reports whether Do should resend req under the retry policy
+// after the failed attempt, and if so after how long. start is the time
+// the call began.
+func (c *Client) retryWait(ctx context.Context, req *http.Request, cfg *requestConfig, attempt int, start time.Time, response *Response, err error) (time.Duration, bool) {
+	p := c.retryPolicy
+	if p == nil || cfg.noRetry || err == nil || ctx.Err() != nil {
+		return 0, false
+	}
+	if attempt+1 >= p.maxAttempts() || !p.retryable(req, response, err) {
+		return 0, false
+	}
+	if req.Body != nil && req.Body != http.NoBody && req.GetBody == nil {
+		return 0, false
+	}
+
+	wait := p.backoff(attempt)
+	var apiErr *APIError
+	if errors.As(err, &apiErr) && errors.Is(err, ErrRateLimited) {
+		wait = apiErr.RetryAfter
+	}
+	if p.MaxElapsedTime > 0 && time.Since(start)+wait > p.MaxElapsedTime {
+		return 0, false
+	}
+	if deadline, ok := ctx.Deadline(); ok && time.Now().Add(wait).After(deadline) {
+		return 0, false
+	}
+
+	return wait, true
+}
+
+// retryable reports whether the policy allows retrying req after err.
+func (p *RetryPolicy) retryable(req *http.Request, response *Response, err error) bool {
+	methods := p.Methods
+	if methods == nil {
+		methods = []string{http.MethodGet, http.MethodHead, http.MethodPut, http.MethodDelete}
+	}
+	if !slices.Contains(methods, req.Method) && !(p.RetryIdempotencyKey && req.Header.Get(IdempotencyKeyHeader) != "") {
+		return false
+	}
+
+	if response == nil {
+		// No response was received, so the error comes from the transport.
+		return true
+	}
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		return false
+	}
+	statuses := p.Statuses
+	if statuses == nil {
+		statuses = []int{http.StatusTooManyRequests, http.StatusInternalServerError, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout}
+	}
+	return slices.Contains(statuses, apiErr.Response.StatusCode)
+}
+
+func (p *RetryPolicy) maxAttempts() int {
+	if p.MaxAttempts <= 0 {
+		return 3
+	}
+	return p.MaxAttempts
+}
+
+// backoff returns the wait before the retry that follows attempt.
+func (p *RetryPolicy) backoff(attempt int) time.Duration {
+	wait := orDefault(p.Backoff, 500*time.Millisecond)
+	limit := orDefault(p.MaxBackoff, 10*time.Second)
+	for i := 0; i < attempt && wait < limit; i++ {
+		wait *= 2
+	}
+	return min(wait, limit)
+}
@@ -0,0 +1,76 @@
+package chatwork
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestOptionRetryPolicy(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1)%2 == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			w.Write([]byte(`{"errors":["Maintenance"]}`))
+			return
+		}
+		w.Write([]byte(`{"message_id":"1"}`))
+	}))
+	defer server.Close()
+
+	ctx := context.Background()
+	newClient := func(policy *RetryPolicy) *Client {
+		client := New(testToken, OptionRetryPolicy(policy))
+		client.BaseURL, _ = url.Parse(server.URL)
+		return client
+	}
+	send := func(client *Client, opts ...RequestOption) error {
+		calls.Store(0)
+		_, _, err := client.Messages.SendMessage(ctx, 1, "hello", opts...)
+		return err
+	}
+
+	client := newClient(&RetryPolicy{Backoff: time.Millisecond})
+	calls.Store(0)
+	if _, err := client.Rooms.Delete(ctx, 1, "leave"); err != nil || calls.Load() != 2 {
+		t.Errorf("Expected an idempotent call to be retried, got %v after %d calls", err, calls.Load())
+	}
+	if err := send(client); !errors.Is(err, ErrServerError) || calls.Load() != 1 {
+		t.Errorf("Expected a POST not to be retried, got %v after %d calls", err, calls.Load())
+	}
+	if err := send(client, WithHeader(IdempotencyKeyHeader, "k")); !errors.Is(err, ErrServerError) {
+		t.Errorf("Expected an idempotency key to need RetryIdempotencyKey, got %v", err)
+	}
+
+	client = newClient(&RetryPolicy{Backoff: time.Millisecond, RetryIdempotencyKey: true})
+	if err := send(client, WithHeader(IdempotencyKeyHeader, "k")); err != nil || calls.Load() != 2 {
+		t.Errorf("Expected a POST with an idempotency key to be retried, got %v after %d calls", err, calls.Load())
+	}
+	if err := send(client, WithHeader(IdempotencyKeyHeader, "k"), WithNoRetry()); !errors.Is(err, ErrServerError) {
+		t.Errorf("Expected WithNoRetry to disable retries, got %v", err)
+	}
+
+	client = newClient(&RetryPolicy{Methods: []string{http.MethodPost}, Statuses: []int{http.StatusBadGateway}, Backoff: time.Millisecond})
+	if err := send(client); !errors.Is(err, ErrServerError) || calls.Load() != 1 {
+		t.Errorf("Expected statuses outside the allowlist not to be retried, got %v after %d calls", err, calls.Load())
+	}
+
+	client = newClient(&RetryPolicy{Methods: []string{http.MethodPost}, Backoff: time.Second, MaxElapsedTime: 100 * time.Millisecond})
+	if err := send(client); !errors.Is(err, ErrServerError) || calls.Load() != 1 {
+		t.Errorf("Expected MaxElapsedTime to stop retries, got %v after %d calls", err, calls.Load())
+	}
+}
+
+func TestRetryPolicy_Backoff(t *testing.T) {
+	p := &RetryPolicy{Backoff: 100 * time.Millisecond, MaxBackoff: time.Second}
+	for attempt, want := range []time.Duration{100 * time.Millisecond, 200 * time.Millisecond, 400 * time.Millisecond, 800 * time.Millisecond, time.Second, time.Second} {
+		if got := p.backoff(attempt); got != want {
+			t.Errorf("backoff(%d) = %v, want %v", attempt, got, want)
+		}
+	}
+}
@@ -0,0 +1,89 @@
+package chatwork_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/nashirox/chatwork-go"
+	"github.com/nashirox/chatwork-go/chatworktest"
+)
+
+func TestRoomsService_ExportHistory(t *testing.T) {
+	srv := chatworktest.NewServer()
+	defer srv.Close()
+
+	roomID := srv.AddRoom(chatwork.Room{Name: "Compliance"})
+	srv.AddMessage(roomID, chatwork.Message{Body: "first", SendTime: 1700000000})
+	srv.AddMessage(roomID, chatwork.Message{Body: "second", SendTime: 1700000060})
+	srv.AddFile(roomID, chatwork.File{Filename: "report.pdf"}, []byte("pdf"))
+
+	var buf bytes.Buffer
+	summary, _, err := srv.Client().Rooms.ExportHistory(context.Background(), roomID, chatwork.NewJSONLinesSink(&buf))
+	if err != nil {
+		t.Fatalf("ExportHistory returned error: %v", err)
+	}
+	if summary.Messages != 2 || summary.Files != 1 {
+		t.Errorf("Unexpected summary: %+v", summary)
+	}
+
+	var records []chatwork.ExportRecord
+	dec := json.NewDecoder(&buf)
+	for dec.More() {
+		var r chatwork.ExportRecord
+		if err := dec.Decode(&r); err != nil {
+			t.Fatal(err)
+		}
+		records = append(records, r)
+	}
+	if len(records) != 3 {
+		t.Fatalf("Expected 3 records, got %d", len(records))
+	}
+	if records[0].Kind != chatwork.ExportRecordMessage || records[0].Message.Body != "first" || records[0].RoomID != roomID {
+		t.Errorf("Unexpected first record: %+v", records[0])
+	}
+	if records[2].Kind != chatwork.ExportRecordFile || records[2].File.Filename != "report.pdf" {
+		t.Errorf("Unexpected file record: %+v", records[2])
+	}
+}
+
+func TestNewAnonymizingSink(t *testing.T) {
+	srv := chatworktest.NewServer()
+	defer srv.Close()
+
+	roomID := srv.AddRoom(chatwork.Room{Name: "Compliance"})
+	taro := chatwork.User{AccountID: 123, Name: "Taro Yamada"}
+	srv.AddMessage(roomID, chatwork.Message{Account: taro, Body: "[To:456]Hanako Suzukiさん\nMail taro@example.com", SendTime: 1700000000})
+	srv.AddFile(roomID, chatwork.File{Account: taro, Filename: "taro@example.com.pdf"}, []byte("pdf"))
+
+	a, err := chatwork.NewAnonymizer("s3cret")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var buf bytes.Buffer
+	if _, _, err := srv.Client().Rooms.ExportHistory(context.Background(), roomID, chatwork.NewAnonymizingSink(chatwork.NewJSONLinesSink(&buf), a)); err != nil {
+		t.Fatalf("ExportHistory returned error: %v", err)
+	}
+
+	for _, leaked := range []string{"Taro", "Hanako", "taro@example.com", `"account_id":123`, "To:456"} {
+		if bytes.Contains(buf.Bytes(), []byte(leaked)) {
+			t.Errorf("Anonymized export still contains %q: %s", leaked, buf.String())
+		}
+	}
+	if !bytes.Contains(buf.Bytes(), []byte("user-"+a.AccountID(123).String())) {
+		t.Errorf("Expected the author to be pseudonymized, got %s", buf.String())
+	}
+
+	sink := chatwork.NewAnonymizingSink(chatwork.NewJSONLinesSink(&buf), a)
+	if err := sink.WriteRecord(&chatwork.ExportRecord{Kind: chatwork.ExportRecordMember, Member: &chatwork.Member{AccountID: 123, Name: "Taro Yamada", Department: "Sales"}}, time.Time{}); err != nil {
+		t.Fatal(err)
+	}
+	if bytes.Contains(buf.Bytes(), []byte("Sales")) {
+		t.Errorf("Expected the member to be anonymized, got %s", buf.String())
+	}
+	if err := sink.WriteRecord(map[string]string{"name": "Taro"}, time.Time{}); err == nil {
+		t.Error("Expected records of unknown types to be refused")
+	}
+}
@@ -2,8 +2,12 @@ package chatwork
 
 import (
 	"context"
+	"errors"
 	"fmt"
-	"strconv"
+	"io"
+	"regexp"
+	"slices"
+	"strings"
 )
 
 // RoomsService handles communication with the room related
@@ -17,21 +21,21 @@ type RoomsService service
 // Name is required. Other fields are optional.
 // Members can be specified with different permission levels.
 type RoomCreateParams struct {
-	Name               string `url:"name"`
-	Description        string `url:"description,omitempty"`
-	IconPreset         string `url:"icon_preset,omitempty"`
-	MembersAdminIDs    []int  `url:"members_admin_ids,comma,omitempty"`
-	MembersMemberIDs   []int  `url:"members_member_ids,comma,omitempty"`
-	MembersReadonlyIDs []int  `url:"members_readonly_ids,comma,omitempty"`
+	Name               string      `url:"name"`
+	Description        string      `url:"description,omitempty"`
+	IconPreset         IconPreset  `url:"icon_preset,omitempty"`
+	MembersAdminIDs    []AccountID `url:"members_admin_ids,comma,omitempty"`
+	MembersMemberIDs   []AccountID `url:"members_member_ids,comma,omitempty"`
+	MembersReadonlyIDs []AccountID `url:"members_readonly_ids,comma,omitempty"`
 }
 
 // RoomUpdateParams represents the parameters for updating a room.
 //
 // All fields are optional. Only fields with non-zero values will be updated.
 type RoomUpdateParams struct {
-	Name        string `url:"name,omitempty"`
-	Description string `url:"description,omitempty"`
-	IconPreset  string `url:"icon_preset,omitempty"`
+	Name        string     `url:"name,omitempty"`
+	Description string     `url:"description,omitempty"`
+	IconPreset  IconPreset `url:"icon_preset,omitempty"`
 }
 
 // RoomMembersUpdateParams represents the parameters for updating room members.
@@ -39,27 +43,149 @@ type RoomUpdateParams struct {
 // This replaces all members in the room with the specified member lists.
 // Be careful to include all desired members, not just new ones.
 type RoomMembersUpdateParams struct {
-	MembersAdminIDs    []int `url:"members_admin_ids,comma,omitempty"`
-	MembersMemberIDs   []int `url:"members_member_ids,comma,omitempty"`
-	MembersReadonlyIDs []int `url:"members_readonly_ids,comma,omitempty"`
+	MembersAdminIDs    []AccountID `url:"members_admin_ids,comma,omitempty"`
+	MembersMemberIDs   []AccountID `url:"members_member_ids,comma,omitempty"`
+	MembersReadonlyIDs []AccountID `url:"members_readonly_ids,comma,omitempty"`
+}
+
+// RoomMembersUpdateResult lists the account IDs of the members of a room
+// by role, as returned when the members are updated.
+type RoomMembersUpdateResult struct {
+	Admin    []AccountID `json:"admin"`
+	Member   []AccountID `json:"member"`
+	Readonly []AccountID `json:"readonly"`
 }
 
 // List returns the list of all rooms the authenticated user participates in.
 //
 // ChatWork API docs: https://developer.chatwork.com/reference/get-rooms
-func (s *RoomsService) List(ctx context.Context) ([]*Room, *Response, error) {
+func (s *RoomsService) List(ctx context.Context, opts ...RequestOption) ([]*Room, *Response, error) {
 	req, err := s.client.NewRequest("GET", "rooms", nil)
 	if err != nil {
 		return nil, nil, err
 	}
 
-	var rooms []*Room
-	resp, err := s.client.Do(ctx, req, &rooms)
+	return doValue[[]*Room](ctx, s.client, req, opts...)
+}
+
+// ChangedRooms lists the rooms and returns those updated at or after since,
+// so that messages are fetched only from rooms with new
+// activity rather than from every room.
+//
+// Pass the largest LastUpdateTime seen so far as since. Since update times
+// have a resolution of a second, rooms updated in that second are returned
+// again.
+func (s *RoomsService) ChangedRooms(ctx context.Context, since Timestamp, opts ...RequestOption) ([]*Room, *Response, error) {
+	rooms, resp, err := s.List(ctx, opts...)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	var changed []*Room
+	for _, room := range rooms {
+		if room.LastUpdateTime >= since {
+			changed = append(changed, room)
+		}
+	}
+	return changed, resp, nil
+}
+
+// Search lists the rooms and returns those for which match returns true,
+// such as RoomNamePrefix("deploy-").
+func (s *RoomsService) Search(ctx context.Context, match func(*Room) bool, opts ...RequestOption) ([]*Room, *Response, error) {
+	rooms, resp, err := s.List(ctx, opts...)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	var matched []*Room
+	for _, room := range rooms {
+		if match(room) {
+			matched = append(matched, room)
+		}
+	}
+	return matched, resp, nil
+}
+
+// FindByName returns the first listed room named name. The error wraps
+// ErrNotFound if there is none.
+func (s *RoomsService) FindByName(ctx context.Context, name string, opts ...RequestOption) (*Room, *Response, error) {
+	rooms, resp, err := s.Search(ctx, RoomNameIs(name), opts...)
 	if err != nil {
 		return nil, resp, err
 	}
+	if len(rooms) == 0 {
+		return nil, resp, fmt.Errorf("%w: room %q", ErrNotFound, name)
+	}
+	return rooms[0], resp, nil
+}
 
-	return rooms, resp, nil
+// ListGroupChats returns the group chats the authenticated user
+// participates in.
+func (s *RoomsService) ListGroupChats(ctx context.Context, opts ...RequestOption) ([]*Room, *Response, error) {
+	return s.Search(ctx, RoomTypeIs(RoomTypeGroup), opts...)
+}
+
+// ListDirectChats returns the direct chats of the authenticated user.
+func (s *RoomsService) ListDirectChats(ctx context.Context, opts ...RequestOption) ([]*Room, *Response, error) {
+	return s.Search(ctx, RoomTypeIs(RoomTypeDirect), opts...)
+}
+
+// GetMyChat returns the authenticated user's own chat, of type RoomTypeMy. The
+// error wraps ErrNotFound if it is not listed.
+func (s *RoomsService) GetMyChat(ctx context.Context, opts ...RequestOption) (*Room, *Response, error) {
+	rooms, resp, err := s.Search(ctx, RoomTypeIs(RoomTypeMy), opts...)
+	if err != nil {
+		return nil, resp, err
+	}
+	if len(rooms) == 0 {
+		return nil, resp, fmt.Errorf("%w: my chat", ErrNotFound)
+	}
+	return rooms[0], resp, nil
+}
+
+// RoomNameIs matches rooms named name, for RoomsService.Search.
+func RoomNameIs(name string) func(*Room) bool {
+	return func(room *Room) bool { return room.Name == name }
+}
+
+// RoomNamePrefix matches rooms whose name starts with prefix, for
+// RoomsService.Search.
+func RoomNamePrefix(prefix string) func(*Room) bool {
+	return func(room *Room) bool { return strings.HasPrefix(room.Name, prefix) }
+}
+
+// RoomNameMatches matches rooms whose name matches re, for
+// RoomsService.Search.
+func RoomNameMatches(re *regexp.Regexp) func(*Room) bool {
+	return func(room *Room) bool { return re.MatchString(room.Name) }
+}
+
+// RoomTypeIs matches rooms of a type, for RoomsService.Search.
+func RoomTypeIs(roomType RoomType) func(*Room) bool {
+	return func(room *Room) bool { return room.Type == roomType }
+}
+
+// RoomRoleIs matches rooms where the authenticated user has a role, for
+// RoomsService.Search.
+func RoomRoleIs(role Role) func(*Room) bool {
+	return func(room *Room) bool { return room.Role == role }
+}
+
+// RoomMatchesAll matches rooms that all of matches match, such as the group
+// chats the authenticated user administers:
+//
+//	rooms, _, err := client.Rooms.Search(ctx, chatwork.RoomMatchesAll(
+//		chatwork.RoomTypeIs(chatwork.RoomTypeGroup), chatwork.RoomRoleIs(chatwork.RoleAdmin)))
+func RoomMatchesAll(matches ...func(*Room) bool) func(*Room) bool {
+	return func(room *Room) bool {
+		for _, match := range matches {
+			if !match(room) {
+				return false
+			}
+		}
+		return true
+	}
 }
 
 // Create creates a new group chat room.
@@ -67,38 +193,32 @@ func (s *RoomsService) List(ctx context.Context) ([]*Room, *Response, error) {
 // The authenticated user will automatically become an admin of the created room.
 //
 // ChatWork API docs: https://developer.chatwork.com/reference/post-rooms
-func (s *RoomsService) Create(ctx context.Context, params *RoomCreateParams) (*Room, *Response, error) {
-	req, err := s.client.NewFormRequest("POST", "rooms", params)
-	if err != nil {
+func (s *RoomsService) Create(ctx context.Context, params *RoomCreateParams, opts ...RequestOption) (*Room, *Response, error) {
+	if params == nil {
+		return nil, nil, errNilParams
+	}
+	if err := params.Validate(); err != nil {
 		return nil, nil, err
 	}
-
-	room := new(Room)
-	resp, err := s.client.Do(ctx, req, room)
+	req, err := s.client.NewFormRequest("POST", "rooms", params)
 	if err != nil {
-		return nil, resp, err
+		return nil, nil, err
 	}
 
-	return room, resp, nil
+	return Do[Room](ctx, s.client, req, opts...)
 }
 
 // Get returns information about the specified room.
 //
 // ChatWork API docs: https://developer.chatwork.com/reference/get-rooms-room_id
-func (s *RoomsService) Get(ctx context.Context, roomID int) (*Room, *Response, error) {
+func (s *RoomsService) Get(ctx context.Context, roomID RoomID, opts ...RequestOption) (*Room, *Response, error) {
 	u := fmt.Sprintf("rooms/%d", roomID)
 	req, err := s.client.NewRequest("GET", u, nil)
 	if err != nil {
 		return nil, nil, err
 	}
 
-	room := new(Room)
-	resp, err := s.client.Do(ctx, req, room)
-	if err != nil {
-		return nil, resp, err
-	}
-
-	return room, resp, nil
+	return Do[Room](ctx, s.client, req, opts...)
 }
 
 // Update updates the room information.
@@ -106,20 +226,20 @@ func (s *RoomsService) Get(ctx context.Context, roomID int) (*Room, *Response, e
 // Only room admins can update room information.
 //
 // ChatWork API docs: https://developer.chatwork.com/reference/put-rooms-room_id
-func (s *RoomsService) Update(ctx context.Context, roomID int, params *RoomUpdateParams) (*Room, *Response, error) {
+func (s *RoomsService) Update(ctx context.Context, roomID RoomID, params *RoomUpdateParams, opts ...RequestOption) (*Room, *Response, error) {
+	if params == nil {
+		return nil, nil, errNilParams
+	}
+	if err := params.Validate(); err != nil {
+		return nil, nil, err
+	}
 	u := fmt.Sprintf("rooms/%d", roomID)
 	req, err := s.client.NewFormRequest("PUT", u, params)
 	if err != nil {
 		return nil, nil, err
 	}
 
-	room := new(Room)
-	resp, err := s.client.Do(ctx, req, room)
-	if err != nil {
-		return nil, resp, err
-	}
-
-	return room, resp, nil
+	return Do[Room](ctx, s.client, req, opts...)
 }
 
 // Delete performs room deletion or user removal based on the specified action type.
@@ -128,8 +248,16 @@ func (s *RoomsService) Update(ctx context.Context, roomID int, params *RoomUpdat
 // - "leave": Leave the room (any member can do this)
 // - "delete": Delete the room (only room creator can do this)
 //
+// With OptionRetentionPolicy, a room is archived before it is deleted.
+//
 // ChatWork API docs: https://developer.chatwork.com/reference/delete-rooms-room_id
-func (s *RoomsService) Delete(ctx context.Context, roomID int, actionType string) (*Response, error) {
+func (s *RoomsService) Delete(ctx context.Context, roomID RoomID, actionType string, opts ...RequestOption) (*Response, error) {
+	if actionType == "delete" && s.client.retention != nil {
+		if err := s.retain(ctx, roomID, opts); err != nil {
+			return nil, err
+		}
+	}
+
 	u := fmt.Sprintf("rooms/%d", roomID)
 
 	params := struct {
@@ -143,7 +271,7 @@ func (s *RoomsService) Delete(ctx context.Context, roomID int, actionType string
 		return nil, err
 	}
 
-	resp, err := s.client.Do(ctx, req, nil)
+	resp, err := s.client.Do(ctx, req, nil, opts...)
 	if err != nil {
 		return resp, err
 	}
@@ -154,90 +282,399 @@ func (s *RoomsService) Delete(ctx context.Context, roomID int, actionType string
 // Leave leaves the specified room.
 //
 // This is a convenience method that calls Delete with actionType "leave".
-func (s *RoomsService) Leave(ctx context.Context, roomID int) (*Response, error) {
-	return s.Delete(ctx, roomID, "leave")
+func (s *RoomsService) Leave(ctx context.Context, roomID RoomID, opts ...RequestOption) (*Response, error) {
+	return s.Delete(ctx, roomID, "leave", opts...)
 }
 
 // DeleteRoom deletes the specified room.
 //
 // Only the room creator can delete a room.
 // This is a convenience method that calls Delete with actionType "delete".
-func (s *RoomsService) DeleteRoom(ctx context.Context, roomID int) (*Response, error) {
-	return s.Delete(ctx, roomID, "delete")
+func (s *RoomsService) DeleteRoom(ctx context.Context, roomID RoomID, opts ...RequestOption) (*Response, error) {
+	return s.Delete(ctx, roomID, "delete", opts...)
 }
 
 // GetMembers returns the list of all members in the specified room.
 //
 // ChatWork API docs: https://developer.chatwork.com/reference/get-rooms-room_id-members
-func (s *RoomsService) GetMembers(ctx context.Context, roomID int) ([]*Member, *Response, error) {
+func (s *RoomsService) GetMembers(ctx context.Context, roomID RoomID, opts ...RequestOption) ([]*Member, *Response, error) {
 	u := fmt.Sprintf("rooms/%d/members", roomID)
 	req, err := s.client.NewRequest("GET", u, nil)
 	if err != nil {
 		return nil, nil, err
 	}
 
-	var members []*Member
-	resp, err := s.client.Do(ctx, req, &members)
-	if err != nil {
-		return nil, resp, err
+	return doValue[[]*Member](ctx, s.client, req, opts...)
+}
+
+// RoomDetails is the room and members fetched by RoomsService.GetAllDetails.
+type RoomDetails struct {
+	RoomID  RoomID
+	Room    *Room
+	Members []*Member
+
+	// Why the details could not be fetched, if they were not
+	Err error
+}
+
+// GetAllDetails fetches each of the rooms with its members, running up to
+// concurrency rooms at the same time (4 if not positive) as Batch does, and
+// returns the details in the order of roomIDs.
+//
+// Failures in individual rooms do not stop the others. They are joined into
+// the returned error.
+func (s *RoomsService) GetAllDetails(ctx context.Context, roomIDs []RoomID, concurrency int, opts ...RequestOption) ([]*RoomDetails, error) {
+	details := make([]*RoomDetails, len(roomIDs))
+	jobs := make([]BatchJob, len(roomIDs))
+	for i, roomID := range roomIDs {
+		d := &RoomDetails{RoomID: roomID}
+		details[i] = d
+		jobs[i] = BatchJob{
+			Name: fmt.Sprintf("room %d", roomID),
+			Run: func(ctx context.Context, client *Client) error {
+				room, _, err := client.Rooms.Get(ctx, d.RoomID, opts...)
+				if err != nil {
+					return err
+				}
+				members, _, err := client.Rooms.GetMembers(ctx, d.RoomID, opts...)
+				if err != nil {
+					return err
+				}
+				d.Room, d.Members = room, members
+				return nil
+			},
+		}
 	}
 
-	return members, resp, nil
+	results, err := Batch(ctx, s.client, jobs, &BatchOptions{Concurrency: concurrency})
+	for i, result := range results {
+		details[i].Err = result.Err
+	}
+	return details, err
 }
 
-// UpdateMembers updates the members of a room.
+// ReplaceMembers updates the members of a room and returns the resulting
+// members by role.
 //
 // This replaces all members in the room. Be sure to include all desired members.
-// Only room admins can update members.
+// Only room admins can update members. Permissions and policies name its
+// calls "Rooms.UpdateMembers".
 //
 // ChatWork API docs: https://developer.chatwork.com/reference/put-rooms-room_id-members
-func (s *RoomsService) UpdateMembers(ctx context.Context, roomID int, params *RoomMembersUpdateParams) (*Member, *Response, error) {
+func (s *RoomsService) ReplaceMembers(ctx context.Context, roomID RoomID, params *RoomMembersUpdateParams, opts ...RequestOption) (*RoomMembersUpdateResult, *Response, error) {
+	if params == nil {
+		return nil, nil, errNilParams
+	}
 	u := fmt.Sprintf("rooms/%d/members", roomID)
 	req, err := s.client.NewFormRequest("PUT", u, params)
 	if err != nil {
 		return nil, nil, err
 	}
 
-	member := new(Member)
-	resp, err := s.client.Do(ctx, req, member)
+	return Do[RoomMembersUpdateResult](ctx, s.client, req, opts...)
+}
+
+// UpdateMembers updates the members of a room like ReplaceMembers.
+//
+// Deprecated: The API responds with the member IDs by role, not a member,
+// so the returned *Member is always empty. Use ReplaceMembers instead.
+func (s *RoomsService) UpdateMembers(ctx context.Context, roomID RoomID, params *RoomMembersUpdateParams, opts ...RequestOption) (*Member, *Response, error) {
+	_, resp, err := s.ReplaceMembers(ctx, roomID, params, opts...)
 	if err != nil {
 		return nil, resp, err
 	}
+	return new(Member), resp, nil
+}
 
-	return member, resp, nil
+// AddMembers adds accounts to a room with role, keeping its current
+// members. Accounts already in the room are given role.
+//
+// Unlike ReplaceMembers, it reads the current members first and writes them
+// back with the change, so members that are not mentioned are never
+// dropped. Changes made by others in between are overwritten. If the
+// members already have their roles, nothing is written and the returned
+// *RoomMembersUpdateResult is nil.
+func (s *RoomsService) AddMembers(ctx context.Context, roomID RoomID, accountIDs []AccountID, role Role, opts ...RequestOption) (*RoomMembersUpdateResult, *Response, error) {
+	if err := role.Validate(); err != nil {
+		return nil, nil, err
+	}
+	return s.changeMembers(ctx, roomID, func(roles map[AccountID]Role) error {
+		for _, id := range accountIDs {
+			roles[id] = role
+		}
+		return nil
+	}, opts)
 }
 
-// GetMessagesReadStatus returns the read/unread status of a message.
+// RemoveMembers removes accounts from a room, keeping its other members,
+// like AddMembers. It fails without changes if no admin would remain.
+func (s *RoomsService) RemoveMembers(ctx context.Context, roomID RoomID, accountIDs []AccountID, opts ...RequestOption) (*RoomMembersUpdateResult, *Response, error) {
+	return s.changeMembers(ctx, roomID, func(roles map[AccountID]Role) error {
+		for _, id := range accountIDs {
+			delete(roles, id)
+		}
+		return nil
+	}, opts)
+}
+
+// ChangeMemberRole gives a member of a room another role, keeping the
+// other members, like AddMembers.
+// It fails if the account is not a member or no admin would remain.
+func (s *RoomsService) ChangeMemberRole(ctx context.Context, roomID RoomID, accountID AccountID, role Role, opts ...RequestOption) (*RoomMembersUpdateResult, *Response, error) {
+	if err := role.Validate(); err != nil {
+		return nil, nil, err
+	}
+	return s.changeMembers(ctx, roomID, func(roles map[AccountID]Role) error {
+		if _, ok := roles[accountID]; !ok {
+			return fmt.Errorf("%w: account %d is not a member of room %d", ErrNotFound, accountID, roomID)
+		}
+		roles[accountID] = role
+		return nil
+	}, opts)
+}
+
+// MemberDiff lists the changes between the members of two rooms, by
+// account ID in ascending order.
+type MemberDiff struct {
+	// Members of the second room that are not in the first
+	Added []AccountID
+
+	// Members of the first room that are not in the second
+	Removed []AccountID
+
+	// Members of both rooms with different roles
+	RoleChanged []AccountID
+}
+
+// Empty reports whether the rooms have the same members and roles.
+func (d *MemberDiff) Empty() bool {
+	return len(d.Added)+len(d.Removed)+len(d.RoleChanged) == 0
+}
+
+// DiffMembers compares the members of two rooms and returns the changes
+// that turn the members of roomA into those of roomB.
+func (s *RoomsService) DiffMembers(ctx context.Context, roomA, roomB RoomID, opts ...RequestOption) (*MemberDiff, *Response, error) {
+	budget := NewBudget(ctx, 2)
+	readCtx, cancel := budget.Next()
+	a, resp, err := s.memberRoles(readCtx, roomA, opts)
+	cancel()
+	if err != nil {
+		return nil, resp, err
+	}
+	readCtx, cancel = budget.Next()
+	defer cancel()
+	b, resp, err := s.memberRoles(readCtx, roomB, opts)
+	if err != nil {
+		return nil, resp, err
+	}
+	return diffRoles(a, b), resp, nil
+}
+
+// SyncMembersParams controls RoomsService.SyncMembers.
+type SyncMembersParams struct {
+	// Keep the members of the destination that are not in the source,
+	// rather than removing them
+	KeepExtra bool
+}
+
+// SyncMembers gives room dst the members of room src, with the same roles,
+// such as to keep a project room aligned with a team room. params may be
+// nil. It returns the changes made to dst, and makes none if the members
+// already match. It fails without changes if dst would have no admins.
+func (s *RoomsService) SyncMembers(ctx context.Context, src, dst RoomID, params *SyncMembersParams, opts ...RequestOption) (*MemberDiff, *Response, error) {
+	if params == nil {
+		params = &SyncMembersParams{}
+	}
+	// Reading dst and writing it back follow the read of src.
+	readCtx, cancel := NewBudget(ctx, 3).Next()
+	source, resp, err := s.memberRoles(readCtx, src, opts)
+	cancel()
+	if err != nil {
+		return nil, resp, err
+	}
+
+	var diff *MemberDiff
+	_, resp, err = s.changeMembers(ctx, dst, func(roles map[AccountID]Role) error {
+		target := make(map[AccountID]Role, len(source))
+		for id, role := range source {
+			target[id] = role
+		}
+		if params.KeepExtra {
+			for id, role := range roles {
+				if _, ok := target[id]; !ok {
+					target[id] = role
+				}
+			}
+		}
+		diff = diffRoles(roles, target)
+
+		for id := range roles {
+			delete(roles, id)
+		}
+		for id, role := range target {
+			roles[id] = role
+		}
+		return nil
+	}, opts)
+	if err != nil {
+		return nil, resp, err
+	}
+	return diff, resp, nil
+}
+
+// memberRoles returns the roles of the members of a room, by account ID.
+func (s *RoomsService) memberRoles(ctx context.Context, roomID RoomID, opts []RequestOption) (map[AccountID]Role, *Response, error) {
+	members, resp, err := s.GetMembers(ctx, roomID, opts...)
+	if err != nil {
+		return nil, resp, fmt.Errorf("room %d: %w", roomID, err)
+	}
+	roles := make(map[AccountID]Role, len(members))
+	for _, m := range members {
+		roles[m.AccountID] = m.Role
+	}
+	return roles, resp, nil
+}
+
+// diffRoles returns the changes from the roles in a to those in b.
+func diffRoles(a, b map[AccountID]Role) *MemberDiff {
+	diff := &MemberDiff{}
+	for id, role := range b {
+		switch previous, ok := a[id]; {
+		case !ok:
+			diff.Added = append(diff.Added, id)
+		case previous != role:
+			diff.RoleChanged = append(diff.RoleChanged, id)
+		}
+	}
+	for id := range a {
+		if _, ok := b[id]; !ok {
+			diff.Removed = append(diff.Removed, id)
+		}
+	}
+	slices.Sort(diff.Added)
+	slices.Sort(diff.Removed)
+	slices.Sort(diff.RoleChanged)
+	return diff
+}
+
+// ErrMembersChanged is returned by member helpers such as
+// RoomsService.AddMembers when the members of a room kept changing while
+// they were updated, with OptionMemberUpdateRetries.
+var ErrMembersChanged = errors.New("chatwork: room members changed concurrently")
+
+// OptionMemberUpdateRetries makes the member helpers of RoomsService, such
+// as AddMembers, check for concurrent changes. Before writing, they read
+// the members again; if they changed since the first read, the change is
+// merged into the new members, up to retries more times before failing
+// with ErrMembersChanged.
 //
-// The response is a map with "unread_num" and "mention_num" keys.
+// The API has no conditional updates, so this narrows the window in which
+// concurrent changes are overwritten to a single round trip rather than
+// closing it.
+func OptionMemberUpdateRetries(retries int) ClientOption {
+	return func(c *Client) {
+		c.memberUpdateRetries = retries
+	}
+}
+
+// changeMembers applies change to the roles of the current members of a
+// room, by account ID, and writes the result back. Nothing is written if
+// the roles are unchanged; the returned result is nil then.
+func (s *RoomsService) changeMembers(ctx context.Context, roomID RoomID, change func(roles map[AccountID]Role) error, opts []RequestOption) (*RoomMembersUpdateResult, *Response, error) {
+	for attempt := 0; ; attempt++ {
+		calls := 2
+		if s.client.memberUpdateRetries > 0 {
+			calls = 3
+		}
+		budget := NewBudget(ctx, calls)
+
+		readCtx, cancel := budget.Next()
+		current, resp, err := s.memberRoles(readCtx, roomID, opts)
+		cancel()
+		if err != nil {
+			return nil, resp, err
+		}
+		roles := make(map[AccountID]Role, len(current))
+		for id, role := range current {
+			roles[id] = role
+		}
+		if err := change(roles); err != nil {
+			return nil, resp, err
+		}
+		if diffRoles(current, roles).Empty() {
+			return nil, resp, nil
+		}
+
+		ids := make([]AccountID, 0, len(roles))
+		for id := range roles {
+			ids = append(ids, id)
+		}
+		slices.Sort(ids)
+		params := &RoomMembersUpdateParams{}
+		for _, id := range ids {
+			switch roles[id] {
+			case RoleAdmin:
+				params.MembersAdminIDs = append(params.MembersAdminIDs, id)
+			case RoleReadonly:
+				params.MembersReadonlyIDs = append(params.MembersReadonlyIDs, id)
+			default:
+				params.MembersMemberIDs = append(params.MembersMemberIDs, id)
+			}
+		}
+		if len(params.MembersAdminIDs) == 0 {
+			return nil, resp, fmt.Errorf("chatwork: room %d would have no admins", roomID)
+		}
+
+		if retries := s.client.memberUpdateRetries; retries > 0 {
+			readCtx, cancel := budget.Next()
+			latest, resp, err := s.memberRoles(readCtx, roomID, opts)
+			cancel()
+			if err != nil {
+				return nil, resp, err
+			}
+			if !diffRoles(current, latest).Empty() {
+				if attempt >= retries {
+					return nil, resp, fmt.Errorf("room %d: %w", roomID, ErrMembersChanged)
+				}
+				continue
+			}
+		}
+		writeCtx, cancel := budget.Next()
+		defer cancel()
+		return s.ReplaceMembers(writeCtx, roomID, params, opts...)
+	}
+}
+
+// GetMessagesReadStatus returns the read/unread status of a message.
 //
 // ChatWork API docs: https://developer.chatwork.com/reference/get-rooms-room_id-messages-read
-func (s *RoomsService) GetMessagesReadStatus(ctx context.Context, roomID int, messageID string) (map[string]int, *Response, error) {
+func (s *RoomsService) GetMessagesReadStatus(ctx context.Context, roomID RoomID, messageID MessageID, opts ...RequestOption) (*UnreadCount, *Response, error) {
 	u := fmt.Sprintf("rooms/%d/messages/read", roomID)
-	req, err := s.client.NewRequest("GET", u+"?message_id="+messageID, nil)
+	req, err := s.client.NewRequest("GET", u, nil)
 	if err != nil {
 		return nil, nil, err
 	}
 
-	var status map[string]int
-	resp, err := s.client.Do(ctx, req, &status)
-	if err != nil {
-		return nil, resp, err
-	}
+	q := req.URL.Query()
+	q.Set("message_id", string(messageID))
+	req.URL.RawQuery = q.Encode()
 
-	return status, resp, nil
+	return Do[UnreadCount](ctx, s.client, req, opts...)
 }
 
 // MarkMessagesAsRead marks messages as read up to the specified message.
 //
-// All messages up to and including the specified message will be marked as read.
+// All messages up to and including the specified message will be marked as
+// read. An empty messageID marks all messages of the room as read.
+//
+// The returned counts are those that remain.
 //
 // ChatWork API docs: https://developer.chatwork.com/reference/put-rooms-room_id-messages-read
-func (s *RoomsService) MarkMessagesAsRead(ctx context.Context, roomID int, messageID string) (map[string]string, *Response, error) {
+func (s *RoomsService) MarkMessagesAsRead(ctx context.Context, roomID RoomID, messageID MessageID, opts ...RequestOption) (*UnreadCount, *Response, error) {
 	u := fmt.Sprintf("rooms/%d/messages/read", roomID)
 
 	params := struct {
-		MessageID string `url:"message_id"`
+		MessageID MessageID `url:"message_id,omitempty"`
 	}{
 		MessageID: messageID,
 	}
@@ -247,34 +684,33 @@ func (s *RoomsService) MarkMessagesAsRead(ctx context.Context, roomID int, messa
 		return nil, nil, err
 	}
 
-	var result map[string]string
-	resp, err := s.client.Do(ctx, req, &result)
-	if err != nil {
-		return nil, resp, err
-	}
-
-	return result, resp, nil
+	return Do[UnreadCount](ctx, s.client, req, opts...)
 }
 
-// GetMessagesUnreadCount returns the number of unread messages in a room.
-//
-// The response includes "unread_num" and "mention_num".
+// GetMessagesUnreadCount returns the number of unread messages in a room,
+// and of those that mention the authenticated user.
 //
 // ChatWork API docs: https://developer.chatwork.com/reference/get-rooms-room_id-messages-unread
-func (s *RoomsService) GetMessagesUnreadCount(ctx context.Context, roomID int) (map[string]int, *Response, error) {
+func (s *RoomsService) GetMessagesUnreadCount(ctx context.Context, roomID RoomID, opts ...RequestOption) (*UnreadCount, *Response, error) {
 	u := fmt.Sprintf("rooms/%d/messages/unread", roomID)
 	req, err := s.client.NewRequest("GET", u, nil)
 	if err != nil {
 		return nil, nil, err
 	}
 
-	var count map[string]int
-	resp, err := s.client.Do(ctx, req, &count)
-	if err != nil {
-		return nil, resp, err
-	}
+	return Do[UnreadCount](ctx, s.client, req, opts...)
+}
+
+// HasUnread reports whether the room had unread messages when it was
+// listed.
+func (r *Room) HasUnread() bool {
+	return r.UnreadNum > 0
+}
 
-	return count, resp, nil
+// HasMention reports whether the room had unread messages mentioning the
+// authenticated user when it was listed.
+func (r *Room) HasMention() bool {
+	return r.MentionNum > 0
 }
 
 // GetFiles returns the list of files in a room.
@@ -282,10 +718,10 @@ func (s *RoomsService) GetMessagesUnreadCount(ctx context.Context, roomID int) (
 // If accountID is specified (non-zero), only files uploaded by that user are returned.
 //
 // ChatWork API docs: https://developer.chatwork.com/reference/get-rooms-room_id-files
-func (s *RoomsService) GetFiles(ctx context.Context, roomID, accountID int) ([]*File, *Response, error) {
+func (s *RoomsService) GetFiles(ctx context.Context, roomID RoomID, accountID AccountID, opts ...RequestOption) ([]*File, *Response, error) {
 	u := fmt.Sprintf("rooms/%d/files", roomID)
 	if accountID > 0 {
-		u += "?account_id=" + strconv.Itoa(accountID)
+		u += "?account_id=" + accountID.String()
 	}
 
 	req, err := s.client.NewRequest("GET", u, nil)
@@ -293,13 +729,7 @@ func (s *RoomsService) GetFiles(ctx context.Context, roomID, accountID int) ([]*
 		return nil, nil, err
 	}
 
-	var files []*File
-	resp, err := s.client.Do(ctx, req, &files)
-	if err != nil {
-		return nil, resp, err
-	}
-
-	return files, resp, nil
+	return doValue[[]*File](ctx, s.client, req, opts...)
 }
 
 // GetFile returns information about a specific file.
@@ -308,7 +738,7 @@ func (s *RoomsService) GetFiles(ctx context.Context, roomID, accountID int) ([]*
 // Download URLs are valid for a limited time.
 //
 // ChatWork API docs: https://developer.chatwork.com/reference/get-rooms-room_id-files-file_id
-func (s *RoomsService) GetFile(ctx context.Context, roomID, fileID int, createDownloadURL bool) (*File, *Response, error) {
+func (s *RoomsService) GetFile(ctx context.Context, roomID RoomID, fileID int, createDownloadURL bool, opts ...RequestOption) (*File, *Response, error) {
 	u := fmt.Sprintf("rooms/%d/files/%d", roomID, fileID)
 	if createDownloadURL {
 		u += "?create_download_url=1"
@@ -319,13 +749,56 @@ func (s *RoomsService) GetFile(ctx context.Context, roomID, fileID int, createDo
 		return nil, nil, err
 	}
 
-	file := new(File)
-	resp, err := s.client.Do(ctx, req, file)
+	return Do[File](ctx, s.client, req, opts...)
+}
+
+// FileUploadParams represents optional parameters for uploading a file.
+type FileUploadParams struct {
+	// Message posted with the file
+	Message string
+
+	// Content type of the file. Defaults to the type associated with the
+	// extension of the file name.
+	ContentType string
+}
+
+// FileUploadedResponse represents the response when a file is uploaded.
+type FileUploadedResponse struct {
+	// The ID of the uploaded file
+	FileID int `json:"file_id"`
+}
+
+// UploadFile uploads the contents read from content to a room as filename.
+// params may be nil.
+//
+// The multipart body is streamed from content as the request is sent, rather
+// than read into memory first, so large files can be uploaded by workers
+// with little memory. As a result the request has no Content-Length, and
+// WithProgress reports the total as -1. The upload is also never retried,
+// since content cannot be read again. content is not used after UploadFile
+// returns.
+//
+// ChatWork API docs: https://developer.chatwork.com/reference/post-rooms-room_id-files
+func (s *RoomsService) UploadFile(ctx context.Context, roomID RoomID, filename string, content io.Reader, params *FileUploadParams, opts ...RequestOption) (*FileUploadedResponse, *Response, error) {
+	if filename == "" {
+		return nil, nil, fmt.Errorf("%w: filename is required", ErrInvalidParams)
+	}
+	if params == nil {
+		params = &FileUploadParams{}
+	}
+
+	u := fmt.Sprintf("rooms/%d/files", roomID)
+	req, err := s.client.NewRequest("POST", u, nil)
 	if err != nil {
-		return nil, resp, err
+		return nil, nil, err
 	}
 
-	return file, resp, nil
+	body := newMultipartUpload(filename, content, params)
+	defer body.wait()
+	req.Body = body
+	req.Header.Set("Content-Type", body.contentType)
+
+	return Do[FileUploadedResponse](ctx, s.client, req, opts...)
 }
 
 // GetTasks returns the list of tasks in a room.
@@ -333,7 +806,12 @@ func (s *RoomsService) GetFile(ctx context.Context, roomID, fileID int, createDo
 // Tasks can be filtered by various parameters.
 //
 // ChatWork API docs: https://developer.chatwork.com/reference/get-rooms-room_id-tasks
-func (s *RoomsService) GetTasks(ctx context.Context, roomID int, params *TaskListParams) ([]*Task, *Response, error) {
+func (s *RoomsService) GetTasks(ctx context.Context, roomID RoomID, params *TaskListParams, opts ...RequestOption) ([]*Task, *Response, error) {
+	if params != nil {
+		if err := params.Validate(); err != nil {
+			return nil, nil, err
+		}
+	}
 	u := fmt.Sprintf("rooms/%d/tasks", roomID)
 
 	req, err := s.client.NewRequest("GET", u, nil)
@@ -345,34 +823,28 @@ func (s *RoomsService) GetTasks(ctx context.Context, roomID int, params *TaskLis
 	if params != nil {
 		q := req.URL.Query()
 		if params.AccountID > 0 {
-			q.Add("account_id", strconv.Itoa(params.AccountID))
+			q.Add("account_id", params.AccountID.String())
 		}
 		if params.AssignedByAccountID > 0 {
-			q.Add("assigned_by_account_id", strconv.Itoa(params.AssignedByAccountID))
+			q.Add("assigned_by_account_id", params.AssignedByAccountID.String())
 		}
 		if params.Status != "" {
-			q.Add("status", params.Status)
+			q.Add("status", string(params.Status))
 		}
 		req.URL.RawQuery = q.Encode()
 	}
 
-	var tasks []*Task
-	resp, err := s.client.Do(ctx, req, &tasks)
-	if err != nil {
-		return nil, resp, err
-	}
-
-	return tasks, resp, nil
+	return doValue[[]*Task](ctx, s.client, req, opts...)
 }
 
 // TaskListParams represents optional parameters for listing tasks.
 type TaskListParams struct {
 	// Filter by the account ID of the task assignee
-	AccountID int
+	AccountID AccountID
 
 	// Filter by the account ID of the task creator
-	AssignedByAccountID int
+	AssignedByAccountID AccountID
 
-	// Filter by task status: "open" or "done"
-	Status string
+	// Filter by task status
+	Status TaskStatus
 }
@@ -0,0 +1,76 @@
+package chatwork
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+)
+
+// StagingRoomEnv is the environment variable read by OptionStagingRoomFromEnv.
+const StagingRoomEnv = "CHATWORK_STAGING_ROOM_ID"
+
+// OptionStagingRoom redirects every message the client sends to roomID,
+// so that test runs and non-production environments do not notify real
+// teams. The room a message was meant for is noted at the start of its
+// body, and its mentions and replies are rendered as plain text, as
+// FormatImportedMessage does, so that members of the staging room are not
+// notified either. A roomID of zero leaves messages unchanged.
+//
+// Only new messages are redirected. Updates of messages in other rooms are
+// refused with ErrPermissionDenied, and other calls, such as creating tasks
+// or uploading files, still go to their rooms. Combine the option with
+// WithPermissions to refuse those.
+func OptionStagingRoom(roomID RoomID) ClientOption {
+	return func(c *Client) {
+		c.stagingRoom = roomID
+		c.stagingErr = nil
+	}
+}
+
+// OptionStagingRoomFromEnv is OptionStagingRoom with the room ID read from
+// the CHATWORK_STAGING_ROOM_ID environment variable. Messages are sent to
+// their rooms if it is unset or empty, as in production. If the variable is
+// not a room ID, the client refuses to send messages, returning an error
+// wrapping ErrInvalidParams, rather than notifying real teams.
+func OptionStagingRoomFromEnv() ClientOption {
+	value := os.Getenv(StagingRoomEnv)
+	if value == "" {
+		return OptionStagingRoom(0)
+	}
+	roomID, err := strconv.Atoi(value)
+	if err != nil || roomID <= 0 {
+		return func(c *Client) {
+			c.stagingErr = fmt.Errorf("%w: invalid %s %q", ErrInvalidParams, StagingRoomEnv, value)
+		}
+	}
+	return OptionStagingRoom(RoomID(roomID))
+}
+
+// stage returns the room and parameters a message for roomID is sent with.
+func (c *Client) stage(roomID RoomID, params *MessageCreateParams) (RoomID, *MessageCreateParams, error) {
+	if c.stagingErr != nil {
+		return 0, nil, c.stagingErr
+	}
+	if c.stagingRoom == 0 || roomID == c.stagingRoom {
+		return roomID, params, nil
+	}
+	staged := &MessageCreateParams{}
+	if params != nil {
+		*staged = *params
+	}
+	body := notifyingTagPattern.ReplaceAllString(staged.Body, "($1)")
+	staged.Body = fmt.Sprintf("[staging: room %d]\n%s", roomID, body)
+	return c.stagingRoom, staged, nil
+}
+
+// checkStagedUpdate returns an error if a message in roomID may not be
+// updated, as it is not in the staging room.
+func (c *Client) checkStagedUpdate(roomID RoomID) error {
+	if c.stagingErr != nil {
+		return c.stagingErr
+	}
+	if c.stagingRoom == 0 || roomID == c.stagingRoom {
+		return nil
+	}
+	return fmt.Errorf("%w: updating a message in room %d while staging to room %d", ErrPermissionDenied, roomID, c.stagingRoom)
+}
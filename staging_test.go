@@ -0,0 +1,104 @@
+package chatwork_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/nashirox/chatwork-go"
+	"github.com/nashirox/chatwork-go/chatworktest"
+)
+
+func TestOptionStagingRoom(t *testing.T) {
+	srv := chatworktest.NewServer()
+	defer srv.Close()
+
+	team := srv.AddRoom(chatwork.Room{Name: "Team"})
+	staging := srv.AddRoom(chatwork.Room{Name: "Staging"})
+	client := srv.Client(chatwork.OptionStagingRoom(staging))
+	ctx := context.Background()
+
+	params := &chatwork.MessageCreateParams{Body: "Deploy finished"}
+	if _, _, err := client.Messages.Create(ctx, team, params); err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := client.Messages.SendMessage(ctx, staging, "Direct"); err != nil {
+		t.Fatal(err)
+	}
+
+	if messages := srv.Messages(team); len(messages) != 0 {
+		t.Errorf("Expected no messages in the team room, got %d", len(messages))
+	}
+	messages := srv.Messages(staging)
+	if len(messages) != 2 {
+		t.Fatalf("Expected 2 messages in the staging room, got %d", len(messages))
+	}
+	if want := fmt.Sprintf("[staging: room %d]\nDeploy finished", team); messages[0].Body != want {
+		t.Errorf("Expected %q, got %q", want, messages[0].Body)
+	}
+	if messages[1].Body != "Direct" {
+		t.Errorf("Expected the staging room's own message unchanged, got %q", messages[1].Body)
+	}
+	if params.Body != "Deploy finished" {
+		t.Errorf("Expected the parameters to be left unchanged, got %q", params.Body)
+	}
+}
+
+func TestOptionStagingRoom_Notifications(t *testing.T) {
+	srv := chatworktest.NewServer()
+	defer srv.Close()
+
+	team := srv.AddRoom(chatwork.Room{Name: "Team"})
+	staging := srv.AddRoom(chatwork.Room{Name: "Staging"})
+	client := srv.Client(chatwork.OptionStagingRoom(staging))
+	ctx := context.Background()
+
+	if _, _, err := client.Messages.SendMessage(ctx, team, "[To:123]Alice [toall] [rp aid=456 to=1-2]Done"); err != nil {
+		t.Fatal(err)
+	}
+	messages := srv.Messages(staging)
+	if len(messages) != 1 {
+		t.Fatalf("Expected 1 message in the staging room, got %d", len(messages))
+	}
+	if want := fmt.Sprintf("[staging: room %d]\n(To:123)Alice (toall) (rp aid=456 to=1-2)Done", team); messages[0].Body != want {
+		t.Errorf("Expected %q, got %q", want, messages[0].Body)
+	}
+
+	update := &chatwork.MessageUpdateParams{Body: "Edited"}
+	if _, _, err := client.Messages.Update(ctx, team, "1", update); !errors.Is(err, chatwork.ErrPermissionDenied) {
+		t.Errorf("Expected updates outside the staging room to be refused, got %v", err)
+	}
+	if _, _, err := client.Messages.Update(ctx, staging, messages[0].MessageID, update); err != nil {
+		t.Errorf("Expected updates in the staging room to be sent, got %v", err)
+	}
+}
+
+func TestOptionStagingRoomFromEnv(t *testing.T) {
+	srv := chatworktest.NewServer()
+	defer srv.Close()
+
+	team := srv.AddRoom(chatwork.Room{Name: "Team"})
+	staging := srv.AddRoom(chatwork.Room{Name: "Staging"})
+
+	t.Setenv(chatwork.StagingRoomEnv, "")
+	if _, _, err := srv.Client(chatwork.OptionStagingRoomFromEnv()).Messages.SendMessage(context.Background(), team, "Production"); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv(chatwork.StagingRoomEnv, fmt.Sprint(staging))
+	if _, _, err := srv.Client(chatwork.OptionStagingRoomFromEnv()).Messages.SendMessage(context.Background(), team, "Test"); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Setenv(chatwork.StagingRoomEnv, "staging")
+	if _, _, err := srv.Client(chatwork.OptionStagingRoomFromEnv()).Messages.SendMessage(context.Background(), team, "Misconfigured"); !errors.Is(err, chatwork.ErrInvalidParams) {
+		t.Errorf("Expected messages to be refused with an invalid room ID, got %v", err)
+	}
+
+	if n := len(srv.Messages(team)); n != 1 {
+		t.Errorf("Expected 1 message in the team room, got %d", n)
+	}
+	if n := len(srv.Messages(staging)); n != 1 {
+		t.Errorf("Expected 1 message in the staging room, got %d", n)
+	}
+}
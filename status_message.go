@@ -0,0 +1,99 @@
+package chatwork
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+// StatusMessage is a message that is edited in place to report progress.
+//
+// It is created once with MessagesService.NewStatusMessage and then updated
+// repeatedly, so a long-running job shows a single message that changes
+// instead of flooding the room. When ChatWork no longer allows the message
+// to be edited, Update posts a new message and continues editing that one.
+//
+// A StatusMessage is safe for concurrent use.
+type StatusMessage struct {
+	messages *MessagesService
+	roomID   RoomID
+	created  time.Time
+
+	mu        sync.Mutex
+	messageID MessageID
+	body      string
+}
+
+// NewStatusMessage posts body to the room and returns a StatusMessage for editing it.
+func (s *MessagesService) NewStatusMessage(ctx context.Context, roomID RoomID, body string, opts ...RequestOption) (*StatusMessage, *Response, error) {
+	created, resp, err := s.SendMessage(ctx, roomID, body, opts...)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	status := &StatusMessage{
+		messages:  s,
+		roomID:    roomID,
+		created:   time.Now(),
+		messageID: created.MessageID,
+		body:      body,
+	}
+	return status, resp, nil
+}
+
+// MessageID returns the ID of the message currently being edited.
+func (m *StatusMessage) MessageID() MessageID {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.messageID
+}
+
+// Body returns the text most recently written to the message.
+func (m *StatusMessage) Body() string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.body
+}
+
+// Update replaces the text of the status message.
+//
+// Nothing is sent when body equals the current text. If the API refuses the
+// edit with 403 Forbidden, as it does once the edit window has passed, body
+// is posted as a new message which becomes the target of subsequent updates.
+func (m *StatusMessage) Update(ctx context.Context, body string, opts ...RequestOption) (*Response, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if body == m.body {
+		return nil, nil
+	}
+
+	params := &MessageUpdateParams{
+		Body: body,
+	}
+	_, resp, err := m.messages.Update(ctx, m.roomID, m.messageID, params, opts...)
+	if err == nil {
+		m.body = body
+		return resp, nil
+	}
+	if !isEditRejected(err) {
+		return resp, err
+	}
+
+	created, resp, err := m.messages.SendMessage(ctx, m.roomID, body, opts...)
+	if err != nil {
+		return resp, err
+	}
+	m.messageID = created.MessageID
+	m.body = body
+
+	return resp, nil
+}
+
+// isEditRejected reports whether err means the message can no longer be
+// edited. The API refuses such edits with 403 Forbidden; other errors, such
+// as invalid input or a deleted message, are returned to the caller.
+func isEditRejected(err error) bool {
+	return errors.Is(err, ErrForbidden)
+}
@@ -0,0 +1,63 @@
+package chatwork_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/nashirox/chatwork-go"
+	"github.com/nashirox/chatwork-go/chatworktest"
+)
+
+func TestStatusMessage_Update(t *testing.T) {
+	srv := chatworktest.NewServer()
+	defer srv.Close()
+
+	roomID := srv.AddRoom(chatwork.Room{Name: "Deploys"})
+	client := srv.Client()
+	ctx := context.Background()
+
+	status, _, err := client.Messages.NewStatusMessage(ctx, roomID, "Deploying: 0%")
+	if err != nil {
+		t.Fatalf("NewStatusMessage returned error: %v", err)
+	}
+	first := status.MessageID()
+
+	if _, err := status.Update(ctx, "Deploying: 50%"); err != nil {
+		t.Fatalf("Update returned error: %v", err)
+	}
+	if resp, err := status.Update(ctx, "Deploying: 50%"); resp != nil || err != nil {
+		t.Errorf("Expected an unchanged body not to be sent, got %v, %v", resp, err)
+	}
+	messages := srv.Messages(roomID)
+	if len(messages) != 1 || messages[0].Body != "Deploying: 50%" {
+		t.Fatalf("Expected the message to be edited in place, got %+v", messages)
+	}
+
+	path := "rooms/" + roomID.String() + "/messages/" + first.String()
+	srv.Fail("PUT", path, http.StatusBadRequest, "Invalid body")
+	if _, err := status.Update(ctx, "Deploying: 60%"); !errors.Is(err, chatwork.ErrBadRequest) {
+		t.Errorf("Expected a rejected body to be returned as an error, got %v", err)
+	}
+	if n := len(srv.Messages(roomID)); n != 1 || status.MessageID() != first {
+		t.Errorf("Expected no new message after a bad request, got %d messages", n)
+	}
+
+	srv.Fail("PUT", path, http.StatusForbidden, "Message can no longer be edited")
+	if _, err := status.Update(ctx, "Deploying: 75%"); err != nil {
+		t.Fatalf("Update returned error: %v", err)
+	}
+	second := status.MessageID()
+	if second == first {
+		t.Fatal("Expected a new message once the edit window has passed")
+	}
+	if _, err := status.Update(ctx, "Deployed"); err != nil {
+		t.Fatalf("Update returned error: %v", err)
+	}
+
+	messages = srv.Messages(roomID)
+	if len(messages) != 2 || messages[1].MessageID != second || messages[1].Body != "Deployed" || status.Body() != "Deployed" {
+		t.Errorf("Expected later updates to edit the new message, got %+v", messages)
+	}
+}
@@ -3,7 +3,8 @@ package chatwork
 import (
 	"context"
 	"fmt"
-	"strconv"
+	"sort"
+	"time"
 )
 
 // TasksService handles communication with the task related
@@ -18,19 +19,19 @@ type TaskCreateParams struct {
 	Body string `url:"body"`
 
 	// Account IDs to assign the task to (required)
-	ToIDs []int `url:"to_ids,comma"`
+	ToIDs []AccountID `url:"to_ids,comma"`
 
 	// Task deadline as Unix timestamp (optional)
 	Limit int64 `url:"limit,omitempty"`
 
-	// Type of deadline: "none", "date", or "time" (optional)
-	LimitType string `url:"limit_type,omitempty"`
+	// Type of deadline: LimitTypeNone, LimitTypeDate, or LimitTypeTime (optional)
+	LimitType LimitType `url:"limit_type,omitempty"`
 }
 
 // TaskCreatedResponse represents the response when tasks are created.
 type TaskCreatedResponse struct {
 	// IDs of the created tasks (one for each assignee)
-	TaskIDs []int `json:"task_ids"`
+	TaskIDs []TaskID `json:"task_ids"`
 }
 
 // Create creates new tasks in the specified room.
@@ -38,51 +39,47 @@ type TaskCreatedResponse struct {
 // A separate task is created for each account ID specified in ToIDs.
 //
 // ChatWork API docs: https://developer.chatwork.com/reference/post-rooms-room_id-tasks
-func (s *TasksService) Create(ctx context.Context, roomID int, params *TaskCreateParams) (*TaskCreatedResponse, *Response, error) {
+func (s *TasksService) Create(ctx context.Context, roomID RoomID, params *TaskCreateParams, opts ...RequestOption) (*TaskCreatedResponse, *Response, error) {
+	if params == nil {
+		return nil, nil, errNilParams
+	}
+	if err := params.Validate(); err != nil {
+		return nil, nil, err
+	}
 	u := fmt.Sprintf("rooms/%d/tasks", roomID)
 	req, err := s.client.NewFormRequest("POST", u, params)
 	if err != nil {
 		return nil, nil, err
 	}
 
-	result := new(TaskCreatedResponse)
-	resp, err := s.client.Do(ctx, req, result)
-	if err != nil {
-		return nil, resp, err
-	}
-
-	return result, resp, nil
+	return Do[TaskCreatedResponse](ctx, s.client, req, opts...)
 }
 
 // Get returns information about a specific task.
 //
 // ChatWork API docs: https://developer.chatwork.com/reference/get-rooms-room_id-tasks-task_id
-func (s *TasksService) Get(ctx context.Context, roomID, taskID int) (*Task, *Response, error) {
+func (s *TasksService) Get(ctx context.Context, roomID RoomID, taskID TaskID, opts ...RequestOption) (*Task, *Response, error) {
 	u := fmt.Sprintf("rooms/%d/tasks/%d", roomID, taskID)
 	req, err := s.client.NewRequest("GET", u, nil)
 	if err != nil {
 		return nil, nil, err
 	}
 
-	task := new(Task)
-	resp, err := s.client.Do(ctx, req, task)
-	if err != nil {
-		return nil, resp, err
-	}
-
-	return task, resp, nil
+	return Do[Task](ctx, s.client, req, opts...)
 }
 
-// UpdateStatus updates the status of a task.
-//
-// Status can be "open" or "done".
+// UpdateStatus updates the status of a task. An invalid status fails with
+// ErrInvalidParams.
 //
 // ChatWork API docs: https://developer.chatwork.com/reference/put-rooms-room_id-tasks-task_id-status
-func (s *TasksService) UpdateStatus(ctx context.Context, roomID, taskID int, status string) (*Task, *Response, error) {
+func (s *TasksService) UpdateStatus(ctx context.Context, roomID RoomID, taskID TaskID, status TaskStatus, opts ...RequestOption) (*Task, *Response, error) {
+	if err := status.Validate(); err != nil {
+		return nil, nil, err
+	}
 	u := fmt.Sprintf("rooms/%d/tasks/%d/status", roomID, taskID)
 
 	params := struct {
-		Body string `url:"body"`
+		Body TaskStatus `url:"body"`
 	}{
 		Body: status,
 	}
@@ -92,49 +89,104 @@ func (s *TasksService) UpdateStatus(ctx context.Context, roomID, taskID int, sta
 		return nil, nil, err
 	}
 
-	task := new(Task)
-	resp, err := s.client.Do(ctx, req, task)
-	if err != nil {
-		return nil, resp, err
-	}
-
-	return task, resp, nil
+	return Do[Task](ctx, s.client, req, opts...)
 }
 
 // Complete marks a task as completed.
 //
-// This is a convenience method that calls UpdateStatus with status "done".
-func (s *TasksService) Complete(ctx context.Context, roomID, taskID int) (*Task, *Response, error) {
-	return s.UpdateStatus(ctx, roomID, taskID, "done")
+// This is a convenience method that calls UpdateStatus with TaskStatusDone.
+func (s *TasksService) Complete(ctx context.Context, roomID RoomID, taskID TaskID, opts ...RequestOption) (*Task, *Response, error) {
+	return s.UpdateStatus(ctx, roomID, taskID, TaskStatusDone, opts...)
 }
 
 // Reopen marks a task as open (not completed).
 //
-// This is a convenience method that calls UpdateStatus with status "open".
-func (s *TasksService) Reopen(ctx context.Context, roomID, taskID int) (*Task, *Response, error) {
-	return s.UpdateStatus(ctx, roomID, taskID, "open")
+// This is a convenience method that calls UpdateStatus with TaskStatusOpen.
+func (s *TasksService) Reopen(ctx context.Context, roomID RoomID, taskID TaskID, opts ...RequestOption) (*Task, *Response, error) {
+	return s.UpdateStatus(ctx, roomID, taskID, TaskStatusOpen, opts...)
 }
 
 // CreateSimple is a convenience method for creating a task without a deadline.
-func (s *TasksService) CreateSimple(ctx context.Context, roomID int, body string, toIDs []int) (*TaskCreatedResponse, *Response, error) {
+func (s *TasksService) CreateSimple(ctx context.Context, roomID RoomID, body string, toIDs []AccountID, opts ...RequestOption) (*TaskCreatedResponse, *Response, error) {
 	params := &TaskCreateParams{
 		Body:  body,
 		ToIDs: toIDs,
 	}
-	return s.Create(ctx, roomID, params)
+	return s.Create(ctx, roomID, params, opts...)
 }
 
 // CreateWithDeadline is a convenience method for creating a task with a deadline.
 //
 // The deadline should be a Unix timestamp.
-func (s *TasksService) CreateWithDeadline(ctx context.Context, roomID int, body string, toIDs []int, deadline int64) (*TaskCreatedResponse, *Response, error) {
+func (s *TasksService) CreateWithDeadline(ctx context.Context, roomID RoomID, body string, toIDs []AccountID, deadline int64, opts ...RequestOption) (*TaskCreatedResponse, *Response, error) {
 	params := &TaskCreateParams{
 		Body:      body,
 		ToIDs:     toIDs,
 		Limit:     deadline,
-		LimitType: "time",
+		LimitType: LimitTypeTime,
+	}
+	return s.Create(ctx, roomID, params, opts...)
+}
+
+// CreateWithDueDate is a convenience method for creating a task due at
+// due, or on its date if dateOnly is set.
+func (s *TasksService) CreateWithDueDate(ctx context.Context, roomID RoomID, body string, toIDs []AccountID, due time.Time, dateOnly bool, opts ...RequestOption) (*TaskCreatedResponse, *Response, error) {
+	params := &TaskCreateParams{
+		Body:      body,
+		ToIDs:     toIDs,
+		Limit:     due.Unix(),
+		LimitType: LimitTypeTime,
+	}
+	if dateOnly {
+		params.LimitType = LimitTypeDate
+	}
+	return s.Create(ctx, roomID, params, opts...)
+}
+
+// Deadline returns when the task is due, or the zero time if it has no
+// deadline. For LimitTypeDate deadlines, only the date is meaningful.
+func (t *Task) Deadline() time.Time {
+	return taskDeadline(t.LimitTime, t.LimitType)
+}
+
+// Deadline returns when the task is due, or the zero time if it has no
+// deadline. For LimitTypeDate deadlines, only the date is meaningful.
+func (t *MyTask) Deadline() time.Time {
+	return taskDeadline(t.LimitTime, t.LimitType)
+}
+
+// IsOverdue reports whether the task is open and its deadline passed
+// before now. A LimitTypeDate deadline passes at the end of its date in
+// the location of now.
+func (t *Task) IsOverdue(now time.Time) bool {
+	return taskOverdue(t.Status, t.LimitTime, t.LimitType, now)
+}
+
+// IsOverdue reports whether the task is open and its deadline passed
+// before now. A LimitTypeDate deadline passes at the end of its date in
+// the location of now.
+func (t *MyTask) IsOverdue(now time.Time) bool {
+	return taskOverdue(t.Status, t.LimitTime, t.LimitType, now)
+}
+
+func taskOverdue(status TaskStatus, limit Timestamp, limitType LimitType, now time.Time) bool {
+	deadline := taskDeadline(limit, limitType)
+	if status != TaskStatusOpen || deadline.IsZero() {
+		return false
 	}
-	return s.Create(ctx, roomID, params)
+	if limitType == LimitTypeDate {
+		y, m, d := deadline.In(now.Location()).Date()
+		deadline = time.Date(y, m, d+1, 0, 0, 0, 0, now.Location())
+		return !now.Before(deadline)
+	}
+	return now.After(deadline)
+}
+
+func taskDeadline(limit Timestamp, limitType LimitType) time.Time {
+	if limit == 0 || limitType == LimitTypeNone {
+		return time.Time{}
+	}
+	return limit.Time()
 }
 
 // MyTasksService handles communication with the "my tasks" related
@@ -146,10 +198,10 @@ type MyTasksService service
 // MyTaskListParams represents optional parameters for listing my tasks.
 type MyTaskListParams struct {
 	// Filter by the account ID of who assigned the task
-	AssignedByAccountID int
+	AssignedByAccountID AccountID
 
-	// Filter by task status: "open" or "done"
-	Status string
+	// Filter by task status
+	Status TaskStatus
 }
 
 // List returns all tasks assigned to the authenticated user.
@@ -157,7 +209,12 @@ type MyTaskListParams struct {
 // Tasks can be filtered by status and who assigned them.
 //
 // ChatWork API docs: https://developer.chatwork.com/reference/get-my-tasks
-func (s *MyTasksService) List(ctx context.Context, params *MyTaskListParams) ([]*MyTask, *Response, error) {
+func (s *MyTasksService) List(ctx context.Context, params *MyTaskListParams, opts ...RequestOption) ([]*MyTask, *Response, error) {
+	if params != nil {
+		if err := params.Validate(); err != nil {
+			return nil, nil, err
+		}
+	}
 	req, err := s.client.NewRequest("GET", "my/tasks", nil)
 	if err != nil {
 		return nil, nil, err
@@ -167,48 +224,96 @@ func (s *MyTasksService) List(ctx context.Context, params *MyTaskListParams) ([]
 	if params != nil {
 		q := req.URL.Query()
 		if params.AssignedByAccountID > 0 {
-			q.Add("assigned_by_account_id", strconv.Itoa(params.AssignedByAccountID))
+			q.Add("assigned_by_account_id", params.AssignedByAccountID.String())
 		}
 		if params.Status != "" {
-			q.Add("status", params.Status)
+			q.Add("status", string(params.Status))
 		}
 		req.URL.RawQuery = q.Encode()
 	}
 
-	var tasks []*MyTask
-	resp, err := s.client.Do(ctx, req, &tasks)
+	return doValue[[]*MyTask](ctx, s.client, req, opts...)
+}
+
+// GetOverdue returns the open tasks assigned to the authenticated user
+// whose deadline has passed, as reported by MyTask.IsOverdue in the local
+// time zone.
+func (s *MyTasksService) GetOverdue(ctx context.Context, opts ...RequestOption) ([]*MyTask, *Response, error) {
+	tasks, resp, err := s.GetOpen(ctx, opts...)
 	if err != nil {
 		return nil, resp, err
 	}
 
-	return tasks, resp, nil
+	now := time.Now()
+	var overdue []*MyTask
+	for _, t := range tasks {
+		if t.IsOverdue(now) {
+			overdue = append(overdue, t)
+		}
+	}
+	return overdue, resp, nil
+}
+
+// MyTaskGroup holds the tasks of the authenticated user in one room.
+type MyTaskGroup struct {
+	Room  TaskRoom
+	Tasks []*MyTask
+}
+
+// ListGroupedByRoom lists the tasks selected by params, which may be nil,
+// and groups them by room, such as for per-room summaries. Groups are
+// ordered by room name, and tasks keep the order of the listing.
+func (s *MyTasksService) ListGroupedByRoom(ctx context.Context, params *MyTaskListParams, opts ...RequestOption) ([]*MyTaskGroup, *Response, error) {
+	tasks, resp, err := s.List(ctx, params, opts...)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	var groups []*MyTaskGroup
+	byRoom := make(map[RoomID]*MyTaskGroup)
+	for _, t := range tasks {
+		group := byRoom[t.Room.RoomID]
+		if group == nil {
+			group = &MyTaskGroup{Room: t.Room}
+			byRoom[t.Room.RoomID] = group
+			groups = append(groups, group)
+		}
+		group.Tasks = append(group.Tasks, t)
+	}
+	sort.SliceStable(groups, func(i, j int) bool {
+		if groups[i].Room.Name != groups[j].Room.Name {
+			return groups[i].Room.Name < groups[j].Room.Name
+		}
+		return groups[i].Room.RoomID < groups[j].Room.RoomID
+	})
+	return groups, resp, nil
 }
 
 // GetOpen returns all open (uncompleted) tasks assigned to the authenticated user.
 //
-// This is a convenience method that calls List with status "open".
-func (s *MyTasksService) GetOpen(ctx context.Context) ([]*MyTask, *Response, error) {
+// This is a convenience method that calls List with TaskStatusOpen.
+func (s *MyTasksService) GetOpen(ctx context.Context, opts ...RequestOption) ([]*MyTask, *Response, error) {
 	params := &MyTaskListParams{
-		Status: "open",
+		Status: TaskStatusOpen,
 	}
-	return s.List(ctx, params)
+	return s.List(ctx, params, opts...)
 }
 
 // GetCompleted returns all completed tasks assigned to the authenticated user.
 //
-// This is a convenience method that calls List with status "done".
-func (s *MyTasksService) GetCompleted(ctx context.Context) ([]*MyTask, *Response, error) {
+// This is a convenience method that calls List with TaskStatusDone.
+func (s *MyTasksService) GetCompleted(ctx context.Context, opts ...RequestOption) ([]*MyTask, *Response, error) {
 	params := &MyTaskListParams{
-		Status: "done",
+		Status: TaskStatusDone,
 	}
-	return s.List(ctx, params)
+	return s.List(ctx, params, opts...)
 }
 
 // GetByRoom returns tasks assigned to the authenticated user in a specific room.
 //
 // This fetches all tasks and filters them by room ID locally.
-func (s *MyTasksService) GetByRoom(ctx context.Context, roomID int) ([]*MyTask, *Response, error) {
-	allTasks, resp, err := s.List(ctx, nil)
+func (s *MyTasksService) GetByRoom(ctx context.Context, roomID RoomID, opts ...RequestOption) ([]*MyTask, *Response, error) {
+	allTasks, resp, err := s.List(ctx, nil, opts...)
 	if err != nil {
 		return nil, resp, err
 	}
@@ -226,15 +331,15 @@ func (s *MyTasksService) GetByRoom(ctx context.Context, roomID int) ([]*MyTask,
 // CompleteTask marks a task as completed.
 //
 // This is a convenience method that uses the Tasks service to complete a task.
-func (s *MyTasksService) CompleteTask(ctx context.Context, roomID, taskID int) (*Task, *Response, error) {
+func (s *MyTasksService) CompleteTask(ctx context.Context, roomID RoomID, taskID TaskID, opts ...RequestOption) (*Task, *Response, error) {
 	tasksService := (*TasksService)(&s.client.common)
-	return tasksService.Complete(ctx, roomID, taskID)
+	return tasksService.Complete(ctx, roomID, taskID, opts...)
 }
 
 // ReopenTask marks a task as open (not completed).
 //
 // This is a convenience method that uses the Tasks service to reopen a task.
-func (s *MyTasksService) ReopenTask(ctx context.Context, roomID, taskID int) (*Task, *Response, error) {
+func (s *MyTasksService) ReopenTask(ctx context.Context, roomID RoomID, taskID TaskID, opts ...RequestOption) (*Task, *Response, error) {
 	tasksService := (*TasksService)(&s.client.common)
-	return tasksService.Reopen(ctx, roomID, taskID)
+	return tasksService.Reopen(ctx, roomID, taskID, opts...)
 }
@@ -0,0 +1,118 @@
+package chatwork_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/nashirox/chatwork-go"
+	"github.com/nashirox/chatwork-go/chatworktest"
+)
+
+func TestTasksService_CreateWithDueDate(t *testing.T) {
+	srv := chatworktest.NewServer()
+	defer srv.Close()
+
+	roomID := srv.AddRoom(chatwork.Room{Name: "Team"})
+	client := srv.Client()
+	ctx := context.Background()
+
+	due := time.Date(2024, 3, 1, 17, 0, 0, 0, time.UTC)
+	if _, _, err := client.Tasks.CreateWithDueDate(ctx, roomID, "Report", []chatwork.AccountID{100}, due, false); err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := client.Tasks.CreateWithDueDate(ctx, roomID, "Review", []chatwork.AccountID{100}, due, true); err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := client.Tasks.CreateSimple(ctx, roomID, "Someday", []chatwork.AccountID{100}); err != nil {
+		t.Fatal(err)
+	}
+
+	tasks := srv.Tasks(roomID)
+	if len(tasks) != 3 {
+		t.Fatalf("Expected 3 tasks, got %d", len(tasks))
+	}
+	for i, want := range []chatwork.LimitType{chatwork.LimitTypeTime, chatwork.LimitTypeDate, chatwork.LimitTypeNone} {
+		if tasks[i].LimitType != want {
+			t.Errorf("Expected task %d to have limit type %q, got %q", i, want, tasks[i].LimitType)
+		}
+	}
+	if deadline := tasks[0].Deadline(); !deadline.Equal(due) {
+		t.Errorf("Expected deadline %v, got %v", due, deadline)
+	}
+	if deadline := tasks[2].Deadline(); !deadline.IsZero() {
+		t.Errorf("Expected no deadline, got %v", deadline)
+	}
+}
+
+func TestMyTask_IsOverdue(t *testing.T) {
+	tokyo := time.FixedZone("JST", 9*60*60)
+	due := time.Date(2024, 3, 1, 10, 0, 0, 0, tokyo)
+	now := time.Date(2024, 3, 1, 18, 0, 0, 0, tokyo)
+
+	tests := []struct {
+		name string
+		task chatwork.MyTask
+		want bool
+	}{
+		{"time passed", chatwork.MyTask{Status: "open", LimitTime: chatwork.NewTimestamp(due), LimitType: chatwork.LimitTypeTime}, true},
+		{"time ahead", chatwork.MyTask{Status: "open", LimitTime: chatwork.NewTimestamp(now.Add(time.Hour)), LimitType: chatwork.LimitTypeTime}, false},
+		{"date today", chatwork.MyTask{Status: "open", LimitTime: chatwork.NewTimestamp(due), LimitType: chatwork.LimitTypeDate}, false},
+		{"date yesterday", chatwork.MyTask{Status: "open", LimitTime: chatwork.NewTimestamp(due.AddDate(0, 0, -1)), LimitType: chatwork.LimitTypeDate}, true},
+		{"done", chatwork.MyTask{Status: "done", LimitTime: chatwork.NewTimestamp(due), LimitType: chatwork.LimitTypeTime}, false},
+		{"no deadline", chatwork.MyTask{Status: "open", LimitType: chatwork.LimitTypeNone}, false},
+	}
+	for _, tt := range tests {
+		if got := tt.task.IsOverdue(now); got != tt.want {
+			t.Errorf("%s: expected IsOverdue %v, got %v", tt.name, tt.want, got)
+		}
+	}
+}
+
+func TestMyTasksService_GetOverdue(t *testing.T) {
+	srv := chatworktest.NewServer()
+	defer srv.Close()
+
+	srv.SetMe(chatwork.Me{AccountID: 100})
+	roomID := srv.AddRoom(chatwork.Room{Name: "Team"})
+	me := chatwork.User{AccountID: 100}
+	late := srv.AddTask(roomID, chatwork.Task{Account: me, Status: "open", LimitTime: chatwork.NewTimestamp(time.Now().Add(-time.Hour)), LimitType: chatwork.LimitTypeTime})
+	srv.AddTask(roomID, chatwork.Task{Account: me, Status: "open", LimitTime: chatwork.NewTimestamp(time.Now().Add(time.Hour)), LimitType: chatwork.LimitTypeTime})
+	srv.AddTask(roomID, chatwork.Task{Account: me, Status: "done", LimitTime: chatwork.NewTimestamp(time.Now().Add(-time.Hour)), LimitType: chatwork.LimitTypeTime})
+
+	overdue, _, err := srv.Client().MyTasks.GetOverdue(context.Background())
+	if err != nil {
+		t.Fatalf("GetOverdue returned error: %v", err)
+	}
+	if len(overdue) != 1 || overdue[0].TaskID != late {
+		t.Errorf("Expected only task %d, got %v", late, overdue)
+	}
+}
+
+func TestMyTasksService_ListGroupedByRoom(t *testing.T) {
+	srv := chatworktest.NewServer()
+	defer srv.Close()
+
+	srv.SetMe(chatwork.Me{AccountID: 100})
+	me := chatwork.User{AccountID: 100}
+	sales := srv.AddRoom(chatwork.Room{Name: "Sales"})
+	dev := srv.AddRoom(chatwork.Room{Name: "Dev"})
+	srv.AddTask(sales, chatwork.Task{Account: me, Status: "open", Body: "Call"})
+	srv.AddTask(dev, chatwork.Task{Account: me, Status: "open", Body: "Fix"})
+	srv.AddTask(dev, chatwork.Task{Account: me, Status: "open", Body: "Review"})
+	srv.AddTask(dev, chatwork.Task{Account: me, Status: "done", Body: "Deploy"})
+
+	groups, _, err := srv.Client().MyTasks.ListGroupedByRoom(context.Background(), chatwork.NewMyTaskListParams().WithStatus("open"))
+	if err != nil {
+		t.Fatalf("ListGroupedByRoom returned error: %v", err)
+	}
+	if len(groups) != 2 {
+		t.Fatalf("Expected 2 groups, got %d", len(groups))
+	}
+	if groups[0].Room.RoomID != dev || len(groups[0].Tasks) != 2 || groups[0].Tasks[0].Body != "Fix" {
+		t.Errorf("Unexpected first group: %+v", groups[0])
+	}
+	if groups[1].Room.RoomID != sales || len(groups[1].Tasks) != 1 {
+		t.Errorf("Unexpected second group: %+v", groups[1])
+	}
+}
@@ -0,0 +1,256 @@
+package chatwork
+
+import (
+	"fmt"
+	"math/rand"
+	"sort"
+	"strings"
+	"sync"
+	"text/template"
+	"time"
+)
+
+// TemplateVariant is one wording of a message template, for rolling out
+// new wordings gradually or comparing them. See
+// MessageTemplates.RegisterVariants.
+type TemplateVariant struct {
+	Name string
+
+	// Relative share of messages rendered with the variant. A variant with
+	// a weight of zero is never picked.
+	Weight int
+
+	Text string
+}
+
+type templateVariant struct {
+	name   string
+	weight int
+	tmpl   *template.Template
+}
+
+// RegisterVariants parses the variants and adds them as the template name,
+// replacing any template of the same name. Each message rendered from it
+// uses a variant picked at random by weight, such as:
+//
+//	err := templates.RegisterVariants("deploy-failed",
+//		chatwork.TemplateVariant{Name: "blue", Weight: 90, Text: `Deploy of {{.Service}} failed`},
+//		chatwork.TemplateVariant{Name: "green", Weight: 10, Text: `{{.Service}}: deploy failed, see the log`},
+//	)
+func (t *MessageTemplates) RegisterVariants(name string, variants ...TemplateVariant) error {
+	parsed := make([]*templateVariant, 0, len(variants))
+	total := 0
+	for _, v := range variants {
+		if v.Weight < 0 {
+			return fmt.Errorf("chatwork: negative weight of template %q variant %q", name, v.Name)
+		}
+		tmpl, err := template.New(name).Funcs(templateFuncs).Option("missingkey=error").Parse(v.Text)
+		if err != nil {
+			return err
+		}
+		parsed = append(parsed, &templateVariant{name: v.Name, weight: v.Weight, tmpl: tmpl})
+		total += v.Weight
+	}
+	if total == 0 {
+		return fmt.Errorf("chatwork: template %q has no variant with a positive weight", name)
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.templates[name] = parsed
+	return nil
+}
+
+// RenderVariant is Render that also returns the name of the variant used.
+func (t *MessageTemplates) RenderVariant(name string, data interface{}) (body, variant string, err error) {
+	t.mu.RLock()
+	variants, ok := t.templates[name]
+	t.mu.RUnlock()
+	if !ok {
+		return "", "", fmt.Errorf("chatwork: no message template named %q", name)
+	}
+
+	v := pickVariant(variants)
+	var b strings.Builder
+	if err := v.tmpl.Execute(&b, data); err != nil {
+		return "", "", err
+	}
+	return b.String(), v.name, nil
+}
+
+// pickVariant returns a variant at random by weight.
+func pickVariant(variants []*templateVariant) *templateVariant {
+	total := 0
+	for _, v := range variants {
+		total += v.weight
+	}
+	n := rand.Intn(total)
+	for _, v := range variants {
+		if n < v.weight {
+			return v
+		}
+		n -= v.weight
+	}
+	return variants[len(variants)-1]
+}
+
+// DefaultTemplateRetention is how long a TemplateExperiment keeps the
+// messages sent with templates by default. Replies to older messages are no
+// longer counted.
+const DefaultTemplateRetention = 7 * 24 * time.Hour
+
+// TemplateSend describes a message sent by MessagesService.SendTemplate.
+type TemplateSend struct {
+	Template  string
+	Variant   string
+	RoomID    RoomID
+	MessageID MessageID
+	Time      time.Time
+}
+
+// VariantReport is the engagement with the messages sent with a variant.
+type VariantReport struct {
+	Template string
+	Variant  string
+
+	// Messages sent, and how many of them were replied to
+	Sent    int
+	Replied int
+}
+
+// ReplyRate returns the share of sent messages that were replied to.
+func (r *VariantReport) ReplyRate() float64 {
+	if r.Sent == 0 {
+		return 0
+	}
+	return float64(r.Replied) / float64(r.Sent)
+}
+
+// TemplateExperiment measures the engagement with template variants by
+// the replies their messages receive.
+//
+// The API reports only the unread counts of the authenticated account,
+// not whether others read a message, so replies are the measurable
+// acknowledgement. Record sent messages by setting Sent as the OnSend hook
+// of the templates, and pass the messages of the rooms, such as from
+// Client.Watch, to Observe:
+//
+//	experiment := chatwork.NewTemplateExperiment()
+//	templates.OnSend = experiment.Sent
+//
+// Reports cover the messages sent within the retention window; Reset starts
+// a new experiment. A TemplateExperiment is safe for concurrent use.
+type TemplateExperiment struct {
+	// How long sent messages are kept, counting from the send time of the
+	// latest one. Defaults to DefaultTemplateRetention; a negative value keeps
+	// every message.
+	Retention time.Duration
+
+	mu      sync.Mutex
+	sent    map[string]*TemplateSend // by room and message ID
+	replied map[string]bool
+}
+
+// NewTemplateExperiment returns an empty TemplateExperiment.
+func NewTemplateExperiment() *TemplateExperiment {
+	return &TemplateExperiment{
+		sent:    make(map[string]*TemplateSend),
+		replied: make(map[string]bool),
+	}
+}
+
+func sentKey(roomID RoomID, messageID MessageID) string {
+	return fmt.Sprintf("%d-%s", roomID, messageID)
+}
+
+// Sent records a message sent with a template, and forgets the messages
+// sent before the retention window.
+func (e *TemplateExperiment) Sent(s *TemplateSend) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.sent[sentKey(s.RoomID, s.MessageID)] = s
+
+	retention := e.Retention
+	if retention == 0 {
+		retention = DefaultTemplateRetention
+	}
+	if retention < 0 {
+		return
+	}
+	cutoff := s.Time.Add(-retention)
+	for key, sent := range e.sent {
+		if sent.Time.Before(cutoff) {
+			delete(e.sent, key)
+			delete(e.replied, key)
+		}
+	}
+}
+
+// Reset forgets the messages sent and the replies observed so far.
+func (e *TemplateExperiment) Reset() {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.sent = make(map[string]*TemplateSend)
+	e.replied = make(map[string]bool)
+}
+
+// Observe records the replies in a message received in a room to messages
+// sent with templates.
+func (e *TemplateExperiment) Observe(roomID RoomID, m *Message) {
+	var replies []*Node
+	var walk func([]*Node)
+	walk = func(nodes []*Node) {
+		for _, n := range nodes {
+			if n.Type == NodeReply && n.MessageID != "" {
+				replies = append(replies, n)
+			}
+			walk(n.Children)
+		}
+	}
+	walk(ParseMessage(m.Body))
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	for _, n := range replies {
+		room := n.RoomID
+		if room == 0 {
+			room = roomID
+		}
+		if key := sentKey(room, n.MessageID); e.sent[key] != nil {
+			e.replied[key] = true
+		}
+	}
+}
+
+// Report returns the engagement per template variant, ordered by template
+// and variant name.
+func (e *TemplateExperiment) Report() []*VariantReport {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	byVariant := make(map[[2]string]*VariantReport)
+	for key, s := range e.sent {
+		id := [2]string{s.Template, s.Variant}
+		r := byVariant[id]
+		if r == nil {
+			r = &VariantReport{Template: s.Template, Variant: s.Variant}
+			byVariant[id] = r
+		}
+		r.Sent++
+		if e.replied[key] {
+			r.Replied++
+		}
+	}
+
+	reports := make([]*VariantReport, 0, len(byVariant))
+	for _, r := range byVariant {
+		reports = append(reports, r)
+	}
+	sort.Slice(reports, func(i, j int) bool {
+		if reports[i].Template != reports[j].Template {
+			return reports[i].Template < reports[j].Template
+		}
+		return reports[i].Variant < reports[j].Variant
+	})
+	return reports
+}
@@ -0,0 +1,84 @@
+package chatwork
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestTemplateExperiment(t *testing.T) {
+	templates := NewMessageTemplates()
+	err := templates.RegisterVariants("alert",
+		TemplateVariant{Name: "blue", Weight: 1, Text: `Alert: {{.}}`},
+		TemplateVariant{Name: "green", Weight: 0, Text: `{{.}} needs attention`},
+	)
+	if err != nil {
+		t.Fatalf("RegisterVariants returned error: %v", err)
+	}
+	if err := templates.RegisterVariants("disabled", TemplateVariant{Name: "off", Text: "x"}); err == nil {
+		t.Error("Expected an error for variants without weight")
+	}
+
+	id := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id++
+		r.ParseForm()
+		if body := r.PostForm.Get("body"); body != "Alert: disk" {
+			t.Errorf("Expected the blue variant, got %q", body)
+		}
+		w.Write([]byte(`{"message_id":"` + strconv.Itoa(id) + `"}`))
+	}))
+	defer server.Close()
+
+	experiment := NewTemplateExperiment()
+	templates.OnSend = experiment.Sent
+	client := New(testToken, OptionMessageTemplates(templates))
+	client.BaseURL, _ = url.Parse(server.URL)
+
+	for i := 0; i < 2; i++ {
+		if _, _, err := client.Messages.SendTemplate(context.Background(), 10, "alert", "disk"); err != nil {
+			t.Fatalf("SendTemplate returned error: %v", err)
+		}
+	}
+	experiment.Observe(10, &Message{Body: "[rp aid=5 to=10-1] On it"})
+	experiment.Observe(10, &Message{Body: "[rp aid=5 to=11-2] Other room"})
+
+	reports := experiment.Report()
+	if len(reports) != 1 {
+		t.Fatalf("Expected 1 report, got %d", len(reports))
+	}
+	r := reports[0]
+	if r.Template != "alert" || r.Variant != "blue" || r.Sent != 2 || r.Replied != 1 || r.ReplyRate() != 0.5 {
+		t.Errorf("Unexpected report: %+v", r)
+	}
+}
+
+func TestTemplateExperiment_Retention(t *testing.T) {
+	experiment := NewTemplateExperiment()
+	experiment.Retention = time.Hour
+
+	start := time.Date(2024, 1, 1, 9, 0, 0, 0, time.UTC)
+	experiment.Sent(&TemplateSend{Template: "alert", Variant: "blue", RoomID: 10, MessageID: "1", Time: start})
+	experiment.Observe(10, &Message{Body: "[rp aid=5 to=10-1] On it"})
+	experiment.Sent(&TemplateSend{Template: "alert", Variant: "blue", RoomID: 10, MessageID: "2", Time: start.Add(30 * time.Minute)})
+	if reports := experiment.Report(); len(reports) != 1 || reports[0].Sent != 2 || reports[0].Replied != 1 {
+		t.Fatalf("Expected both messages within the window, got %+v", reports)
+	}
+
+	experiment.Sent(&TemplateSend{Template: "alert", Variant: "blue", RoomID: 10, MessageID: "3", Time: start.Add(90 * time.Minute)})
+	if reports := experiment.Report(); len(reports) != 1 || reports[0].Sent != 2 || reports[0].Replied != 0 {
+		t.Errorf("Expected the first message and its reply to be forgotten, got %+v", reports)
+	}
+	if len(experiment.replied) != 0 {
+		t.Errorf("Expected no reply to be kept, got %v", experiment.replied)
+	}
+
+	experiment.Reset()
+	if reports := experiment.Report(); len(reports) != 0 {
+		t.Errorf("Expected no report after Reset, got %+v", reports)
+	}
+}
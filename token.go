@@ -0,0 +1,67 @@
+package chatwork
+
+import (
+	"context"
+	"net/http"
+)
+
+// TokenProvider supplies the API token used to authenticate each request.
+//
+// Implementations can fetch tokens from a secret manager or rotate them
+// without recreating the Client. Token is called when a request is built
+// with NewRequest, and again by Client.Do for every attempt, including
+// retries, with the context of the call, so implementations that perform
+// remote lookups should cache the result.
+type TokenProvider interface {
+	Token(ctx context.Context) (string, error)
+}
+
+// StaticToken is a TokenProvider that always returns the same token.
+type StaticToken string
+
+// Token returns the token itself.
+func (t StaticToken) Token(context.Context) (string, error) {
+	return string(t), nil
+}
+
+// TokenProviderFunc adapts an ordinary function to the TokenProvider interface.
+type TokenProviderFunc func(ctx context.Context) (string, error)
+
+// Token calls f(ctx).
+func (f TokenProviderFunc) Token(ctx context.Context) (string, error) {
+	return f(ctx)
+}
+
+// OptionTokenProvider sets a TokenProvider that is consulted on every request.
+// It takes precedence over the token passed to New.
+//
+// Example:
+//
+//	provider := chatwork.TokenProviderFunc(func(ctx context.Context) (string, error) {
+//		return secrets.Get(ctx, "chatwork-token")
+//	})
+//	client := chatwork.New("", chatwork.OptionTokenProvider(provider))
+func OptionTokenProvider(provider TokenProvider) ClientOption {
+	return func(c *Client) {
+		c.tokenProvider = provider
+	}
+}
+
+// authenticate sets the authentication header on req.
+func (c *Client) authenticate(ctx context.Context, req *http.Request) error {
+	token := c.token
+	if c.tokenProvider != nil {
+		var err error
+		token, err = c.tokenProvider.Token(ctx)
+		if err != nil {
+			return err
+		}
+	}
+
+	if c.bearerAuth {
+		req.Header.Set("Authorization", "Bearer "+token)
+		return nil
+	}
+	req.Header.Set("X-ChatWorkToken", token)
+	return nil
+}
@@ -0,0 +1,239 @@
+package chatwork
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// DefaultTokenCooldown is how long a token of a pool is left unused after
+	// it was rejected with 401 Unauthorized.
+	DefaultTokenCooldown = 10 * time.Minute
+
+	// Number of consecutive 401 responses after which a token is rotated out.
+	// A single 429 is enough, as the token's limit stays exhausted until reset.
+	tokenPoolMaxUnauthorized = 2
+)
+
+// TokenStats holds the metrics of a single token of a pool, as returned by
+// Client.TokenPoolStats.
+type TokenStats struct {
+	// Whether the token is the one currently in use
+	Active bool
+
+	// Number of requests sent with the token
+	Requests int64
+
+	// Number of 401 and 429 responses to those requests
+	Unauthorized int64
+	RateLimited  int64
+
+	// Number of times the pool switched away from the token
+	Rotations int64
+
+	// Time until which the token is not used. Zero when it is not cooling down.
+	CooldownUntil time.Time
+}
+
+// OptionTokenPool authenticates with a pool of tokens, such as those of
+// several bot accounts, instead of a single one.
+//
+// Requests use one token until it is rejected persistently: once with 429
+// Too Many Requests, or twice in a row with 401 Unauthorized. The client then
+// switches to the next token and retries the request with it. A rate-limited
+// token cools down until its limit resets and an unauthorized one for
+// DefaultTokenCooldown. When every token is cooling down, the one that
+// becomes available first is used.
+//
+// The pool takes precedence over OptionTokenProvider and over the token
+// passed to New. Its metrics are available from Client.TokenPoolStats.
+func OptionTokenPool(providers []TokenProvider) ClientOption {
+	return func(c *Client) {
+		if len(providers) == 0 {
+			return
+		}
+		pool := &tokenPool{
+			providers: providers,
+			tokens:    make([]string, len(providers)),
+			stats:     make([]TokenStats, len(providers)),
+			now:       time.Now,
+		}
+		c.tokenPool = pool
+		c.tokenProvider = pool
+	}
+}
+
+// TokenPoolStats returns the metrics of each token configured with
+// OptionTokenPool, in the order of the providers. It returns nil when the
+// client does not use a pool.
+func (c *Client) TokenPoolStats() []TokenStats {
+	if c.tokenPool == nil {
+		return nil
+	}
+	return c.tokenPool.Stats()
+}
+
+// tokenPool is the TokenProvider installed by OptionTokenPool.
+type tokenPool struct {
+	providers []TokenProvider
+	now       func() time.Time
+
+	mu           sync.Mutex
+	current      int
+	unauthorized int      // consecutive 401 responses of the current token
+	tokens       []string // last token returned by each provider
+	stats        []TokenStats
+}
+
+// Token returns a token of the current provider.
+func (p *tokenPool) Token(ctx context.Context) (string, error) {
+	p.mu.Lock()
+	index := p.current
+	p.mu.Unlock()
+
+	token, err := p.providers[index].Token(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	p.mu.Lock()
+	p.tokens[index] = token
+	p.mu.Unlock()
+	return token, nil
+}
+
+// observe records the outcome of a request sent with token and reports
+// whether the pool switched to another token that is not cooling down, in
+// which case the request should be retried.
+func (p *tokenPool) observe(token string, err error) bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	index := -1
+	for i, t := range p.tokens {
+		if t == token {
+			index = i
+			break
+		}
+	}
+	if index < 0 {
+		return false
+	}
+
+	stats := &p.stats[index]
+	stats.Requests++
+
+	var apiErr *APIError
+	errors.As(err, &apiErr)
+	switch {
+	case errors.Is(err, ErrRateLimited):
+		stats.RateLimited++
+		cooldown := DefaultRateLimitWindow
+		if apiErr != nil && apiErr.RetryAfter > 0 {
+			cooldown = apiErr.RetryAfter
+		}
+		return p.rotate(index, cooldown)
+	case errors.Is(err, ErrUnauthorized):
+		stats.Unauthorized++
+		if index != p.current {
+			return false
+		}
+		p.unauthorized++
+		if p.unauthorized < tokenPoolMaxUnauthorized {
+			return false
+		}
+		return p.rotate(index, DefaultTokenCooldown)
+	default:
+		if index == p.current && err == nil {
+			p.unauthorized = 0
+		}
+		return false
+	}
+}
+
+// active returns the index of the current token.
+func (p *tokenPool) active() int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.current
+}
+
+// index returns the index of the provider that last returned token, or -1.
+func (p *tokenPool) index(token string) int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	for i, t := range p.tokens {
+		if t == token {
+			return i
+		}
+	}
+	return -1
+}
+
+// rotate puts the token at index in cooldown and, if it is the current one,
+// switches to the token that is available first.
+func (p *tokenPool) rotate(index int, cooldown time.Duration) bool {
+	now := p.now()
+	p.stats[index].CooldownUntil = now.Add(cooldown)
+	if index != p.current {
+		return false
+	}
+
+	next := index
+	for i := 1; i < len(p.providers); i++ {
+		candidate := (index + i) % len(p.providers)
+		if p.stats[candidate].CooldownUntil.Before(p.stats[next].CooldownUntil) {
+			next = candidate
+		}
+	}
+	if next == index {
+		return false
+	}
+
+	p.stats[index].Rotations++
+	p.current = next
+	p.unauthorized = 0
+	return !p.stats[next].CooldownUntil.After(now)
+}
+
+// Stats returns a snapshot of the metrics.
+func (p *tokenPool) Stats() []TokenStats {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	now := p.now()
+	stats := make([]TokenStats, len(p.stats))
+	copy(stats, p.stats)
+	for i := range stats {
+		stats[i].Active = i == p.current
+		if !stats[i].CooldownUntil.After(now) {
+			stats[i].CooldownUntil = time.Time{}
+		}
+	}
+	return stats
+}
+
+// failover reports the outcome of req to the token pool, if any, and whether
+// the request should be retried with the token the pool switched to.
+func (c *Client) failover(req *http.Request, cfg *requestConfig, err error) bool {
+	if c.tokenPool == nil || !c.tokenPool.observe(requestToken(req), err) {
+		return false
+	}
+	if cfg.noRetry {
+		return false
+	}
+	return req.Body == nil || req.Body == http.NoBody || req.GetBody != nil
+}
+
+// requestToken returns the token that req is authenticated with.
+func requestToken(req *http.Request) string {
+	if token := req.Header.Get("X-ChatWorkToken"); token != "" {
+		return token
+	}
+	return strings.TrimPrefix(req.Header.Get("Authorization"), "Bearer ")
+}
@@ -0,0 +1,118 @@
+package chatwork
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestOptionTokenPool(t *testing.T) {
+	var used []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		token := r.Header.Get("X-ChatWorkToken")
+		used = append(used, token)
+		switch token {
+		case "limited":
+			w.Header().Set("Retry-After", "60")
+			w.WriteHeader(http.StatusTooManyRequests)
+			w.Write([]byte(`{"errors":["Too many requests"]}`))
+		case "revoked":
+			w.WriteHeader(http.StatusUnauthorized)
+			w.Write([]byte(`{"errors":["Invalid API token"]}`))
+		default:
+			w.Write([]byte(`{"account_id": 1}`))
+		}
+	}))
+	defer server.Close()
+
+	client := New("", OptionTokenPool([]TokenProvider{
+		StaticToken("limited"),
+		StaticToken("revoked"),
+		StaticToken("valid"),
+	}))
+	client.BaseURL, _ = url.Parse(server.URL)
+	ctx := context.Background()
+
+	// The rate-limited token fails over to the revoked one, which is only
+	// rotated out after a second 401.
+	if _, _, err := client.Me.Get(ctx); !errors.Is(err, ErrUnauthorized) {
+		t.Fatalf("Expected the first 401 to be returned, got %v", err)
+	}
+	if _, _, err := client.Me.Get(ctx); err != nil {
+		t.Fatalf("Expected the request to fail over to a valid token, got %v", err)
+	}
+	if got := strings.Join(used, ","); got != "limited,revoked,revoked,valid" {
+		t.Errorf("Unexpected tokens used: %s", got)
+	}
+
+	stats := client.TokenPoolStats()
+	if len(stats) != 3 {
+		t.Fatalf("Expected stats for 3 tokens, got %d", len(stats))
+	}
+	if stats[0].RateLimited != 1 || stats[0].Rotations != 1 || stats[0].CooldownUntil.IsZero() {
+		t.Errorf("Unexpected stats for the rate-limited token: %+v", stats[0])
+	}
+	if stats[1].Unauthorized != 2 || stats[1].Rotations != 1 || stats[1].CooldownUntil.IsZero() {
+		t.Errorf("Unexpected stats for the revoked token: %+v", stats[1])
+	}
+	if !stats[2].Active || stats[2].Requests != 1 {
+		t.Errorf("Unexpected stats for the valid token: %+v", stats[2])
+	}
+
+	if New("token").TokenPoolStats() != nil {
+		t.Error("Expected no stats without a pool")
+	}
+}
+
+func TestOptionTokenPool_ContactsCache(t *testing.T) {
+	var limited bool
+	listed := map[string]int{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		token := r.Header.Get("X-ChatWorkToken")
+		if token == "alice" && limited {
+			w.Header().Set("Retry-After", "60")
+			w.WriteHeader(http.StatusTooManyRequests)
+			w.Write([]byte(`{"errors":["Too many requests"]}`))
+			return
+		}
+		if strings.HasSuffix(r.URL.Path, "/contacts") {
+			listed[token]++
+			fmt.Fprintf(w, `[{"account_id": 10, "name": "Contact of %s"}]`, token)
+			return
+		}
+		w.Write([]byte(`{"account_id": 1}`))
+	}))
+	defer server.Close()
+
+	client := New("", OptionContactsCache(time.Hour), OptionTokenPool([]TokenProvider{
+		StaticToken("alice"),
+		StaticToken("bob"),
+	}))
+	client.BaseURL, _ = url.Parse(server.URL)
+	ctx := context.Background()
+
+	if contacts, _, err := client.Contacts.List(ctx); err != nil || contacts[0].Name != "Contact of alice" {
+		t.Fatalf("Expected the contacts of the first token, got %+v, %v", contacts, err)
+	}
+
+	// Once the pool rotates, the contacts of the first token must not be
+	// served for the second one.
+	limited = true
+	if _, _, err := client.Me.Get(ctx); err != nil {
+		t.Fatalf("Expected the request to fail over, got %v", err)
+	}
+	for i := 0; i < 2; i++ {
+		if contacts, _, err := client.Contacts.List(ctx); err != nil || contacts[0].Name != "Contact of bob" {
+			t.Fatalf("Expected the contacts of the second token, got %+v, %v", contacts, err)
+		}
+	}
+	if listed["alice"] != 1 || listed["bob"] != 1 {
+		t.Errorf("Expected the contacts of each token to be listed once, got %v", listed)
+	}
+}
@@ -0,0 +1,85 @@
+package chatwork
+
+import (
+	"net"
+	"net/http"
+	"time"
+)
+
+// TransportSettings configures the http.Transport built by OptionTransport.
+// Zero fields take the documented defaults.
+type TransportSettings struct {
+	// Limit on establishing TCP connections. Defaults to 10 seconds.
+	DialTimeout time.Duration
+
+	// Interval of TCP keep-alive probes. Defaults to 30 seconds.
+	KeepAlive time.Duration
+
+	// Limit on TLS handshakes. Defaults to 10 seconds.
+	TLSHandshakeTimeout time.Duration
+
+	// Limit on waiting for response headers after the request is written.
+	// Defaults to 30 seconds.
+	ResponseHeaderTimeout time.Duration
+
+	// Idle connections kept open to the API. Defaults to 10.
+	MaxIdleConnsPerHost int
+
+	// How long an idle connection is kept open. Defaults to 90 seconds.
+	IdleConnTimeout time.Duration
+}
+
+// OptionTransport sends requests through an http.Transport built from
+// settings, replacing the transport of the HTTP client. Without it, the
+// client uses http.DefaultTransport, which has no limit on waiting for
+// responses and keeps only two idle connections, a poor fit for long-running
+// bots.
+//
+// Give the option after OptionHTTPClient when combining them, as the later
+// option wins. Middlewares wrap the transport built here.
+//
+// Example:
+//
+//	client := chatwork.New("token",
+//		chatwork.OptionTransport(chatwork.TransportSettings{DialTimeout: 5 * time.Second}),
+//		chatwork.OptionDefaultTimeout(30*time.Second),
+//	)
+func OptionTransport(settings TransportSettings) ClientOption {
+	return func(c *Client) {
+		httpClient := *c.client
+		httpClient.Transport = settings.transport()
+		c.client = &httpClient
+	}
+}
+
+// transport builds the http.Transport described by s.
+func (s TransportSettings) transport() *http.Transport {
+	dialer := &net.Dialer{
+		Timeout:   orDefault(s.DialTimeout, 10*time.Second),
+		KeepAlive: orDefault(s.KeepAlive, 30*time.Second),
+	}
+	maxIdle := s.MaxIdleConnsPerHost
+	if maxIdle <= 0 {
+		maxIdle = 10
+	}
+
+	return &http.Transport{
+		Proxy:                 http.ProxyFromEnvironment,
+		DialContext:           dialer.DialContext,
+		ForceAttemptHTTP2:     true,
+		TLSHandshakeTimeout:   orDefault(s.TLSHandshakeTimeout, 10*time.Second),
+		ResponseHeaderTimeout: orDefault(s.ResponseHeaderTimeout, 30*time.Second),
+		MaxIdleConns:          maxIdle,
+		MaxIdleConnsPerHost:   maxIdle,
+		IdleConnTimeout:       orDefault(s.IdleConnTimeout, 90*time.Second),
+		ExpectContinueTimeout: time.Second,
+	}
+}
+
+// orDefault returns d, or def if d is not positive.
+func orDefault(d, def time.Duration) time.Duration {
+	if d <= 0 {
+		return def
+	}
+	return d
+}
@@ -0,0 +1,147 @@
+package chatwork
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// defaultUnreadMonitorInterval is the default time between polls of an UnreadMonitor.
+const defaultUnreadMonitorInterval = 30 * time.Second
+
+// UnreadThreshold selects the counts at which an UnreadMonitor reports a
+// room. An increase is reported once the count reaches the threshold; zero
+// reports every increase and a negative value none.
+type UnreadThreshold struct {
+	Unread   int
+	Mentions int
+}
+
+// UnreadEvent reports that the unread or mention count of a room increased.
+type UnreadEvent struct {
+	// The room as listed, with its current counts
+	Room *Room
+
+	// Increase of each count since the previous poll
+	UnreadIncrease  int
+	MentionIncrease int
+}
+
+// UnreadMonitor periodically lists the rooms of the authenticated account
+// and reports rooms whose unread or mention counts increased, for building
+// notifiers and dashboards.
+//
+// The first poll only records the current counts; events are reported for
+// increases after it.
+type UnreadMonitor struct {
+	client *Client
+
+	// Time between polls in Run. Defaults to 30 seconds.
+	Interval time.Duration
+
+	// Threshold of each room, by room ID. Rooms without one use Default.
+	Thresholds map[RoomID]UnreadThreshold
+	Default    UnreadThreshold
+
+	// Called with the events of every poll in Run
+	OnEvent func(UnreadEvent)
+
+	// Called with errors from polls in Run. If nil, Run returns the first error.
+	OnError func(error)
+
+	mu     sync.Mutex
+	counts map[RoomID]unreadCounts // counts of each room at the previous poll
+}
+
+type unreadCounts struct {
+	unread   int
+	mentions int
+}
+
+// NewUnreadMonitor returns an UnreadMonitor that calls onEvent for every
+// reported room.
+func NewUnreadMonitor(client *Client, onEvent func(UnreadEvent)) *UnreadMonitor {
+	return &UnreadMonitor{
+		client:   client,
+		Interval: defaultUnreadMonitorInterval,
+		OnEvent:  onEvent,
+	}
+}
+
+// Run polls every Interval until ctx is done.
+func (m *UnreadMonitor) Run(ctx context.Context, opts ...RequestOption) error {
+	interval := m.Interval
+	if interval <= 0 {
+		interval = defaultUnreadMonitorInterval
+	}
+
+	for {
+		events, err := m.Poll(ctx, opts...)
+		if err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			if m.OnError == nil {
+				return err
+			}
+			m.OnError(err)
+		}
+		if m.OnEvent != nil {
+			for _, event := range events {
+				m.OnEvent(event)
+			}
+		}
+		if err := m.client.sleep(ctx, interval); err != nil {
+			return err
+		}
+	}
+}
+
+// Poll lists the rooms once and returns the events for increases since the
+// previous poll.
+func (m *UnreadMonitor) Poll(ctx context.Context, opts ...RequestOption) ([]UnreadEvent, error) {
+	rooms, _, err := m.client.Rooms.List(ctx, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	first := m.counts == nil
+	counts := make(map[RoomID]unreadCounts, len(rooms))
+	var events []UnreadEvent
+	for _, room := range rooms {
+		counts[room.RoomID] = unreadCounts{unread: room.UnreadNum, mentions: room.MentionNum}
+		if first {
+			continue
+		}
+
+		// Rooms joined since the previous poll are compared against zero.
+		previous := m.counts[room.RoomID]
+		threshold, ok := m.Thresholds[room.RoomID]
+		if !ok {
+			threshold = m.Default
+		}
+
+		event := UnreadEvent{Room: room}
+		if reached(room.UnreadNum, previous.unread, threshold.Unread) {
+			event.UnreadIncrease = room.UnreadNum - previous.unread
+		}
+		if reached(room.MentionNum, previous.mentions, threshold.Mentions) {
+			event.MentionIncrease = room.MentionNum - previous.mentions
+		}
+		if event.UnreadIncrease > 0 || event.MentionIncrease > 0 {
+			events = append(events, event)
+		}
+	}
+	m.counts = counts
+
+	return events, nil
+}
+
+// reached reports whether a count increased from previous and is at or
+// above threshold.
+func reached(count, previous, threshold int) bool {
+	return threshold >= 0 && count > previous && count >= threshold
+}
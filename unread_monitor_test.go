@@ -0,0 +1,58 @@
+package chatwork
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"testing"
+)
+
+func TestUnreadMonitor_Poll(t *testing.T) {
+	responses := []string{
+		`[{"room_id":1,"unread_num":1,"mention_num":0},{"room_id":2,"unread_num":0,"mention_num":0}]`,
+		`[{"room_id":1,"unread_num":3,"mention_num":1},{"room_id":2,"unread_num":2,"mention_num":0},{"room_id":3,"unread_num":1,"mention_num":0}]`,
+		`[{"room_id":1,"unread_num":0,"mention_num":0},{"room_id":2,"unread_num":6,"mention_num":0},{"room_id":3,"unread_num":1,"mention_num":0}]`,
+	}
+	poll := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(responses[poll]))
+		poll++
+	}))
+	defer server.Close()
+
+	client := New(testToken)
+	client.BaseURL, _ = url.Parse(server.URL)
+
+	monitor := NewUnreadMonitor(client, nil)
+	monitor.Thresholds = map[RoomID]UnreadThreshold{2: {Unread: 5}, 3: {Unread: -1}}
+
+	type increase struct {
+		room             RoomID
+		unread, mentions int
+	}
+	summarize := func(events []UnreadEvent) []increase {
+		var got []increase
+		for _, e := range events {
+			got = append(got, increase{e.Room.RoomID, e.UnreadIncrease, e.MentionIncrease})
+		}
+		return got
+	}
+
+	ctx := context.Background()
+	want := [][]increase{
+		nil,
+		{{1, 2, 1}},
+		{{2, 4, 0}},
+	}
+	for i, w := range want {
+		events, err := monitor.Poll(ctx)
+		if err != nil {
+			t.Fatalf("Poll %d returned error: %v", i+1, err)
+		}
+		if got := summarize(events); !reflect.DeepEqual(got, w) {
+			t.Errorf("Poll %d: expected %v, got %v", i+1, w, got)
+		}
+	}
+}
@@ -0,0 +1,251 @@
+package chatwork
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+)
+
+// DefaultWatchInterval is the time between polls of Client.Watch. At one
+// request per interval, a few dozen rooms can be watched within the API's
+// rate limit.
+const DefaultWatchInterval = 10 * time.Second
+
+const (
+	// defaultWatchBudget is the default share of the rate limit used by the
+	// adaptive watchers of a client.
+	defaultWatchBudget = 0.5
+
+	// unreadRefreshInterval is the time between listings of the rooms, for
+	// their unread counts, by adaptive watchers that have backed off.
+	unreadRefreshInterval = 30 * time.Second
+)
+
+// WatchOptions configures Client.Watch.
+type WatchOptions struct {
+	// Time between polls. Defaults to DefaultWatchInterval. With adaptive
+	// polling, the time between polls of an active room.
+	Interval time.Duration
+
+	// Longest time between polls. If greater than Interval, polling adapts
+	// to the activity of the room: the time between polls doubles after
+	// every poll without new messages, up to MaxInterval, and returns to
+	// Interval when new messages are seen or the room's unread count
+	// increases.
+	MaxInterval time.Duration
+
+	// Share of the rate limit, between 0 and 1, that the adaptive watchers
+	// of a client may use together. Defaults to 0.5. The rate limit is the
+	// one set with OptionRateLimit, or ChatWork's limit without it.
+	RateBudget float64
+
+	// Emit the messages newer than this message ID. By default, watching
+	// starts with the messages posted after the first poll.
+	AfterMessageID MessageID
+
+	// Called with errors from polls (optional). Watching continues after
+	// errors, so that transient failures do not end it.
+	OnError func(error)
+
+	// Options applied to every request
+	RequestOptions []RequestOption
+}
+
+// Watch polls a room and sends the messages posted to it on the returned
+// channel, oldest first, for applications that cannot receive webhooks.
+//
+// Each message is sent once. Polling lists the latest messages with Force,
+// so unlike MessagesService.List without it, Watch does not consume the
+// unread state shared by other users of the same token. If more than 100
+// messages are posted between two polls, the older ones are not seen.
+//
+// The channel is closed when ctx is done.
+func (c *Client) Watch(ctx context.Context, roomID RoomID, opts *WatchOptions) <-chan *Message {
+	if opts == nil {
+		opts = &WatchOptions{}
+	}
+	interval := opts.Interval
+	if interval <= 0 {
+		interval = DefaultWatchInterval
+	}
+
+	adaptive := opts.MaxInterval > interval
+	watches := c.watches
+	if watches == nil {
+		watches = &watchCoordinator{}
+	}
+
+	ch := make(chan *Message)
+	go func() {
+		defer close(ch)
+
+		if adaptive {
+			watches.add(1)
+			defer watches.add(-1)
+		}
+
+		last := opts.AfterMessageID
+		started := last != ""
+		wait := interval
+		unread := -1
+		for {
+			seen := last
+			messages, _, err := c.Messages.List(ctx, roomID, &MessageListParams{Force: 1}, opts.RequestOptions...)
+			switch {
+			case ctx.Err() != nil:
+				return
+			case err != nil:
+				if opts.OnError != nil {
+					opts.OnError(err)
+				}
+			default:
+				sort.SliceStable(messages, func(i, j int) bool {
+					return compareMessageIDs(messages[i].MessageID, messages[j].MessageID) < 0
+				})
+				for _, m := range messages {
+					if compareMessageIDs(m.MessageID, last) <= 0 {
+						continue
+					}
+					last = m.MessageID
+					if !started {
+						continue
+					}
+					select {
+					case ch <- m:
+					case <-ctx.Done():
+						return
+					}
+				}
+				started = true
+			}
+
+			if !adaptive {
+				if err := c.sleep(ctx, interval); err != nil {
+					return
+				}
+				continue
+			}
+			wait = nextWatchInterval(wait, interval, opts.MaxInterval, last != seen)
+			if err := c.waitForActivity(ctx, watches, roomID, wait, opts, &unread); err != nil {
+				return
+			}
+		}
+	}()
+	return ch
+}
+
+// nextWatchInterval returns the time until the next poll of an adaptive
+// watcher that waited wait before the previous poll.
+func nextWatchInterval(wait, interval, maxInterval time.Duration, active bool) time.Duration {
+	if active {
+		return interval
+	}
+	wait *= 2
+	if wait > maxInterval {
+		wait = maxInterval
+	}
+	return wait
+}
+
+// waitForActivity sleeps for wait, but no shorter than the share of the rate
+// budget of each adaptive watcher. Watchers that back off beyond
+// unreadRefreshInterval wake up early when the unread count of the room,
+// last seen as *unread, increases.
+func (c *Client) waitForActivity(ctx context.Context, watches *watchCoordinator, roomID RoomID, wait time.Duration, opts *WatchOptions, unread *int) error {
+	if floor := watches.floor(c.limiter, opts.RateBudget); wait < floor {
+		wait = floor
+	}
+
+	deadline := c.now().Add(wait)
+	for {
+		remaining := deadline.Sub(c.now())
+		if remaining <= 0 {
+			return nil
+		}
+		if remaining > unreadRefreshInterval {
+			remaining = unreadRefreshInterval
+		}
+		if err := c.sleep(ctx, remaining); err != nil {
+			return err
+		}
+		if !deadline.After(c.now()) {
+			return nil
+		}
+
+		count, ok, err := watches.unreadCount(ctx, c, roomID, opts.RequestOptions)
+		if err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			if opts.OnError != nil {
+				opts.OnError(err)
+			}
+			continue
+		}
+		if ok {
+			increased := *unread >= 0 && count > *unread
+			*unread = count
+			if increased {
+				return nil
+			}
+		}
+	}
+}
+
+// watchCoordinator is shared by the watchers of a client, and of the clients
+// derived from it, to divide the rate budget between the adaptive watchers
+// and to list the rooms for their unread counts once for all of them.
+type watchCoordinator struct {
+	mu       sync.Mutex
+	watchers int // running adaptive watchers
+
+	listMu sync.Mutex
+	listed time.Time
+	unread map[RoomID]int // unread count of each room at listed
+}
+
+func (w *watchCoordinator) add(n int) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.watchers += n
+}
+
+// floor returns the shortest time between polls that keeps the adaptive
+// watchers within budget, a share of the client's rate limit.
+func (w *watchCoordinator) floor(limiter *rateLimiter, budget float64) time.Duration {
+	if budget <= 0 || budget > 1 {
+		budget = defaultWatchBudget
+	}
+	perRequest := DefaultRateLimitWindow / DefaultRateLimitRequests
+	if limiter != nil {
+		perRequest = limiter.interval()
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return time.Duration(float64(perRequest) * float64(w.watchers) / budget)
+}
+
+// unreadCount returns the unread count of a room, listing the rooms if the
+// previous listing is older than unreadRefreshInterval. It reports false if
+// the room was not listed.
+func (w *watchCoordinator) unreadCount(ctx context.Context, c *Client, roomID RoomID, opts []RequestOption) (int, bool, error) {
+	w.listMu.Lock()
+	defer w.listMu.Unlock()
+
+	if c.now().Sub(w.listed) >= unreadRefreshInterval {
+		rooms, _, err := c.Rooms.List(ctx, opts...)
+		if err != nil {
+			return 0, false, err
+		}
+		w.unread = make(map[RoomID]int, len(rooms))
+		for _, room := range rooms {
+			w.unread[room.RoomID] = room.UnreadNum
+		}
+		w.listed = c.now()
+	}
+
+	count, ok := w.unread[roomID]
+	return count, ok, nil
+}
@@ -0,0 +1,146 @@
+package chatwork_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/nashirox/chatwork-go"
+	"github.com/nashirox/chatwork-go/chatworktest"
+)
+
+func TestClient_Watch(t *testing.T) {
+	srv := chatworktest.NewServer()
+	defer srv.Close()
+
+	roomID := srv.AddRoom(chatwork.Room{Name: "General"})
+	srv.AddMessage(roomID, chatwork.Message{Body: "before"})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	polled := make(chan struct{}, 1)
+	client := srv.Client(chatwork.OptionOnResponse(func(*chatwork.Response, error) {
+		select {
+		case polled <- struct{}{}:
+		default:
+		}
+	}))
+	messages := client.Watch(ctx, roomID, &chatwork.WatchOptions{Interval: 10 * time.Millisecond})
+
+	// Messages posted before the first poll are not emitted.
+	<-polled
+	srv.AddMessage(roomID, chatwork.Message{Body: "first"})
+	srv.AddMessage(roomID, chatwork.Message{Body: "second"})
+
+	for _, want := range []string{"first", "second"} {
+		select {
+		case m := <-messages:
+			if m.Body != want {
+				t.Errorf("Expected %q, got %q", want, m.Body)
+			}
+		case <-time.After(time.Second):
+			t.Fatalf("Timed out waiting for %q", want)
+		}
+	}
+
+	cancel()
+	for range messages {
+	}
+}
+
+func TestClient_WatchShard(t *testing.T) {
+	srv := chatworktest.NewServer()
+	defer srv.Close()
+
+	var roomIDs []chatwork.RoomID
+	for i := 0; i < 10; i++ {
+		roomIDs = append(roomIDs, srv.AddRoom(chatwork.Room{Name: "Room"}))
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	ring := chatwork.NewHashRing("a")
+	client := srv.Client()
+	messages := client.WatchShard(ctx, ring, "a", roomIDs, &chatwork.WatchOptions{Interval: 5 * time.Millisecond})
+
+	// Hand some rooms to another node and wait for the watchers to settle.
+	ring.Add("b")
+	owned := ring.Rooms("a", roomIDs)
+	if len(owned) == 0 || len(owned) == len(roomIDs) {
+		t.Fatalf("Expected the rooms to be split, got %d of %d", len(owned), len(roomIDs))
+	}
+	time.Sleep(50 * time.Millisecond)
+
+	for _, roomID := range roomIDs {
+		srv.AddMessage(roomID, chatwork.Message{Body: "hello"})
+	}
+
+	received := make(map[chatwork.RoomID]bool)
+	timeout := time.After(time.Second)
+	for len(received) < len(owned) {
+		select {
+		case m := <-messages:
+			if ring.Owner(m.RoomID) != "a" {
+				t.Errorf("Received a message from room %d owned by %s", m.RoomID, ring.Owner(m.RoomID))
+			}
+			received[m.RoomID] = true
+		case <-timeout:
+			t.Fatalf("Timed out with messages from %d of %d rooms", len(received), len(owned))
+		}
+	}
+
+	cancel()
+	for range messages {
+	}
+}
+
+func TestClient_Watch_adaptive(t *testing.T) {
+	srv := chatworktest.NewServer()
+	defer srv.Close()
+
+	roomID := srv.AddRoom(chatwork.Room{Name: "General"})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var mu sync.Mutex
+	polls := 0
+	client := srv.Client(
+		chatwork.OptionRateLimit(1000, time.Second),
+		chatwork.OptionOnResponse(func(*chatwork.Response, error) {
+			mu.Lock()
+			polls++
+			mu.Unlock()
+		}),
+	)
+	messages := client.Watch(ctx, roomID, &chatwork.WatchOptions{
+		Interval:    5 * time.Millisecond,
+		MaxInterval: 80 * time.Millisecond,
+	})
+
+	// An idle room backs off to MaxInterval.
+	time.Sleep(400 * time.Millisecond)
+	mu.Lock()
+	idle := polls
+	mu.Unlock()
+	if idle > 15 {
+		t.Errorf("Expected polling of an idle room to back off, got %d polls", idle)
+	}
+
+	srv.AddMessage(roomID, chatwork.Message{Body: "wake up"})
+	select {
+	case m := <-messages:
+		if m.Body != "wake up" {
+			t.Errorf("Expected %q, got %q", "wake up", m.Body)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Timed out waiting for the message")
+	}
+
+	cancel()
+	for range messages {
+	}
+}
@@ -0,0 +1,164 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+)
+
+// maxPayloadSize limits the size of request bodies accepted by a Handler.
+const maxPayloadSize = 1 << 20
+
+// ErrMalformedEvent is returned for events that cannot be decoded. Callbacks
+// that decode events themselves can wrap it, so that the Handler answers 400
+// rather than 500 and ChatWork does not retry the delivery.
+var ErrMalformedEvent = errors.New("webhook: malformed event")
+
+// Handler is an http.Handler that receives ChatWork webhooks and dispatches
+// them to the registered callbacks.
+//
+// It answers 405 to methods other than POST, 401 to requests with a missing
+// or invalid signature, 400 to malformed payloads and events, and 500 when a
+// callback returns another error, so that ChatWork can report failed
+// deliveries. Events
+// without a callback are acknowledged with 200.
+//
+// Callbacks may be registered at any time; a Handler is safe for concurrent use.
+type Handler struct {
+	secret string
+
+	mu               sync.RWMutex
+	onEvent          func(context.Context, *Payload) error
+	onMessageCreated func(context.Context, *MessageEvent) error
+	onMessageUpdated func(context.Context, *MessageEvent) error
+	onMentionToMe    func(context.Context, *MentionEvent) error
+}
+
+// NewHandler returns a Handler that verifies requests with the webhook token secret.
+func NewHandler(secret string) *Handler {
+	return &Handler{secret: secret}
+}
+
+// OnEvent registers a callback for every event, including those of types
+// this package does not know. It runs before the event type's own callback.
+func (h *Handler) OnEvent(f func(ctx context.Context, p *Payload) error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.onEvent = f
+}
+
+// OnMessageCreated registers the callback for message_created events.
+func (h *Handler) OnMessageCreated(f func(ctx context.Context, e *MessageEvent) error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.onMessageCreated = f
+}
+
+// OnMessageUpdated registers the callback for message_updated events.
+func (h *Handler) OnMessageUpdated(f func(ctx context.Context, e *MessageEvent) error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.onMessageUpdated = f
+}
+
+// OnMentionToMe registers the callback for mention_to_me events.
+func (h *Handler) OnMentionToMe(f func(ctx context.Context, e *MentionEvent) error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.onMentionToMe = f
+}
+
+// ServeHTTP implements http.Handler.
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		return
+	}
+
+	body, err := io.ReadAll(io.LimitReader(r.Body, maxPayloadSize+1))
+	if err != nil {
+		http.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest)
+		return
+	}
+	if len(body) > maxPayloadSize {
+		http.Error(w, http.StatusText(http.StatusRequestEntityTooLarge), http.StatusRequestEntityTooLarge)
+		return
+	}
+
+	signature := r.Header.Get(SignatureHeader)
+	if signature == "" {
+		signature = r.URL.Query().Get(SignatureParam)
+	}
+	if err := VerifySignature(h.secret, body, signature); err != nil {
+		if errors.Is(err, ErrInvalidSignature) {
+			http.Error(w, http.StatusText(http.StatusUnauthorized), http.StatusUnauthorized)
+		} else {
+			http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		}
+		return
+	}
+
+	payload := new(Payload)
+	if err := json.Unmarshal(body, payload); err != nil {
+		http.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest)
+		return
+	}
+
+	if err := h.dispatch(r.Context(), payload); err != nil {
+		if errors.Is(err, ErrMalformedEvent) {
+			http.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest)
+			return
+		}
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
+
+// dispatch decodes the event of p and calls the callbacks registered for it.
+func (h *Handler) dispatch(ctx context.Context, p *Payload) error {
+	h.mu.RLock()
+	onEvent := h.onEvent
+	onMessageCreated := h.onMessageCreated
+	onMessageUpdated := h.onMessageUpdated
+	onMentionToMe := h.onMentionToMe
+	h.mu.RUnlock()
+
+	if onEvent != nil {
+		if err := onEvent(ctx, p); err != nil {
+			return err
+		}
+	}
+
+	switch p.EventType {
+	case EventMessageCreated, EventMessageUpdated:
+		f := onMessageCreated
+		if p.EventType == EventMessageUpdated {
+			f = onMessageUpdated
+		}
+		if f == nil {
+			return nil
+		}
+		event := new(MessageEvent)
+		if err := json.Unmarshal(p.Event, event); err != nil {
+			return fmt.Errorf("%w: %v", ErrMalformedEvent, err)
+		}
+		return f(ctx, event)
+	case EventMentionToMe:
+		if onMentionToMe == nil {
+			return nil
+		}
+		event := new(MentionEvent)
+		if err := json.Unmarshal(p.Event, event); err != nil {
+			return fmt.Errorf("%w: %v", ErrMalformedEvent, err)
+		}
+		return onMentionToMe(ctx, event)
+	}
+	return nil
+}
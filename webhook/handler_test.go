@@ -0,0 +1,113 @@
+package webhook
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/nashirox/chatwork-go"
+)
+
+const testSecret = "c2VjcmV0LXdlYmhvb2stdG9rZW4="
+
+func signedRequest(t *testing.T, body string) *http.Request {
+	t.Helper()
+	signature, err := Sign(testSecret, []byte(body))
+	if err != nil {
+		t.Fatal(err)
+	}
+	req := httptest.NewRequest(http.MethodPost, "/webhook", strings.NewReader(body))
+	req.Header.Set(SignatureHeader, signature)
+	return req
+}
+
+func TestHandler(t *testing.T) {
+	handler := NewHandler(testSecret)
+
+	var mention *MentionEvent
+	var types []string
+	handler.OnEvent(func(ctx context.Context, p *Payload) error {
+		types = append(types, p.EventType)
+		if p.EventType == "room_created" {
+			return fmt.Errorf("%w: unexpected array", ErrMalformedEvent)
+		}
+		return nil
+	})
+	handler.OnMentionToMe(func(ctx context.Context, e *MentionEvent) error {
+		mention = e
+		return nil
+	})
+	handler.OnMessageCreated(func(ctx context.Context, e *MessageEvent) error {
+		return errors.New("downstream failure")
+	})
+
+	mentionBody := `{"webhook_setting_id":"1","webhook_event_type":"mention_to_me","webhook_event_time":1498028130,` +
+		`"webhook_event":{"from_account_id":123,"to_account_id":456,"room_id":789,"message_id":"1001","body":"[To:456] hi","send_time":1498028125}}`
+
+	tests := []struct {
+		name string
+		req  *http.Request
+		want int
+	}{
+		{"mention", signedRequest(t, mentionBody), http.StatusOK},
+		{"unhandled type", signedRequest(t, `{"webhook_event_type":"message_updated","webhook_event":{}}`), http.StatusOK},
+		{"callback error", signedRequest(t, `{"webhook_event_type":"message_created","webhook_event":{}}`), http.StatusInternalServerError},
+		{"malformed event", signedRequest(t, `{"webhook_event_type":"mention_to_me","webhook_event":[]}`), http.StatusBadRequest},
+		{"malformed event callback", signedRequest(t, `{"webhook_event_type":"room_created","webhook_event":[]}`), http.StatusBadRequest},
+		{"method", httptest.NewRequest(http.MethodGet, "/webhook", nil), http.StatusMethodNotAllowed},
+		{"missing signature", httptest.NewRequest(http.MethodPost, "/webhook", strings.NewReader(mentionBody)), http.StatusUnauthorized},
+	}
+	for _, tt := range tests {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, tt.req)
+		if rec.Code != tt.want {
+			t.Errorf("%s: expected status %d, got %d", tt.name, tt.want, rec.Code)
+		}
+	}
+
+	if mention == nil || mention.RoomID != 789 || mention.MessageID != "1001" || mention.FromAccountID != 123 || mention.SendTime.Time().Unix() != 1498028125 {
+		t.Errorf("Unexpected mention event: %+v", mention)
+	}
+	if strings.Join(types, ",") != "mention_to_me,message_updated,message_created,mention_to_me,room_created" {
+		t.Errorf("Unexpected events: %v", types)
+	}
+}
+
+func TestHandler_QuerySignature(t *testing.T) {
+	body := `{"webhook_event_type":"message_created","webhook_event":{"room_id":1}}`
+	signature, _ := Sign(testSecret, []byte(body))
+	req := httptest.NewRequest(http.MethodPost, "/webhook?"+SignatureParam+"="+url.QueryEscape(signature), strings.NewReader(body))
+
+	var roomID chatwork.RoomID
+	handler := NewHandler(testSecret)
+	handler.OnMessageCreated(func(ctx context.Context, e *MessageEvent) error {
+		roomID = e.RoomID
+		return nil
+	})
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	if rec.Code != http.StatusOK || roomID != 1 {
+		t.Errorf("Expected the room webhook to be accepted, got status %d and room %d", rec.Code, roomID)
+	}
+}
+
+func TestVerifySignature(t *testing.T) {
+	body := []byte(`{}`)
+	signature, _ := Sign(testSecret, body)
+
+	if err := VerifySignature(testSecret, body, signature); err != nil {
+		t.Errorf("Expected a valid signature, got %v", err)
+	}
+	if err := VerifySignature(testSecret, []byte(`{"x":1}`), signature); !errors.Is(err, ErrInvalidSignature) {
+		t.Errorf("Expected ErrInvalidSignature for a modified body, got %v", err)
+	}
+	if err := VerifySignature("not base64!", body, signature); err == nil || errors.Is(err, ErrInvalidSignature) {
+		t.Errorf("Expected a token error, got %v", err)
+	}
+}
@@ -0,0 +1,109 @@
+// Package webhook receives ChatWork webhooks.
+//
+// A Handler verifies the signature of each request, decodes the payload, and
+// dispatches it to the callback registered for its event type:
+//
+//	handler := webhook.NewHandler(os.Getenv("CHATWORK_WEBHOOK_TOKEN"))
+//	handler.OnMentionToMe(func(ctx context.Context, e *webhook.MentionEvent) error {
+//		_, _, err := client.Messages.Reply(ctx, e.RoomID, e.MessageID, "On it!")
+//		return err
+//	})
+//
+//	http.Handle("/chatwork", handler)
+//
+// The secret is the webhook token shown by ChatWork when the webhook is
+// registered.
+package webhook
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/nashirox/chatwork-go"
+)
+
+// Event types sent by ChatWork.
+const (
+	EventMessageCreated = "message_created"
+	EventMessageUpdated = "message_updated"
+	EventMentionToMe    = "mention_to_me"
+)
+
+// Where ChatWork sends the request signature: account webhooks use the
+// header and room webhooks the query parameter.
+const (
+	SignatureHeader = "X-ChatWorkWebhookSignature"
+	SignatureParam  = "chatwork_webhook_signature"
+)
+
+// ErrInvalidSignature is returned by VerifySignature when a request was not
+// signed with the webhook token.
+var ErrInvalidSignature = errors.New("webhook: invalid signature")
+
+// Payload is the body of a webhook request.
+type Payload struct {
+	SettingID string             `json:"webhook_setting_id"`
+	EventType string             `json:"webhook_event_type"`
+	EventTime chatwork.Timestamp `json:"webhook_event_time"`
+
+	// Event details, to be decoded according to EventType
+	Event json.RawMessage `json:"webhook_event"`
+}
+
+// MessageEvent is the event of message_created and message_updated webhooks.
+type MessageEvent struct {
+	MessageID  chatwork.MessageID `json:"message_id"`
+	RoomID     chatwork.RoomID    `json:"room_id"`
+	AccountID  chatwork.AccountID `json:"account_id"`
+	Body       string             `json:"body"`
+	SendTime   chatwork.Timestamp `json:"send_time"`
+	UpdateTime chatwork.Timestamp `json:"update_time"`
+}
+
+// MentionEvent is the event of mention_to_me webhooks.
+type MentionEvent struct {
+	FromAccountID chatwork.AccountID `json:"from_account_id"`
+	ToAccountID   chatwork.AccountID `json:"to_account_id"`
+	RoomID        chatwork.RoomID    `json:"room_id"`
+	MessageID     chatwork.MessageID `json:"message_id"`
+	Body          string             `json:"body"`
+	SendTime      chatwork.Timestamp `json:"send_time"`
+	UpdateTime    chatwork.Timestamp `json:"update_time"`
+}
+
+// VerifySignature checks that signature is the base64 encoded HMAC-SHA256 of
+// body, keyed with the base64 decoded webhook token secret.
+func VerifySignature(secret string, body []byte, signature string) error {
+	key, err := base64.StdEncoding.DecodeString(secret)
+	if err != nil {
+		return fmt.Errorf("webhook: invalid token: %w", err)
+	}
+	got, err := base64.StdEncoding.DecodeString(signature)
+	if err != nil {
+		return ErrInvalidSignature
+	}
+
+	mac := hmac.New(sha256.New, key)
+	mac.Write(body)
+	if !hmac.Equal(got, mac.Sum(nil)) {
+		return ErrInvalidSignature
+	}
+	return nil
+}
+
+// Sign returns the signature ChatWork sends for body. It is useful for
+// testing handlers.
+func Sign(secret string, body []byte) (string, error) {
+	key, err := base64.StdEncoding.DecodeString(secret)
+	if err != nil {
+		return "", fmt.Errorf("webhook: invalid token: %w", err)
+	}
+
+	mac := hmac.New(sha256.New, key)
+	mac.Write(body)
+	return base64.StdEncoding.EncodeToString(mac.Sum(nil)), nil
+}
@@ -0,0 +1,281 @@
+package chatwork
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"slices"
+)
+
+// WorkspaceState describes the desired state of group chat rooms, for
+// managing a workspace declaratively, like infrastructure as code:
+//
+//	{
+//		"rooms": [
+//			{
+//				"name": "Incidents",
+//				"description": "On-call coordination",
+//				"admins": [100],
+//				"members": [200, 300]
+//			}
+//		]
+//	}
+type WorkspaceState struct {
+	Rooms []*RoomState `json:"rooms"`
+}
+
+// RoomState is the desired state of a room.
+//
+// A room is identified by RoomID, or else by its name among the group chats
+// of the authenticated account; a room that is not found is created.
+// IconPreset is only set on creation, since the API does not report it.
+//
+// Empty fields are left unmanaged: an empty description is not cleared, and
+// when all three member lists are empty, the members are not changed.
+// Otherwise the lists are the complete membership of the room, so the
+// authenticated account must be listed among the admins to keep managing it.
+type RoomState struct {
+	RoomID      RoomID      `json:"room_id,omitempty"`
+	Name        string      `json:"name"`
+	Description string      `json:"description,omitempty"`
+	IconPreset  IconPreset  `json:"icon_preset,omitempty"`
+	AdminIDs    []AccountID `json:"admins,omitempty"`
+	MemberIDs   []AccountID `json:"members,omitempty"`
+	ReadonlyIDs []AccountID `json:"readonly,omitempty"`
+}
+
+// ReadWorkspaceState reads a WorkspaceState from a JSON file.
+func ReadWorkspaceState(path string) (*WorkspaceState, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var state WorkspaceState
+	if err := json.Unmarshal(data, &state); err != nil {
+		return nil, fmt.Errorf("chatwork: invalid workspace state %s: %w", path, err)
+	}
+	return &state, nil
+}
+
+// WorkspaceActionType identifies what a WorkspaceAction does.
+type WorkspaceActionType string
+
+// Types of workspace actions.
+const (
+	WorkspaceActionCreate  WorkspaceActionType = "create"
+	WorkspaceActionUpdate  WorkspaceActionType = "update"
+	WorkspaceActionMembers WorkspaceActionType = "members"
+)
+
+// WorkspaceAction is a change that brings a room to its desired state.
+type WorkspaceAction struct {
+	Type WorkspaceActionType `json:"type"`
+
+	// Room to change, or zero until a created room exists
+	RoomID RoomID `json:"room_id,omitempty"`
+	Name   string `json:"name"`
+
+	// Properties to set by create and update actions; empty ones are kept
+	Description string     `json:"description,omitempty"`
+	IconPreset  IconPreset `json:"icon_preset,omitempty"` // create only
+
+	// Complete membership set by create and members actions
+	AdminIDs    []AccountID `json:"admins,omitempty"`
+	MemberIDs   []AccountID `json:"members,omitempty"`
+	ReadonlyIDs []AccountID `json:"readonly,omitempty"`
+
+	// Changes made by a members action, for review
+	Added       []AccountID `json:"added,omitempty"`
+	Removed     []AccountID `json:"removed,omitempty"`
+	RoleChanged []AccountID `json:"role_changed,omitempty"`
+}
+
+// WorkspacePlan lists the actions that bring a workspace to its desired
+// state, in the order Apply executes them. An empty plan means the
+// workspace is up to date.
+type WorkspacePlan struct {
+	Actions []*WorkspaceAction `json:"actions"`
+}
+
+// Workspace computes and applies the changes between a WorkspaceState and
+// the rooms of the authenticated account.
+//
+// Plan only reads, so its result can be reviewed (and stored as JSON) before
+// Apply makes the changes.
+type Workspace struct {
+	client *Client
+
+	// Called after every applied action (optional)
+	OnAction func(*WorkspaceAction)
+}
+
+// NewWorkspace returns a Workspace managing the rooms of client's account.
+func NewWorkspace(client *Client) *Workspace {
+	return &Workspace{client: client}
+}
+
+// Plan compares state with the current rooms and returns the actions that
+// bring them in line.
+func (w *Workspace) Plan(ctx context.Context, state *WorkspaceState, opts ...RequestOption) (*WorkspacePlan, error) {
+	rooms, _, err := w.client.Rooms.List(ctx, opts...)
+	if err != nil {
+		return nil, err
+	}
+	byID := make(map[RoomID]*Room, len(rooms))
+	byName := make(map[string]*Room)
+	for _, room := range rooms {
+		byID[room.RoomID] = room
+		if room.Type == RoomTypeGroup {
+			byName[room.Name] = room
+		}
+	}
+
+	plan := &WorkspacePlan{Actions: []*WorkspaceAction{}}
+	for _, desired := range state.Rooms {
+		room := byName[desired.Name]
+		if desired.RoomID != 0 {
+			if room = byID[desired.RoomID]; room == nil {
+				return nil, fmt.Errorf("chatwork: room %d of the workspace state is not accessible", desired.RoomID)
+			}
+		}
+
+		if room == nil {
+			plan.Actions = append(plan.Actions, &WorkspaceAction{
+				Type:        WorkspaceActionCreate,
+				Name:        desired.Name,
+				Description: desired.Description,
+				IconPreset:  desired.IconPreset,
+				AdminIDs:    desired.AdminIDs,
+				MemberIDs:   desired.MemberIDs,
+				ReadonlyIDs: desired.ReadonlyIDs,
+			})
+			continue
+		}
+
+		actions, err := w.planRoom(ctx, room, desired, opts)
+		if err != nil {
+			return nil, fmt.Errorf("room %d: %w", room.RoomID, err)
+		}
+		plan.Actions = append(plan.Actions, actions...)
+	}
+	return plan, nil
+}
+
+// planRoom returns the actions for an existing room.
+func (w *Workspace) planRoom(ctx context.Context, room *Room, desired *RoomState, opts []RequestOption) ([]*WorkspaceAction, error) {
+	var actions []*WorkspaceAction
+
+	// Descriptions are not included in the room list.
+	current, _, err := w.client.Rooms.Get(ctx, room.RoomID, opts...)
+	if err != nil {
+		return nil, err
+	}
+	update := &WorkspaceAction{Type: WorkspaceActionUpdate, RoomID: room.RoomID, Name: desired.Name}
+	changed := desired.Name != current.Name
+	if desired.Description != "" && Normalize(desired.Description) != Normalize(current.Description) {
+		update.Description = desired.Description
+		changed = true
+	}
+	if changed {
+		actions = append(actions, update)
+	}
+
+	if len(desired.AdminIDs)+len(desired.MemberIDs)+len(desired.ReadonlyIDs) == 0 {
+		return actions, nil
+	}
+	members, _, err := w.client.Rooms.GetMembers(ctx, room.RoomID, opts...)
+	if err != nil {
+		return nil, err
+	}
+	roles := make(map[AccountID]Role)
+	for _, id := range desired.AdminIDs {
+		roles[id] = RoleAdmin
+	}
+	for _, id := range desired.MemberIDs {
+		roles[id] = RoleMember
+	}
+	for _, id := range desired.ReadonlyIDs {
+		roles[id] = RoleReadonly
+	}
+
+	diff := &WorkspaceAction{
+		Type:        WorkspaceActionMembers,
+		RoomID:      room.RoomID,
+		Name:        desired.Name,
+		AdminIDs:    desired.AdminIDs,
+		MemberIDs:   desired.MemberIDs,
+		ReadonlyIDs: desired.ReadonlyIDs,
+	}
+	present := make(map[AccountID]bool, len(members))
+	for _, m := range members {
+		present[m.AccountID] = true
+		switch role, ok := roles[m.AccountID]; {
+		case !ok:
+			diff.Removed = append(diff.Removed, m.AccountID)
+		case role != m.Role:
+			diff.RoleChanged = append(diff.RoleChanged, m.AccountID)
+		}
+	}
+	for id := range roles {
+		if !present[id] {
+			diff.Added = append(diff.Added, id)
+		}
+	}
+	slices.Sort(diff.Added)
+	slices.Sort(diff.Removed)
+	slices.Sort(diff.RoleChanged)
+	if len(diff.Added)+len(diff.Removed)+len(diff.RoleChanged) > 0 {
+		actions = append(actions, diff)
+	}
+	return actions, nil
+}
+
+// Apply executes the actions of plan in order. It stops at the first
+// failure; running Plan again then yields the remaining changes. Created
+// rooms are recorded in the RoomID of their actions.
+func (w *Workspace) Apply(ctx context.Context, plan *WorkspacePlan, opts ...RequestOption) error {
+	for _, action := range plan.Actions {
+		if err := w.apply(ctx, action, opts); err != nil {
+			return fmt.Errorf("%s %q: %w", action.Type, action.Name, err)
+		}
+		if w.OnAction != nil {
+			w.OnAction(action)
+		}
+	}
+	return nil
+}
+
+func (w *Workspace) apply(ctx context.Context, action *WorkspaceAction, opts []RequestOption) error {
+	switch action.Type {
+	case WorkspaceActionCreate:
+		room, _, err := w.client.Rooms.Create(ctx, &RoomCreateParams{
+			Name:               action.Name,
+			Description:        action.Description,
+			IconPreset:         action.IconPreset,
+			MembersAdminIDs:    action.AdminIDs,
+			MembersMemberIDs:   action.MemberIDs,
+			MembersReadonlyIDs: action.ReadonlyIDs,
+		}, opts...)
+		if err != nil {
+			return err
+		}
+		action.RoomID = room.RoomID
+		return nil
+	case WorkspaceActionUpdate:
+		_, _, err := w.client.Rooms.Update(ctx, action.RoomID, &RoomUpdateParams{
+			Name:        action.Name,
+			Description: action.Description,
+		}, opts...)
+		return err
+	case WorkspaceActionMembers:
+		_, _, err := w.client.Rooms.ReplaceMembers(ctx, action.RoomID, &RoomMembersUpdateParams{
+			MembersAdminIDs:    action.AdminIDs,
+			MembersMemberIDs:   action.MemberIDs,
+			MembersReadonlyIDs: action.ReadonlyIDs,
+		}, opts...)
+		return err
+	default:
+		return fmt.Errorf("chatwork: unknown workspace action type %q", action.Type)
+	}
+}
@@ -0,0 +1,64 @@
+package chatwork_test
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/nashirox/chatwork-go"
+	"github.com/nashirox/chatwork-go/chatworktest"
+)
+
+func TestWorkspace(t *testing.T) {
+	srv := chatworktest.NewServer()
+	defer srv.Close()
+	srv.SetMe(chatwork.Me{AccountID: 1, Name: "Admin"})
+
+	existing := srv.AddRoom(chatwork.Room{Name: "Incidents", Description: "Old"})
+	srv.AddMember(existing, chatwork.Member{AccountID: 2, Role: "member"})
+	srv.AddMember(existing, chatwork.Member{AccountID: 3, Role: "member"})
+
+	state := &chatwork.WorkspaceState{Rooms: []*chatwork.RoomState{
+		{Name: "Incidents", Description: "On-call coordination", AdminIDs: []chatwork.AccountID{1}, MemberIDs: []chatwork.AccountID{2, 4}, ReadonlyIDs: []chatwork.AccountID{}},
+		{Name: "Releases", AdminIDs: []chatwork.AccountID{1}, ReadonlyIDs: []chatwork.AccountID{3}},
+	}}
+
+	ctx := context.Background()
+	workspace := chatwork.NewWorkspace(srv.Client())
+	plan, err := workspace.Plan(ctx, state)
+	if err != nil {
+		t.Fatalf("Plan returned error: %v", err)
+	}
+
+	var types []chatwork.WorkspaceActionType
+	for _, action := range plan.Actions {
+		types = append(types, action.Type)
+	}
+	want := []chatwork.WorkspaceActionType{chatwork.WorkspaceActionUpdate, chatwork.WorkspaceActionMembers, chatwork.WorkspaceActionCreate}
+	if !reflect.DeepEqual(types, want) {
+		t.Fatalf("Expected actions %v, got %v", want, types)
+	}
+	members := plan.Actions[1]
+	if !reflect.DeepEqual(members.Added, []chatwork.AccountID{4}) || !reflect.DeepEqual(members.Removed, []chatwork.AccountID{3}) || members.RoleChanged != nil {
+		t.Errorf("Unexpected member changes: %+v", members)
+	}
+
+	if err := workspace.Apply(ctx, plan); err != nil {
+		t.Fatalf("Apply returned error: %v", err)
+	}
+	if room, _ := srv.Room(existing); room.Description != "On-call coordination" {
+		t.Errorf("Expected the description to be updated, got %q", room.Description)
+	}
+	if plan.Actions[2].RoomID == 0 {
+		t.Error("Expected the created room's ID to be recorded")
+	}
+
+	// Applied plans converge.
+	plan, err = workspace.Plan(ctx, state)
+	if err != nil {
+		t.Fatalf("Plan returned error: %v", err)
+	}
+	if len(plan.Actions) != 0 {
+		t.Errorf("Expected no actions after Apply, got %+v", plan.Actions)
+	}
+}